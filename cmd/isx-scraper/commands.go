@@ -0,0 +1,831 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"isx-auto-scrapper/internal/calendar"
+	"isx-auto-scrapper/internal/common"
+	"isx-auto-scrapper/internal/doctor"
+	"isx-auto-scrapper/internal/indicators"
+	"isx-auto-scrapper/internal/liquidity"
+	"isx-auto-scrapper/internal/numeric"
+	"isx-auto-scrapper/internal/report"
+	"isx-auto-scrapper/internal/risk"
+	"isx-auto-scrapper/internal/scraper"
+	"isx-auto-scrapper/internal/server"
+	"isx-auto-scrapper/internal/strategies"
+)
+
+// newFetchCmd fetches raw price history for the selected tickers
+func newFetchCmd() *cobra.Command {
+	var calc bool
+
+	cmd := &cobra.Command{
+		Use:   "fetch [TICKER...]",
+		Short: "Fetch raw price history from the ISX website into raw_<TICKER>.csv",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			res := newResult("fetch")
+			tickers, err := selectTickers(args)
+			if err != nil {
+				return err
+			}
+			if err := calendar.DefaultError(); err != nil {
+				return res.abort(err)
+			}
+
+			fetched := fetchTickers(tickers, res)
+			if calc && len(fetched) > 0 {
+				calculateTickers(fetched, false, false, res)
+			}
+			return res.finish()
+		},
+	}
+
+	addTickerFlags(cmd)
+	addWorkersFlag(cmd)
+	cmd.Flags().BoolVar(&calc, "calc", false, "Recalculate indicators for the fetched tickers")
+	return cmd
+}
+
+// newCalcCmd calculates technical indicators for the selected tickers
+func newCalcCmd() *cobra.Command {
+	var numeric, full bool
+
+	cmd := &cobra.Command{
+		Use:   "calc [TICKER...]",
+		Short: "Calculate technical indicators into indicators_<TICKER>.csv",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			res := newResult("calc")
+			tickers, err := selectTickers(args)
+			if err != nil {
+				return err
+			}
+
+			calculateTickers(tickers, numeric, full, res)
+			return res.finish()
+		},
+	}
+
+	addTickerFlags(cmd)
+	addWorkersFlag(cmd)
+	cmd.Flags().BoolVar(&numeric, "numeric", false, "Write numeric-only Indicators2_<TICKER>.csv without descriptions")
+	cmd.Flags().BoolVar(&full, "full", false, "Ignore saved indicator state and recalculate the whole history")
+	return cmd
+}
+
+// newIndicatorsCmd lists the registered indicators and the specs calc computes
+func newIndicatorsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "indicators",
+		Short: "List available indicators and the specs calc computes",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			specFile := common.AppConfig.Indicators.SpecFile
+			specs, err := indicators.LoadSpecs(specFile)
+			if err != nil {
+				return &exitError{exitUsage, err}
+			}
+			customFile := common.AppConfig.Indicators.CustomFile
+			custom, err := indicators.LoadCustomIndicators(customFile, specs)
+			if err != nil {
+				return &exitError{exitUsage, err}
+			}
+
+			type definitionInfo struct {
+				Name        string             `json:"name"`
+				Description string             `json:"description"`
+				Params      []indicators.Param `json:"params"`
+				Inputs      []string           `json:"inputs"`
+			}
+			available := make([]definitionInfo, 0)
+			for _, def := range indicators.Definitions() {
+				available = append(available, definitionInfo{def.Name, def.Description, def.Params, def.Inputs})
+			}
+
+			if outputFormat == "json" {
+				res := newResult("indicators")
+				res.Details = map[string]interface{}{
+					"spec_file":   specFile,
+					"specs":       specs,
+					"custom_file": customFile,
+					"custom":      custom,
+					"columns":     indicators.Columns(append(specs, custom...), true),
+					"available":   available,
+				}
+				return res.finish()
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "Active specs (%s):\n", specFile)
+			for _, spec := range specs {
+				fmt.Fprintf(w, "  %s\t%s\n", spec, strings.Join(spec.Columns(), ", "))
+			}
+			if len(custom) > 0 {
+				fmt.Fprintf(w, "\nCustom indicators (%s):\n", customFile)
+				for _, spec := range custom {
+					fmt.Fprintf(w, "  %s\n", spec)
+				}
+			}
+			fmt.Fprintln(w, "\nAvailable indicators:")
+			for _, def := range available {
+				params := make([]string, len(def.Params))
+				for i, param := range def.Params {
+					params[i] = fmt.Sprintf("%s=%s", param.Name, strconv.FormatFloat(param.Default, 'f', -1, 64))
+				}
+				name := def.Name
+				if len(params) > 0 {
+					name += "(" + strings.Join(params, ", ") + ")"
+				}
+				fmt.Fprintf(w, "  %s\t%s\n", name, def.Description)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+// newStrategiesCmd applies the trading strategies and rebuilds Strategy_Summary.json
+func newStrategiesCmd() *cobra.Command {
+	var list bool
+	cmd := &cobra.Command{
+		Use:   "strategies [TICKER...]",
+		Short: "Apply trading strategies into Strategies_<TICKER>.csv and Strategy_Summary.json",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if list {
+				return listStrategies()
+			}
+			res := newResult("strategies")
+			tickers, err := selectTickers(args)
+			if err != nil {
+				return err
+			}
+
+			if err := runStrategies(symbols(tickers), res); err != nil {
+				return res.abort(err)
+			}
+			return res.finish()
+		},
+	}
+
+	addTickerFlags(cmd)
+	cmd.Flags().BoolVar(&list, "list", false, "List the built-in and rule strategies and their parameters instead of running them")
+	return cmd
+}
+
+// listStrategies prints every built-in and rule strategy with the parameters it runs with
+func listStrategies() error {
+	type strategyInfo struct {
+		Name   string             `json:"name"`
+		Params map[string]float64 `json:"params"`
+	}
+	registered, skipped, err := strategies.Configured(common.AppConfig.Strategies)
+	if err != nil {
+		return &exitError{exitUsage, err}
+	}
+	for _, err := range skipped {
+		logger.Warn("%v; leaving the strategy out", err)
+	}
+	infos := make([]strategyInfo, len(registered))
+	for i, strategy := range registered {
+		infos[i] = strategyInfo{strategy.Name(), strategy.Params()}
+	}
+
+	if outputFormat == "json" {
+		res := newResult("strategies")
+		res.Details = map[string]interface{}{"strategies": infos}
+		return res.finish()
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, info := range infos {
+		keys := make([]string, 0, len(info.Params))
+		for key := range info.Params {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		params := make([]string, len(keys))
+		for i, key := range keys {
+			params[i] = fmt.Sprintf("%s=%s", key, strconv.FormatFloat(info.Params[key], 'f', -1, 64))
+		}
+		fmt.Fprintf(w, "  %s\t%s\n", info.Name, strings.Join(params, ", "))
+	}
+	return w.Flush()
+}
+
+// newBacktestCmd backtests the configured strategies
+func newBacktestCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backtest",
+		Short: "Backtest the strategies configured in the backtest section",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			res := newResult("backtest")
+
+			// --from and --to were applied to backtest.start_date and backtest.end_date
+			strategyTester := strategies.NewStrategyTester(logger.WithStage("backtest"))
+			if tickersFlag != "" || sectorFlag != "" {
+				tickers, err := selectTickers(nil)
+				if err != nil {
+					return err
+				}
+				strategyTester.SetTickers(symbols(tickers))
+			}
+
+			if err := strategyTester.SimulateStrategyResults(); err != nil {
+				return res.abort(err)
+			}
+			if err := strategyTester.SummarizeSimulatedStrategyResults(); err != nil {
+				return res.abort(err)
+			}
+
+			res.output("backtest_results.csv")
+			res.output("backtest_results.json")
+			res.output("backtest_summary.json")
+			if data, err := os.ReadFile("backtest_summary.json"); err == nil {
+				var summary interface{}
+				if json.Unmarshal(data, &summary) == nil {
+					res.Details = summary
+				}
+			}
+			return res.finish()
+		},
+	}
+
+	addTickerFlags(cmd)
+	cmd.Flags().String("from", "", "Backtest start date YYYY-MM-DD (overrides backtest.start_date)")
+	cmd.Flags().String("to", "", "Backtest end date YYYY-MM-DD (overrides backtest.end_date)")
+	return cmd
+}
+
+// newLiquidityCmd recomputes liquidity scores
+func newLiquidityCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "liquidity",
+		Short: "Compute liquidity scores into liquidity_scores.csv",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			res := newResult("liquidity")
+			if err := liquidity.NewLiquidityCalc(logger.WithStage("liquidity")).CalculateScores(); err != nil {
+				return res.abort(err)
+			}
+			res.output("liquidity_scores.csv")
+			return res.finish()
+		},
+	}
+}
+
+// newRiskCmd recomputes volatility and risk metrics
+func newRiskCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "risk",
+		Short: "Compute volatility and risk metrics into risk_<TICKER>.csv and risk_summary.csv",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			res := newResult("risk")
+			if err := risk.NewRiskCalc(logger.WithStage("risk")).CalculateAll(); err != nil {
+				return res.abort(err)
+			}
+			res.output(risk.SummaryFile)
+			return res.finish()
+		},
+	}
+}
+
+// newReportCmd builds the daily market report
+func newReportCmd() *cobra.Command {
+	var date string
+	var excel bool
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Build the daily market report (top movers, traded and non-traded companies)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			res := newResult("report")
+			asOf := time.Now()
+			if date != "" {
+				d, err := parseDateFlag("date", date)
+				if err != nil {
+					return err
+				}
+				// Cover the whole requested day, after the session has closed
+				asOf = time.Date(d.Year(), d.Month(), d.Day(), 23, 59, 59, 0, calendar.Baghdad)
+			}
+
+			rep, err := report.GenerateDailyReport(asOf, common.AppConfig.Language)
+			if err != nil {
+				return res.abort(err)
+			}
+			res.output(fmt.Sprintf("traded_%s.csv", rep.Date))
+			res.output(fmt.Sprintf("non_traded_%s.csv", rep.Date))
+
+			if excel {
+				filename := fmt.Sprintf("Daily_Report_%s.xlsx", rep.Date)
+				if err := report.SaveDailyReportExcel(rep, filename); err != nil {
+					return res.abort(err)
+				}
+				res.output(filename)
+			}
+
+			if rep.Date != rep.ExpectedSession {
+				logger.Info("Latest data is from %s but the last completed session is %s", rep.Date, rep.ExpectedSession)
+			}
+			logger.Info("Daily report for %s: %d traded, %d not traded, %d divergences", rep.Date, len(rep.Traded), len(rep.NonTraded), len(rep.Divergences))
+			res.Details = rep
+			return res.finish()
+		},
+	}
+
+	cmd.Flags().StringVar(&date, "date", "", "Report date YYYY-MM-DD (default: today)")
+	cmd.Flags().BoolVar(&excel, "excel", false, "Also write Daily_Report_<date>.xlsx")
+	return cmd
+}
+
+// newServeCmd starts the web dashboard
+func newServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Start the interactive web dashboard",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// --port and --bind were applied to server.port and server.bind
+			webServer := server.NewWebServer(logger.WithStage("serve"), common.AppConfig.Server.Bind, common.AppConfig.Server.Port)
+			logger.Info("Starting ISX Auto Scrapper Web Dashboard...")
+			if err := webServer.Start(); err != nil {
+				return &exitError{exitFailure, fmt.Errorf("web server failed: %w", err)}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Int("port", 8080, "HTTP port to listen on (overrides server.port)")
+	cmd.Flags().String("bind", "", "Address to bind to (overrides server.bind; default: all interfaces)")
+	return cmd
+}
+
+// newAutoCmd runs the full pipeline: fetch, indicators, liquidity, risk and strategies
+func newAutoCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auto [TICKER...]",
+		Short: "Run the full pipeline: fetch, calc, liquidity, risk and strategies",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			res := newResult("auto")
+			tickers, err := selectTickers(args)
+			if err != nil {
+				return err
+			}
+			if err := calendar.DefaultError(); err != nil {
+				return res.abort(err)
+			}
+
+			fetched := fetchTickers(tickers, res)
+			if len(fetched) == 0 {
+				return res.finish()
+			}
+
+			calculateTickers(fetched, false, false, res)
+
+			// Run additional analysis only for successful downloads
+			logger.Info("Running additional analysis...")
+			if err := liquidity.NewLiquidityCalc(logger.WithStage("liquidity")).CalculateScores(); err != nil {
+				logger.Error("Failed to calculate liquidity scores: %v", err)
+				res.stageFailed("liquidity", err)
+			} else {
+				res.output("liquidity_scores.csv")
+			}
+			if err := risk.NewRiskCalc(logger.WithStage("risk")).CalculateAll(); err != nil {
+				logger.Error("Failed to calculate risk metrics: %v", err)
+				res.stageFailed("risk", err)
+			} else {
+				res.output(risk.SummaryFile)
+			}
+			if err := runStrategies(symbols(fetched), res); err != nil {
+				logger.Error("Failed to apply strategies: %v", err)
+				res.stageFailed("strategies", err)
+			}
+			return res.finish()
+		},
+	}
+
+	addTickerFlags(cmd)
+	addWorkersFlag(cmd)
+	return cmd
+}
+
+// newGapsCmd compares raw data against the ISX trading calendar
+func newGapsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gaps",
+		Short: "Report missing sessions in raw_*.csv against the ISX trading calendar",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			res := newResult("gaps")
+			tickers, err := selectTickers(nil)
+			if err != nil {
+				return err
+			}
+			if err := calendar.DefaultError(); err != nil {
+				return res.abort(err)
+			}
+
+			reports, closures, err := scraper.GenerateGapReport(tickers, calendar.Default(), time.Now())
+			if err != nil {
+				return res.abort(err)
+			}
+
+			timestamp := time.Now().Format("2006-01-02_15-04-05")
+			gapFilename := fmt.Sprintf("Gap_Report_%s.csv", timestamp)
+			if err := scraper.SaveGapReport(reports, gapFilename); err != nil {
+				return res.abort(fmt.Errorf("failed to save gap report: %w", err))
+			}
+			res.output(gapFilename)
+
+			counts := make(map[string]int)
+			for _, r := range reports {
+				counts[r.Status]++
+			}
+			logger.Info("Summary: %d complete, %d with gaps, %d stale, %d without data",
+				counts["COMPLETE"], counts["GAPS"], counts["STALE"], counts["NO_DATA"])
+
+			logger.Info("%s covers %s; days without trades in other years are not reported as closures",
+				calendar.HolidaysFile, calendar.Default().CoveredYears())
+			if len(closures) > 0 {
+				closuresFilename := fmt.Sprintf("Market_Closures_%s.csv", timestamp)
+				if err := scraper.SaveMarketClosures(closures, closuresFilename); err != nil {
+					logger.Error("Failed to save market closures: %v", err)
+				} else {
+					res.output(closuresFilename)
+					logger.Info("%d trading days had no trades in any ticker; review %s and add holidays to %s",
+						len(closures), closuresFilename, calendar.HolidaysFile)
+				}
+			}
+
+			res.Details = map[string]interface{}{
+				"status_counts":   counts,
+				"market_closures": len(closures),
+				"holiday_years":   calendar.Default().CoveredYears(),
+			}
+			return res.finish()
+		},
+	}
+
+	addTickerFlags(cmd)
+	return cmd
+}
+
+// newDoctorCmd checks freshness and consistency of every generated file
+func newDoctorCmd() *cobra.Command {
+	var fix bool
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check all generated files for freshness and consistency",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			res := newResult("doctor")
+			doc := doctor.NewDoctor(logger.WithStage("doctor"))
+			diag, err := doc.Diagnose()
+			if err != nil {
+				return res.abort(err)
+			}
+
+			if !diag.Healthy() && fix {
+				if outputFormat == "text" {
+					diag.PrintTable(os.Stdout)
+				}
+				if err := doc.Fix(diag); err != nil {
+					return res.abort(fmt.Errorf("doctor fix failed: %w", err))
+				}
+				if diag, err = doc.Diagnose(); err != nil {
+					return res.abort(err)
+				}
+			}
+
+			if outputFormat == "text" {
+				diag.PrintTable(os.Stdout)
+			}
+			res.Details = diag
+
+			if !diag.Healthy() {
+				if !fix {
+					logger.Info("Problems found; run with --fix to re-run the stale stages")
+				}
+				res.ExitCode = exitUnhealthy
+			}
+			return res.finish()
+		},
+	}
+
+	cmd.Flags().BoolVar(&fix, "fix", false, "Re-run the stale pipeline stages")
+	return cmd
+}
+
+// newBenchCmd times the float64 engine and checks it against the decimal reference results
+func newBenchCmd() *cobra.Command {
+	var window int
+	var tolerance float64
+
+	cmd := &cobra.Command{
+		Use:   "bench [TICKER...]",
+		Short: "Time the float64 indicator engine and check it against decimal reference results",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if window < 1 {
+				return &exitError{exitUsage, fmt.Errorf("--window must be at least 1, got %d", window)}
+			}
+			res := newResult("bench")
+			tickers, err := selectTickers(args)
+			if err != nil {
+				return err
+			}
+			specs, err := indicators.ConfiguredSpecs()
+			if err != nil {
+				return &exitError{exitUsage, err}
+			}
+
+			benchLogger := logger.WithStage("bench")
+			var checks []*numeric.Check
+			var engineTime time.Duration
+			bars := 0
+			for _, ticker := range tickers {
+				frame, err := indicators.LoadRawFrame(fmt.Sprintf("raw_%s.csv", ticker.Symbol))
+				if err != nil {
+					benchLogger.WithTicker(ticker.Symbol).Info("Skipping: %v", err)
+					continue
+				}
+				bars += frame.Len()
+
+				closes := make([]decimal.Decimal, frame.Len())
+				for i, price := range frame.Close {
+					closes[i] = numeric.Decimal(price)
+				}
+				for k, check := range numeric.CheckSeries("Close", closes, window, tolerance) {
+					if k == len(checks) {
+						checks = append(checks, &numeric.Check{Name: check.Name, Passed: true})
+					}
+					total := checks[k]
+					total.Points += check.Points
+					total.MaxError = math.Max(total.MaxError, check.MaxError)
+					total.FastTime += check.FastTime
+					total.ReferenceTime += check.ReferenceTime
+					total.Passed = total.Passed && check.Passed
+				}
+
+				start := time.Now()
+				if _, err := indicators.ApplySpecs(frame, specs); err != nil {
+					return res.abort(err)
+				}
+				engineTime += time.Since(start)
+			}
+			if bars == 0 {
+				return res.abort(fmt.Errorf("no raw data found for the selected tickers"))
+			}
+
+			passed := true
+			for _, check := range checks {
+				passed = passed && check.Passed
+			}
+
+			if outputFormat == "text" {
+				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+				fmt.Fprintf(w, "Window %d, tolerance %g, %d bars\n", window, tolerance, bars)
+				fmt.Fprintln(w, "Check\tMax error\tfloat64\tdecimal\tSpeedup\tResult")
+				for _, check := range checks {
+					result := "ok"
+					if !check.Passed {
+						result = "FAIL"
+					}
+					fmt.Fprintf(w, "%s\t%.3g\t%s\t%s\t%.0fx\t%s\n", check.Name, check.MaxError,
+						check.FastTime.Round(time.Microsecond), check.ReferenceTime.Round(time.Microsecond), check.Speedup(), result)
+				}
+				fmt.Fprintf(w, "All %d indicator specs over %d bars\t\t%s\n", len(specs), bars, engineTime.Round(time.Microsecond))
+				if err := w.Flush(); err != nil {
+					return err
+				}
+			}
+			res.Details = map[string]interface{}{
+				"window":            window,
+				"tolerance":         tolerance,
+				"bars":              bars,
+				"checks":            checks,
+				"indicator_time_ns": engineTime,
+			}
+
+			if !passed {
+				logger.Error("The float64 engine is outside the tolerance of the decimal reference")
+				res.ExitCode = exitUnhealthy
+			}
+			return res.finish()
+		},
+	}
+
+	addTickerFlags(cmd)
+	cmd.Flags().IntVar(&window, "window", 20, "Rolling window length of the checks")
+	cmd.Flags().Float64Var(&tolerance, "tolerance", 1e-6, "Largest accepted error: absolute below 1, relative above; files keep 2 to 4 decimals")
+	return cmd
+}
+
+// newConfigCmd groups the configuration subcommands
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the effective configuration",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "show",
+		Short: "Print the effective configuration after file, environment and flag overrides",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := common.AppConfig
+			if outputFormat == "json" {
+				res := newResult("config show")
+				res.Details = map[string]interface{}{
+					"sources": cfg.Sources,
+					"config":  cfg,
+				}
+				return res.finish()
+			}
+
+			// Plain YAML on stdout so it can be saved as a starting config file
+			fmt.Printf("# Sources (lowest precedence first): %s\n", strings.Join(cfg.Sources, ", "))
+			encoder := yaml.NewEncoder(os.Stdout)
+			encoder.SetIndent(2)
+			if err := encoder.Encode(cfg); err != nil {
+				return &exitError{exitFailure, err}
+			}
+			return encoder.Close()
+		},
+	})
+
+	return cmd
+}
+
+// fetchTickers fetches raw data with processing reports and returns the tickers that succeeded
+func fetchTickers(tickers []common.TickerInfo, res *commandResult) []common.TickerInfo {
+	n := len(tickers)
+	stageLogger := logger.WithStage("fetch")
+
+	reports := make([]*common.ProcessingReport, n)
+	timings := make([]*common.TimingReport, n)
+	ok := make([]bool, n)
+
+	stageLogger.Info("Fetching %d tickers with %d workers", n, workerCount(n))
+	runParallel(n, func(i int) {
+		tickerInfo := tickers[i]
+		tickerLogger := stageLogger.WithTicker(tickerInfo.Symbol)
+		dataFetcher := scraper.NewDataFetcher(tickerLogger)
+		tickerLogger.Info("Processing %s (%d/%d) - %s", tickerInfo.Symbol, i+1, n, tickerInfo.CompanyName)
+
+		report, err := dataFetcher.FetchDataWithReport(tickerInfo.Symbol, tickerInfo.Sector, tickerInfo.CompanyName)
+		if err != nil {
+			tickerLogger.Error("Failed to fetch data for %s: %v", tickerInfo.Symbol, err)
+			dataFetcher.FinalizeReport(err)
+			if current := dataFetcher.CurrentReport(); current != nil {
+				report = current
+			} else if report == nil {
+				report = &common.ProcessingReport{
+					Ticker:           tickerInfo.Symbol,
+					Sector:           tickerInfo.Sector,
+					CompanyName:      tickerInfo.CompanyName,
+					Status:           "ERROR",
+					ErrorMessage:     err.Error(),
+					Recommendation:   "Manually check ticker - failed to fetch data. Consider removing from tickers file if consistently failing.",
+					DataQualityScore: "FAILED",
+				}
+			}
+			res.fail(tickerInfo.Symbol, err)
+		} else {
+			ok[i] = true
+			res.succeed(tickerInfo.Symbol)
+		}
+
+		if report != nil {
+			r := *report
+			reports[i] = &r
+		}
+		if timing := dataFetcher.GetTimingReport(); timing != nil {
+			t := *timing
+			timings[i] = &t
+		}
+	})
+
+	var processingReports []common.ProcessingReport
+	var timingReports []common.TimingReport
+	var fetched []common.TickerInfo
+	statusCounts := make(map[string]int)
+	for i := range tickers {
+		if reports[i] != nil {
+			processingReports = append(processingReports, *reports[i])
+			statusCounts[reports[i].Status]++
+		}
+		if timings[i] != nil {
+			timingReports = append(timingReports, *timings[i])
+		}
+		if ok[i] {
+			fetched = append(fetched, tickers[i])
+		}
+	}
+
+	timestamp := time.Now().Format("2006-01-02_15-04-05")
+	reportFilename := fmt.Sprintf("Processing_Report_%s.csv", timestamp)
+	if err := scraper.SaveProcessingReport(processingReports, reportFilename); err != nil {
+		logger.Error("Failed to save processing report: %v", err)
+	} else {
+		res.output(reportFilename)
+	}
+
+	timingFilename := fmt.Sprintf("Timing_Analysis_%s.csv", timestamp)
+	if err := scraper.SaveTimingReport(timingReports, timingFilename); err != nil {
+		logger.Error("Failed to save timing report: %v", err)
+	} else {
+		res.output(timingFilename)
+	}
+
+	logger.Info("Fetch summary: %d successful, %d up-to-date, %d partial, %d errors",
+		statusCounts["SUCCESS"], statusCounts["UP_TO_DATE"], statusCounts["PARTIAL"], statusCounts["ERROR"])
+	res.Details = map[string]interface{}{"fetch_status": statusCounts}
+
+	return fetched
+}
+
+// calculateTickers calculates indicators for every ticker and timeframe in parallel
+func calculateTickers(tickers []common.TickerInfo, numeric, full bool, res *commandResult) {
+	n := len(tickers)
+	stageLogger := logger.WithStage("calc")
+
+	runParallel(n, func(i int) {
+		ticker := tickers[i].Symbol
+		tickerLogger := stageLogger.WithTicker(ticker)
+		tickerLogger.Info("Calculating indicators for %s (%d/%d)", ticker, i+1, n)
+
+		for _, tf := range indicators.ConfiguredTimeframes() {
+			calculator := indicators.NewIndicatorsCalculator(tickerLogger)
+			calculator.SetFullRecompute(full)
+			calculator.SetTimeframe(tf)
+			calculate := calculator.CalculateAll
+			if numeric {
+				numerical := indicators.NewNumericalIndicatorsCalculator(tickerLogger)
+				numerical.SetFullRecompute(full)
+				numerical.SetTimeframe(tf)
+				calculate = numerical.CalculateAllNums
+			}
+
+			if err := calculate(ticker); err != nil {
+				tickerLogger.Error("Failed to calculate %s indicators for %s: %v", tf.Name(), ticker, err)
+				res.fail(ticker, err)
+				return
+			}
+		}
+		res.succeed(ticker)
+	})
+
+	// Relative strength compares every ticker with the rest, so it is rebuilt for the whole universe
+	if err := indicators.NewRelativeStrengthCalc(stageLogger).CalculateAll(); err != nil {
+		stageLogger.Error("Failed to calculate relative strength: %v", err)
+		res.stageFailed("relative strength", err)
+	} else {
+		res.output(indicators.MarketIndexFile)
+	}
+}
+
+// runStrategies applies strategies to the given tickers and rebuilds the summary for all tickers; a ticker
+// the strategies or the consensus leave out on any timeframe counts as failed
+func runStrategies(tickers []string, res *commandResult) error {
+	failed := make(map[string]bool)
+	for _, tf := range indicators.ConfiguredTimeframes() {
+		stratService := strategies.NewStrategies(logger.WithStage("strategies"))
+		stratService.SetTimeframe(tf)
+		if err := stratService.ApplyStrategiesAndSaveFor(tickers); err != nil {
+			return err
+		}
+		if err := stratService.ApplyConsensusFor(tickers); err != nil {
+			return err
+		}
+		for ticker, err := range stratService.Failed() {
+			res.fail(ticker, fmt.Errorf("%s strategies: %w", tf.Name(), err))
+			failed[ticker] = true
+		}
+		if err := stratService.SummarizeStrategyActions(); err != nil {
+			return err
+		}
+		res.output(fmt.Sprintf("Strategy_Summary%s.json", tf.Suffix()))
+		res.output(strategies.SignalEventsFile(tf))
+	}
+	for _, ticker := range tickers {
+		if !failed[ticker] {
+			res.succeed(ticker)
+		}
+	}
+	return nil
+}
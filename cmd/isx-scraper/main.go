@@ -1,288 +1,395 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"io"
 	"os"
-	"strconv"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"isx-auto-scrapper/internal/common"
-	"isx-auto-scrapper/internal/indicators"
-	"isx-auto-scrapper/internal/liquidity"
-	"isx-auto-scrapper/internal/scraper"
-	"isx-auto-scrapper/internal/server"
-	"isx-auto-scrapper/internal/strategies"
 )
 
+// Exit codes returned by the CLI so cron jobs and CI can react to the outcome
+const (
+	exitOK        = 0 // command succeeded
+	exitFailure   = 1 // command failed
+	exitUsage     = 2 // invalid command, flag or argument
+	exitPartial   = 3 // some tickers failed, the rest succeeded
+	exitUnhealthy = 4 // doctor found problems it did not fix, or bench exceeded its tolerance
+)
+
+// configFlags maps command line flags to the config keys they override
+var configFlags = map[string]string{
+	"workers": "workers",
+	"port":    "server.port",
+	"bind":    "server.bind",
+	"from":    "backtest.start_date",
+	"to":      "backtest.end_date",
+	"lang":    "language",
+}
+
 var (
-	mode string
+	outputFormat string
+	configFile   string
+	configSets   []string
+	tickersFlag  string
+	sectorFlag   string
+	workers      int
+
+	// started is set once flag validation passed and a command began running
+	started   bool
+	logger    *common.Logger
+	logCloser io.Closer
 )
 
+// exitError carries the process exit code of a failed command
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
 func main() {
-	var rootCmd = &cobra.Command{
-		Use:   "isx-auto-scrapper",
+	rootCmd := &cobra.Command{
+		Use:   "isx-scraper",
 		Short: "ISX Auto Scrapper - Iraq Stock Exchange Data Analysis Tool",
-		Long: `A comprehensive tool for scraping, analyzing, and backtesting 
+		Long: `A comprehensive tool for scraping, analyzing, and backtesting
 Iraq Stock Exchange (ISX) stock data with technical indicators and trading strategies.`,
-		Run: runApp,
-	}
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if outputFormat != "text" && outputFormat != "json" {
+				return fmt.Errorf("invalid --output %q: use text or json", outputFormat)
+			}
+			if err := loadConfig(cmd); err != nil {
+				return err
+			}
+			workers = common.AppConfig.Workers
 
-	rootCmd.Flags().StringVar(&mode, "mode", "", "The mode to run the script in (required)")
-	rootCmd.MarkFlagRequired("mode")
+			console := io.Writer(os.Stdout)
+			if outputFormat == "json" {
+				// Keep stdout clean for the JSON summary
+				console = os.Stderr
+			}
+			var err error
+			logger, logCloser, err = common.SetupLogging(common.AppConfig.Log, console)
+			if err != nil {
+				return err
+			}
+			started = true
+			return nil
+		},
+	}
 
-	// Add mode validation
-	cobra.CheckErr(rootCmd.Execute())
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "text", "Summary format: text or json")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "Config file (default: $ISX_CONFIG or "+common.DefaultConfigFile+")")
+	rootCmd.PersistentFlags().StringArrayVar(&configSets, "set", nil, "Override a config value, e.g. --set server.port=9090 (repeatable)")
+	rootCmd.PersistentFlags().String("lang", "en", "Language of indicator descriptions and reports: en or ar (overrides language)")
+
+	rootCmd.AddCommand(
+		newFetchCmd(),
+		newCalcCmd(),
+		newIndicatorsCmd(),
+		newStrategiesCmd(),
+		newBacktestCmd(),
+		newLiquidityCmd(),
+		newRiskCmd(),
+		newReportCmd(),
+		newServeCmd(),
+		newAutoCmd(),
+		newGapsCmd(),
+		newDoctorCmd(),
+		newBenchCmd(),
+		newConfigCmd(),
+	)
+
+	err := rootCmd.Execute()
+	if logCloser != nil {
+		logCloser.Close()
+	}
+	if err != nil {
+		code := exitFailure
+		var exitErr *exitError
+		if errors.As(err, &exitErr) {
+			code = exitErr.code
+		} else if !started {
+			code = exitUsage
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if code == exitUsage {
+			fmt.Fprintln(os.Stderr, "Run 'isx-scraper --help' for usage.")
+		}
+		os.Exit(code)
+	}
 }
 
-func runApp(cmd *cobra.Command, args []string) {
-	logger := common.NewLogger()
-
-	// Initialize components
-	dataFetcher := scraper.NewDataFetcher()
-	indicatorsCalculator := indicators.NewIndicatorsCalculator()
-	liquidityCalc := liquidity.NewLiquidityCalc()
-	stratService := strategies.NewStrategies()
-	strategyTester := strategies.NewStrategyTester()
-
-	switch mode {
-	case "web":
-		// Start web dashboard
-		port := 8080
-		if len(args) > 0 {
-			if p, err := strconv.Atoi(args[0]); err == nil && p > 0 && p < 65536 {
-				port = p
-			}
+// loadConfig builds common.AppConfig from the config file, ISX_* variables, --set and command flags
+func loadConfig(cmd *cobra.Command) error {
+	path, required := configFile, true
+	if path == "" {
+		path = os.Getenv("ISX_CONFIG")
+	}
+	if path == "" {
+		path, required = common.DefaultConfigFile, false
+	}
+
+	// Dedicated flags win over --set when both are given
+	overrides := append([]string{}, configSets...)
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if key, ok := configFlags[f.Name]; ok {
+			overrides = append(overrides, key+"="+f.Value.String())
 		}
+	})
 
-		webServer := server.NewWebServer(port)
-		logger.Info("Starting ISX Auto Scrapper Web Dashboard...")
-		logger.Info("Open your browser and navigate to: http://localhost:%d", port)
+	cfg, err := common.LoadConfig(path, required, overrides)
+	if err != nil {
+		return err
+	}
+	common.AppConfig = cfg
+	return nil
+}
+
+// addTickerFlags registers the --tickers and --sector selection flags
+func addTickerFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&tickersFlag, "tickers", "", "Comma-separated ticker symbols (default: all in TICKERS.csv)")
+	cmd.Flags().StringVar(&sectorFlag, "sector", "", "Only process tickers in this sector")
+}
+
+// addWorkersFlag registers the --workers concurrency flag
+func addWorkersFlag(cmd *cobra.Command) {
+	cmd.Flags().Int("workers", 1, "Number of tickers processed in parallel (overrides workers)")
+}
 
-		if err := webServer.Start(); err != nil {
-			logger.Error("Web server failed to start: %v", err)
-			os.Exit(1)
+// selectTickers resolves positional arguments, --tickers and --sector against TICKERS.csv
+func selectTickers(args []string) ([]common.TickerInfo, error) {
+	var requested []string
+	for _, arg := range append(args, strings.Split(tickersFlag, ",")...) {
+		if symbol := strings.ToUpper(strings.TrimSpace(arg)); symbol != "" {
+			requested = append(requested, symbol)
 		}
+	}
 
-	case "single":
-		fmt.Print("Enter the ticker: ")
-		var ticker string
-		fmt.Scanln(&ticker)
+	all, loadErr := common.LoadTickersWithInfo("TICKERS.csv")
+	if loadErr != nil && len(requested) == 0 {
+		return nil, fmt.Errorf("failed to load tickers: %w", loadErr)
+	}
 
-		err := dataFetcher.FetchData(ticker)
-		if err != nil {
-			logger.Error("Failed to fetch data for ticker %s: %v", ticker, err)
-			os.Exit(1)
+	var tickers []common.TickerInfo
+	if len(requested) > 0 {
+		known := make(map[string]common.TickerInfo, len(all))
+		for _, t := range all {
+			known[t.Symbol] = t
+		}
+		for _, symbol := range requested {
+			if info, ok := known[symbol]; ok {
+				tickers = append(tickers, info)
+			} else {
+				// Tickers missing from TICKERS.csv can still be fetched by symbol
+				tickers = append(tickers, common.TickerInfo{Symbol: symbol})
+			}
 		}
+	} else {
+		tickers = all
+	}
 
-	case "auto":
-		tickers, err := common.LoadTickersWithInfo("TICKERS.csv")
-		if err != nil {
-			logger.Error("Failed to load tickers: %v", err)
-			os.Exit(1)
+	if sectorFlag != "" {
+		var filtered []common.TickerInfo
+		for _, t := range tickers {
+			if strings.EqualFold(t.Sector, sectorFlag) {
+				filtered = append(filtered, t)
+			}
 		}
+		tickers = filtered
+	}
 
-		numTickers := len(tickers)
-		var reports []common.ProcessingReport
-		var timingReports []common.TimingReport
+	if len(tickers) == 0 {
+		return nil, &exitError{exitUsage, fmt.Errorf("no tickers match the selection")}
+	}
 
-		logger.Info("Starting auto mode processing for %d tickers", numTickers)
-		overallStartTime := time.Now()
+	return tickers, nil
+}
 
-		for i, tickerInfo := range tickers {
-			logger.Info("Processing %s (%d/%d) - %s", tickerInfo.Symbol, i+1, numTickers, tickerInfo.CompanyName)
+// symbols returns the ticker symbols of a selection
+func symbols(tickers []common.TickerInfo) []string {
+	out := make([]string, len(tickers))
+	for i, t := range tickers {
+		out[i] = t.Symbol
+	}
+	return out
+}
 
-			// Fetch data with detailed reporting
-			report, err := dataFetcher.FetchDataWithReport(tickerInfo.Symbol, tickerInfo.Sector, tickerInfo.CompanyName)
-			if err != nil {
-				logger.Error("Failed to fetch data for %s: %v", tickerInfo.Symbol, err)
-				// Create error report
-				if report == nil {
-					report = &common.ProcessingReport{
-						Ticker:           tickerInfo.Symbol,
-						Sector:           tickerInfo.Sector,
-						CompanyName:      tickerInfo.CompanyName,
-						Status:           "ERROR",
-						ErrorMessage:     err.Error(),
-						Recommendation:   "Manually check ticker - failed to fetch data. Consider removing from tickers file if consistently failing.",
-						DataQualityScore: "FAILED",
-					}
-				}
-				dataFetcher.FinalizeReport(err)
-				report = dataFetcher.CurrentReport()
-			}
+// workerCount caps the configured workers to the amount of work
+func workerCount(n int) int {
+	if workers > n {
+		return n
+	}
+	return workers
+}
 
-			if report != nil {
-				reports = append(reports, *report)
+// runParallel calls fn for every index in [0, n) using up to --workers goroutines
+func runParallel(n int, fn func(i int)) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workerCount(n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
 			}
+		}()
+	}
 
-			// Collect timing report
-			if timingReport := dataFetcher.GetTimingReport(); timingReport != nil {
-				timingReports = append(timingReports, *timingReport)
-			}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
 
-			// Continue with calculations if data was fetched successfully
-			if err == nil {
-				if calcErr := indicatorsCalculator.CalculateAll(tickerInfo.Symbol); calcErr != nil {
-					logger.Error("Failed to calculate indicators for %s: %v", tickerInfo.Symbol, calcErr)
-				}
+// parseDateFlag parses a YYYY-MM-DD flag value; an empty value gives the zero time
+func parseDateFlag(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, &exitError{exitUsage, fmt.Errorf("invalid --%s %q: use YYYY-MM-DD", name, value)}
+	}
+	return t, nil
+}
 
-			}
-		}
+// commandResult is the summary of a command, printed as JSON with --output json
+type commandResult struct {
+	Command   string            `json:"command"`
+	Status    string            `json:"status"`
+	ExitCode  int               `json:"exit_code"`
+	Duration  string            `json:"duration"`
+	Error     string            `json:"error,omitempty"`
+	Succeeded []string          `json:"succeeded,omitempty"`
+	Failed    map[string]string `json:"failed,omitempty"`
+	Outputs   []string          `json:"outputs,omitempty"`
+	Details   interface{}       `json:"details,omitempty"`
+
+	start time.Time
+	mu    sync.Mutex
+}
 
-		// Save processing report
-		reportFilename := fmt.Sprintf("Processing_Report_%s.csv", time.Now().Format("2006-01-02_15-04-05"))
-		if err := scraper.SaveProcessingReport(reports, reportFilename); err != nil {
-			logger.Error("Failed to save processing report: %v", err)
-		} else {
-			logger.Info("Processing report saved to %s", reportFilename)
-		}
+// newResult starts timing a command
+func newResult(command string) *commandResult {
+	return &commandResult{
+		Command: command,
+		Failed:  make(map[string]string),
+		start:   time.Now(),
+	}
+}
 
-		// Save timing analysis report
-		timingFilename := fmt.Sprintf("Timing_Analysis_%s.csv", time.Now().Format("2006-01-02_15-04-05"))
-		if err := scraper.SaveTimingReport(timingReports, timingFilename); err != nil {
-			logger.Error("Failed to save timing report: %v", err)
-		} else {
-			logger.Info("Timing analysis report saved to %s", timingFilename)
-		}
+// succeed records a ticker that was processed successfully
+func (r *commandResult) succeed(ticker string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Succeeded = append(r.Succeeded, ticker)
+}
 
-		// Generate summary statistics
-		totalProcessed := len(reports)
-		successful := 0
-		errors := 0
-		partial := 0
-		upToDate := 0
-
-		// Performance statistics
-		var totalProcessingTime time.Duration
-		var excellentPerf, goodPerf, avgPerf, poorPerf int
-		var totalPages int
-
-		for _, report := range reports {
-			switch report.Status {
-			case "SUCCESS":
-				successful++
-			case "ERROR":
-				errors++
-			case "PARTIAL":
-				partial++
-			case "UP_TO_DATE":
-				upToDate++
-			}
-		}
+// fail records a ticker that could not be processed
+func (r *commandResult) fail(ticker string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Failed[ticker] = err.Error()
+}
 
-		for _, timing := range timingReports {
-			totalProcessingTime += timing.TotalProcessingTime
-			totalPages += timing.PagesProcessed
-
-			switch timing.PerformanceScore {
-			case "EXCELLENT":
-				excellentPerf++
-			case "GOOD":
-				goodPerf++
-			case "AVERAGE":
-				avgPerf++
-			case "POOR":
-				poorPerf++
-			}
-		}
+// output records a file written by the command
+func (r *commandResult) output(filename string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Outputs = append(r.Outputs, filename)
+}
 
-		overallDuration := time.Since(overallStartTime)
-		avgProcessingTime := time.Duration(0)
-		if len(timingReports) > 0 {
-			avgProcessingTime = totalProcessingTime / time.Duration(len(timingReports))
-		}
+// stageFailed records a stage that failed as a whole, such as relative strength across all tickers;
+// the command finishes as partial at best
+func (r *commandResult) stageFailed(stage string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Error = fmt.Sprintf("%s: %v", stage, err)
+	if r.ExitCode == exitOK {
+		r.ExitCode = exitPartial
+	}
+}
+
+// abort finishes the command as failed because of err
+func (r *commandResult) abort(err error) error {
+	r.Error = err.Error()
+	r.ExitCode = exitFailure
+	return r.finish()
+}
 
-		logger.Info("Auto mode completed in %s", overallDuration.String())
-		logger.Info("Summary: %d total, %d successful, %d up-to-date, %d partial, %d errors",
-			totalProcessed, successful, upToDate, partial, errors)
-		logger.Info("Performance: %d excellent, %d good, %d average, %d poor",
-			excellentPerf, goodPerf, avgPerf, poorPerf)
-		logger.Info("Timing: Total pages processed: %d, Average processing time per ticker: %s",
-			totalPages, avgProcessingTime.String())
-
-		// Run additional analysis only for successful downloads
-		if successful > 0 {
-			logger.Info("Running additional analysis...")
-			liquidityCalc.CalculateScores()
-			stratService.ApplyStrategiesAndSave()
-			stratService.ApplyAlternativeStrategyStates()
-			stratService.SummarizeStrategyActions()
+// finish prints the summary and returns an exitError when the exit code is not zero
+func (r *commandResult) finish() error {
+	// A ticker that failed any stage counts as failed, and each ticker is listed once
+	seen := make(map[string]bool)
+	var succeeded []string
+	for _, ticker := range r.Succeeded {
+		if _, failed := r.Failed[ticker]; !failed && !seen[ticker] {
+			seen[ticker] = true
+			succeeded = append(succeeded, ticker)
 		}
+	}
+	sort.Strings(succeeded)
+	r.Succeeded = succeeded
+	r.Duration = time.Since(r.start).Round(time.Millisecond).String()
 
-	case "liquidity":
-		liquidityCalc.CalculateScores()
-
-	case "strategies":
-		stratService.ApplyStrategiesAndSave()
-		stratService.ApplyAlternativeStrategyStates()
-		stratService.SummarizeStrategyActions()
-
-	case "simulate":
-		strategyTester.SimulateStrategyResults()
-		strategyTester.SummarizeSimulatedStrategyResults()
-
-	case "calculate":
-		// Calculate indicators for a single ticker (with descriptions)
-		if len(args) > 0 {
-			// Calculate indicators for a single ticker
-			ticker := args[0]
-			logger.Info("Calculating full indicators for ticker %s", ticker)
-			if err := indicatorsCalculator.CalculateAll(ticker); err != nil {
-				logger.Error("Failed to calculate indicators for %s: %v", ticker, err)
-				os.Exit(1)
-			}
-			logger.Info("Indicators calculation completed for %s", ticker)
+	if (r.ExitCode == exitOK || r.ExitCode == exitPartial) && len(r.Failed) > 0 {
+		if len(r.Succeeded) > 0 {
+			r.ExitCode = exitPartial
 		} else {
-			// Calculate indicators for all tickers
-			tickers, err := common.LoadTickers("TICKERS.csv")
-			if err != nil {
-				logger.Error("Failed to load tickers: %v", err)
-				os.Exit(1)
-			}
-
-			numTickers := len(tickers)
-			for i, ticker := range tickers {
-				logger.Info("Calculating full indicators for %s (%d/%d)", ticker, i+1, numTickers)
-				if err := indicatorsCalculator.CalculateAll(ticker); err != nil {
-					logger.Error("Failed to calculate indicators for %s: %v", ticker, err)
-				}
-			}
+			r.ExitCode = exitFailure
 		}
+	}
 
-	case "calculate_num":
-		// Use the dedicated NumericalIndicatorsCalculator for numerical calculations
-		numericalIndicatorsCalculator := indicators.NewNumericalIndicatorsCalculator()
-
-		if len(args) > 0 {
-			// Calculate numerical indicators for a single ticker
-			ticker := args[0]
-			logger.Info("Calculating numerical indicators for ticker %s", ticker)
-			if err := numericalIndicatorsCalculator.CalculateAllNums(ticker); err != nil {
-				logger.Error("Failed to calculate indicators for %s: %v", ticker, err)
-				os.Exit(1)
-			}
-			logger.Info("Numerical indicators calculation completed for %s", ticker)
-		} else {
-			// Calculate numerical indicators for all tickers
-			tickers, err := common.LoadTickers("TICKERS.csv")
-			if err != nil {
-				logger.Error("Failed to load tickers: %v", err)
-				os.Exit(1)
-			}
+	switch r.ExitCode {
+	case exitOK:
+		r.Status = "ok"
+	case exitPartial:
+		r.Status = "partial"
+	case exitUnhealthy:
+		r.Status = "unhealthy"
+	default:
+		r.Status = "failed"
+	}
 
-			numTickers := len(tickers)
-			for i, ticker := range tickers {
-				logger.Info("Calculating indicators for %s (%d/%d)", ticker, i+1, numTickers)
-				if err := numericalIndicatorsCalculator.CalculateAllNums(ticker); err != nil {
-					logger.Error("Failed to calculate indicators for %s: %v", ticker, err)
-				}
-			}
+	if outputFormat == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(r)
+	} else {
+		if len(r.Succeeded)+len(r.Failed) > 0 {
+			logger.Info("%s finished in %s: %s (%d succeeded, %d failed)",
+				r.Command, r.Duration, r.Status, len(r.Succeeded), len(r.Failed))
+		} else {
+			logger.Info("%s finished in %s: %s", r.Command, r.Duration, r.Status)
+		}
+		for _, f := range r.Outputs {
+			logger.Info("Wrote %s", f)
 		}
+	}
 
-	default:
-		log.Fatalf("Invalid mode: %s. Valid modes are: web, single, auto, liquidity, strategies, simulate, calculate, calculate_num", mode)
+	if r.ExitCode == exitOK {
+		return nil
+	}
+	if r.Error != "" {
+		return &exitError{r.ExitCode, errors.New(r.Error)}
 	}
+	return &exitError{r.ExitCode, fmt.Errorf("%s finished with status %s", r.Command, r.Status)}
 }
@@ -0,0 +1,296 @@
+package calendar
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HolidaysFile is the default location of the maintained ISX holiday list
+const HolidaysFile = "ISX_HOLIDAYS.csv"
+
+// Baghdad is the exchange time zone (UTC+3, no daylight saving)
+var Baghdad = time.FixedZone("AST", 3*60*60)
+
+// SessionClose is the time of day (Baghdad) after which the day's session is complete
+const SessionClose = 12 * time.Hour
+
+// Holiday represents a single exchange holiday
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// fixedHoliday is a national holiday that falls on the same Gregorian date every year since it was declared
+type fixedHoliday struct {
+	Month time.Month
+	Day   int
+	Name  string
+	Since int // First year observed; 0 for holidays older than the exchange
+}
+
+// fixedHolidays are observed every year from Since; lunar holidays (Eid etc.) live in ISX_HOLIDAYS.csv
+var fixedHolidays = []fixedHoliday{
+	{time.January, 1, "New Year's Day", 0},
+	{time.January, 6, "Army Day", 0},
+	{time.March, 21, "Nowruz", 0},
+	{time.May, 1, "Labour Day", 0},
+	{time.October, 3, "National Day", 2020},
+	{time.December, 10, "Victory Day", 2017},
+}
+
+// Calendar describes the days on which the Iraq Stock Exchange is open
+type Calendar struct {
+	weekends map[time.Weekday]bool
+	holidays map[string]string
+	years    map[int]bool // Years the holiday list covers
+}
+
+// New creates a calendar with the Iraqi Friday/Saturday weekend and the given holidays
+func New(holidays []Holiday) *Calendar {
+	c := &Calendar{
+		weekends: map[time.Weekday]bool{time.Friday: true, time.Saturday: true},
+		holidays: make(map[string]string),
+		years:    make(map[int]bool),
+	}
+	for _, h := range holidays {
+		c.holidays[dateKey(h.Date)] = h.Name
+		c.years[h.Date.Year()] = true
+	}
+	return c
+}
+
+// Load creates a calendar from a holiday CSV file (Date,Name).
+// A missing file is not an error; only the fixed national holidays are used then.
+func Load(path string) (*Calendar, error) {
+	holidays, err := LoadHolidays(path)
+	if err != nil && !os.IsNotExist(err) {
+		return New(nil), err
+	}
+	return New(holidays), nil
+}
+
+// LoadHolidays reads holidays from a CSV file with a Date,Name header.
+// Lines starting with # are treated as comments.
+func LoadHolidays(path string) ([]Holiday, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var holidays []Holiday
+	// Skip header row (Date,Name)
+	for i := 1; i < len(records); i++ {
+		if len(records[i]) == 0 || strings.TrimSpace(records[i][0]) == "" {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(records[i][0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid holiday date on line %d: %w", i+1, err)
+		}
+		name := ""
+		if len(records[i]) > 1 {
+			name = strings.TrimSpace(records[i][1])
+		}
+		holidays = append(holidays, Holiday{Date: date, Name: name})
+	}
+
+	return holidays, nil
+}
+
+var (
+	defaultOnce     sync.Once
+	defaultCalendar *Calendar
+	defaultErr      error
+)
+
+// Default returns the shared calendar loaded from ISX_HOLIDAYS.csv in the working directory.
+// When the file cannot be read it holds only the fixed national holidays; DefaultError tells why.
+func Default() *Calendar {
+	defaultOnce.Do(func() {
+		defaultCalendar, defaultErr = Load(HolidaysFile)
+	})
+	return defaultCalendar
+}
+
+// DefaultError returns the error reading ISX_HOLIDAYS.csv for the shared calendar, nil when it loaded
+func DefaultError() error {
+	Default()
+	if defaultErr != nil {
+		return fmt.Errorf("invalid %s: %w", HolidaysFile, defaultErr)
+	}
+	return nil
+}
+
+// dateKey normalises a time to its calendar date
+func dateKey(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// truncate strips the time of day, keeping the date in the value's own location
+func truncate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// IsWeekend reports whether the date falls on the Iraqi weekend
+func (c *Calendar) IsWeekend(t time.Time) bool {
+	return c.weekends[t.Weekday()]
+}
+
+// HolidayName returns the name of the holiday on the given date, if any
+func (c *Calendar) HolidayName(t time.Time) (string, bool) {
+	if name, ok := c.holidays[dateKey(t)]; ok {
+		return name, true
+	}
+	for _, h := range fixedHolidays {
+		if t.Month() == h.Month && t.Day() == h.Day && t.Year() >= h.Since {
+			return h.Name, true
+		}
+	}
+	return "", false
+}
+
+// Covers reports whether the holiday list covers the year of the date. In other years the lunar holidays
+// are unknown, so their dates count as trading days.
+func (c *Calendar) Covers(t time.Time) bool {
+	return c.years[t.Year()]
+}
+
+// CoveredYears describes the years the holiday list covers as ranges, e.g. "2019, 2023-2025", or "none"
+func (c *Calendar) CoveredYears() string {
+	years := make([]int, 0, len(c.years))
+	for year := range c.years {
+		years = append(years, year)
+	}
+	if len(years) == 0 {
+		return "none"
+	}
+	slices.Sort(years)
+
+	var ranges []string
+	for i := 0; i < len(years); {
+		j := i
+		for j+1 < len(years) && years[j+1] == years[j]+1 {
+			j++
+		}
+		if i == j {
+			ranges = append(ranges, strconv.Itoa(years[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", years[i], years[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(ranges, ", ")
+}
+
+// IsHoliday reports whether the date is an exchange holiday
+func (c *Calendar) IsHoliday(t time.Time) bool {
+	_, ok := c.HolidayName(t)
+	return ok
+}
+
+// IsTradingDay reports whether the exchange is open on the given date
+func (c *Calendar) IsTradingDay(t time.Time) bool {
+	return !c.IsWeekend(t) && !c.IsHoliday(t)
+}
+
+// LastSession returns the most recent trading day on or before t
+func (c *Calendar) LastSession(t time.Time) time.Time {
+	d := truncate(t)
+	for !c.IsTradingDay(d) {
+		d = d.AddDate(0, 0, -1)
+	}
+	return d
+}
+
+// LastCompletedSession returns the latest session whose trading has finished at time t.
+// Before the session close on a trading day this is the previous trading day.
+func (c *Calendar) LastCompletedSession(t time.Time) time.Time {
+	local := t.In(Baghdad)
+	today := truncate(local)
+	if c.IsTradingDay(today) && local.Sub(today) >= SessionClose {
+		return time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	prev := c.PreviousTradingDay(today)
+	return time.Date(prev.Year(), prev.Month(), prev.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// PreviousTradingDay returns the trading day strictly before t
+func (c *Calendar) PreviousTradingDay(t time.Time) time.Time {
+	return c.LastSession(truncate(t).AddDate(0, 0, -1))
+}
+
+// NextTradingDay returns the trading day strictly after t
+func (c *Calendar) NextTradingDay(t time.Time) time.Time {
+	d := truncate(t).AddDate(0, 0, 1)
+	for !c.IsTradingDay(d) {
+		d = d.AddDate(0, 0, 1)
+	}
+	return d
+}
+
+// AddTradingDays moves n trading days forward (or backward when n is negative) from t
+func (c *Calendar) AddTradingDays(t time.Time, n int) time.Time {
+	d := truncate(t)
+	for ; n > 0; n-- {
+		d = c.NextTradingDay(d)
+	}
+	for ; n < 0; n++ {
+		d = c.PreviousTradingDay(d)
+	}
+	return d
+}
+
+// TradingDays lists every trading day between from and to, inclusive
+func (c *Calendar) TradingDays(from, to time.Time) []time.Time {
+	var days []time.Time
+	for d := truncate(from); !d.After(truncate(to)); d = d.AddDate(0, 0, 1) {
+		if c.IsTradingDay(d) {
+			days = append(days, d)
+		}
+	}
+	return days
+}
+
+// CountTradingDays counts the trading days between from and to, inclusive
+func (c *Calendar) CountTradingDays(from, to time.Time) int {
+	count := 0
+	for d := truncate(from); !d.After(truncate(to)); d = d.AddDate(0, 0, 1) {
+		if c.IsTradingDay(d) {
+			count++
+		}
+	}
+	return count
+}
+
+// YearWindow returns the trailing one-year window of sessions ending at the latest
+// completed session, along with the number of sessions it contains
+func (c *Calendar) YearWindow(now time.Time) (start, end time.Time, sessions int) {
+	end = c.LastCompletedSession(now)
+	start = c.NextTradingDay(end.AddDate(-1, 0, 0))
+	return start, end, c.CountTradingDays(start, end)
+}
+
+// WeekStart returns the first day of the trading week holding t: the day after the preceding weekend.
+// For the Iraqi Friday/Saturday weekend weeks run from Sunday to Thursday.
+func (c *Calendar) WeekStart(t time.Time) time.Time {
+	d := truncate(t)
+	for k := 0; k < 6 && !c.IsWeekend(d.AddDate(0, 0, -1)); k++ {
+		d = d.AddDate(0, 0, -1)
+	}
+	return d
+}
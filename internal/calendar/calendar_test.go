@@ -0,0 +1,139 @@
+package calendar
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testHolidays are the listed holidays of the test calendar: Eid al-Adha 2023 and Eid al-Fitr 2024
+var testHolidays = []Holiday{
+	{date(2023, 6, 28), "Eid al-Adha"},
+	{date(2024, 4, 9), "Eid al-Fitr"},
+	{date(2024, 4, 10), "Eid al-Fitr"},
+	{date(2024, 4, 11), "Eid al-Fitr"},
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// baghdad returns the time of day in Baghdad on a date
+func baghdad(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, Baghdad)
+}
+
+func TestIsTradingDay(t *testing.T) {
+	cal := New(testHolidays)
+	tests := []struct {
+		name string
+		date time.Time
+		want bool
+	}{
+		{"Thursday", date(2024, 3, 14), true},
+		{"Friday", date(2024, 3, 15), false},
+		{"Saturday", date(2024, 3, 16), false},
+		{"Sunday", date(2024, 3, 17), true},
+		{"Army Day", date(2025, 1, 6), false},
+		{"Labour Day", date(2024, 5, 1), false},
+		{"Nowruz", date(2024, 3, 21), false},
+		{"National Day before 2020", date(2019, 10, 3), true},
+		{"National Day from 2020", date(2021, 10, 3), false},
+		{"Victory Day before 2017", date(2015, 12, 10), true},
+		{"Victory Day from 2017", date(2018, 12, 10), false},
+		{"listed Eid", date(2024, 4, 10), false},
+		{"Eid date of another year", date(2025, 4, 10), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cal.IsTradingDay(tt.date); got != tt.want {
+				t.Errorf("IsTradingDay(%s) = %v, want %v", tt.date.Format("2006-01-02 Mon"), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLastCompletedSession(t *testing.T) {
+	cal := New(testHolidays)
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{"before the close", baghdad(2024, 3, 14, 11, 59), date(2024, 3, 13)},
+		{"at the close", baghdad(2024, 3, 14, 12, 0), date(2024, 3, 14)},
+		{"after the close in UTC", time.Date(2024, 3, 14, 9, 30, 0, 0, time.UTC), date(2024, 3, 14)},
+		{"UTC date behind Baghdad", time.Date(2024, 3, 13, 22, 0, 0, 0, time.UTC), date(2024, 3, 13)},
+		{"weekend", baghdad(2024, 3, 16, 15, 0), date(2024, 3, 14)},
+		{"Sunday morning", baghdad(2024, 3, 17, 9, 0), date(2024, 3, 14)},
+		{"after Eid", baghdad(2024, 4, 11, 13, 0), date(2024, 4, 8)},
+		{"after a fixed holiday", baghdad(2024, 5, 1, 13, 0), date(2024, 4, 30)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cal.LastCompletedSession(tt.now); !got.Equal(tt.want) {
+				t.Errorf("LastCompletedSession(%s) = %s, want %s", tt.now, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestYearWindow(t *testing.T) {
+	start, end, sessions := New(testHolidays).YearWindow(baghdad(2024, 3, 14, 13, 0))
+	if !start.Equal(date(2023, 3, 15)) || !end.Equal(date(2024, 3, 14)) || sessions != 256 {
+		t.Errorf("YearWindow = %s to %s with %d sessions, want 2023-03-15 to 2024-03-14 with 256",
+			start.Format("2006-01-02"), end.Format("2006-01-02"), sessions)
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	cal := New(nil)
+	for _, day := range []time.Time{date(2024, 3, 10), date(2024, 3, 12), date(2024, 3, 14)} {
+		if got := cal.WeekStart(day); !got.Equal(date(2024, 3, 10)) {
+			t.Errorf("WeekStart(%s) = %s, want Sunday 2024-03-10", day.Format("2006-01-02 Mon"), got.Format("2006-01-02 Mon"))
+		}
+	}
+	if got := cal.WeekStart(date(2024, 3, 17)); !got.Equal(date(2024, 3, 17)) {
+		t.Errorf("WeekStart(2024-03-17) = %s, want itself", got.Format("2006-01-02"))
+	}
+}
+
+func TestLoadHolidays(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, HolidaysFile)
+	content := "# Moving holidays\nDate,Name\n2019-06-04,Eid al-Fitr\n\n2023-06-28,Eid al-Adha\n2024-04-10\n2025-03-31,Eid al-Fitr\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cal, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name, ok := cal.HolidayName(date(2023, 6, 28)); !ok || name != "Eid al-Adha" {
+		t.Errorf("HolidayName(2023-06-28) = %q, %v, want Eid al-Adha", name, ok)
+	}
+	if !cal.IsHoliday(date(2024, 4, 10)) {
+		t.Error("a listed date without a name is not a holiday")
+	}
+	if got := cal.CoveredYears(); got != "2019, 2023-2025" {
+		t.Errorf("CoveredYears() = %q, want \"2019, 2023-2025\"", got)
+	}
+	if !cal.Covers(date(2024, 11, 5)) || cal.Covers(date(2022, 11, 6)) {
+		t.Error("Covers should hold for the listed years only")
+	}
+
+	missing, err := Load(filepath.Join(dir, "missing.csv"))
+	if err != nil || !missing.IsHoliday(date(2024, 5, 1)) || missing.CoveredYears() != "none" {
+		t.Errorf("missing file gave %v, want the fixed holidays and no covered years", err)
+	}
+
+	if err := os.WriteFile(path, []byte("Date,Name\n2024-04-10,Eid\n10/04/2024,Eid\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("malformed date gave %v, want an error naming line 3", err)
+	}
+}
@@ -1,96 +1,679 @@
 package common
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
 	"os"
-	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+
+	"isx-auto-scrapper/internal/i18n"
+)
+
+// DefaultConfigFile is the configuration file read from the working directory
+const DefaultConfigFile = "isx.yaml"
+
+// Legacy JSON files still honoured below isx.yaml so existing setups keep working
+const (
+	legacyStrategyConfigFile = "strategy_config.json"
+	legacyBacktestConfigFile = "backtest_config.json"
 )
 
-// Config holds all application configuration
+// Config holds all application configuration.
+// Values are layered: defaults, config file, ISX_* environment variables, then command line flags.
 type Config struct {
-	// Logging Configuration
-	Debug       bool
-	LogFilename string
-	LogLevel    string
-	LogFormat   string
+	Workers    int              `yaml:"workers" json:"workers"`
+	Language   string           `yaml:"language" json:"language"` // Descriptions and report text: en or ar
+	Log        LogConfig        `yaml:"log" json:"log"`
+	Scraper    ScraperConfig    `yaml:"scraper" json:"scraper"`
+	Indicators IndicatorsConfig `yaml:"indicators" json:"indicators"`
+	Strategies StrategyConfig   `yaml:"strategies" json:"strategies"`
+	Backtest   BacktestConfig   `yaml:"backtest" json:"backtest"`
+	Liquidity  LiquidityConfig  `yaml:"liquidity" json:"liquidity"`
+	Risk       RiskConfig       `yaml:"risk" json:"risk"`
+	Server     ServerConfig     `yaml:"server" json:"server"`
 
-	// Directory Configuration
-	CurrentDirectory string
+	// Sources lists the layers that set values, lowest precedence first
+	Sources []string `yaml:"-" json:"-"`
+}
 
-	// Edge Driver Configuration
-	EdgeDriverPath string
+// LogConfig holds logging settings
+type LogConfig struct {
+	Filename   string `yaml:"filename" json:"filename"`
+	Level      string `yaml:"level" json:"level"`             // DEBUG, INFO, WARN or ERROR
+	Format     string `yaml:"format" json:"format"`           // Log file format: text or json
+	MaxSizeMB  int    `yaml:"max_size_mb" json:"max_size_mb"` // Rotate when the file exceeds this size; 0 disables
+	MaxBackups int    `yaml:"max_backups" json:"max_backups"` // Rotated files to keep; 0 keeps all
+	Daily      bool   `yaml:"daily" json:"daily"`             // Also rotate on the first write of each day
+}
 
-	// URL Configuration
-	BaseURL     string
-	BaseURLASE  string
-	DefaultDate string
+// ScraperConfig holds the ISX website and browser settings
+type ScraperConfig struct {
+	BaseURL         string `yaml:"base_url" json:"base_url"`
+	FromDate        string `yaml:"from_date" json:"from_date"`       // First date requested from the ISX portal (D/M/YYYY)
+	BrowserPath     string `yaml:"browser_path" json:"browser_path"` // Empty lets chromedp find Edge/Chrome
+	Headless        bool   `yaml:"headless" json:"headless"`
+	TimeoutSeconds  int    `yaml:"timeout_seconds" json:"timeout_seconds"`     // Whole fetch of one ticker
+	PageWaitSeconds int    `yaml:"page_wait_seconds" json:"page_wait_seconds"` // Initial page load
+}
 
-	// Table Configuration
-	TableSelector string
+// IndicatorsConfig holds the indicator calculation settings
+type IndicatorsConfig struct {
+	SpecFile    string `yaml:"spec_file" json:"spec_file"`       // JSON list of indicator specs; missing uses the built-in set
+	CustomFile  string `yaml:"custom_file" json:"custom_file"`   // JSON list of custom indicator formulas; missing adds none
+	BarCleaning string `yaml:"bar_cleaning" json:"bar_cleaning"` // Handling of raw bars with a zero price: substitute, ffill, drop, mark or none
+	ProfileBars int    `yaml:"profile_bars" json:"profile_bars"` // Bars covered by the volume profile
+	ProfileBins int    `yaml:"profile_bins" json:"profile_bins"` // Price bands of the volume profile
+
+	SwingLookback int     `yaml:"swing_lookback" json:"swing_lookback"` // Bars on each side a swing high or low must exceed
+	ZoneTolerance float64 `yaml:"zone_tolerance" json:"zone_tolerance"` // Percent within which swings form one support/resistance zone
+	LevelBars     int     `yaml:"level_bars" json:"level_bars"`         // Bars whose swings form zones
+	FibBars       int     `yaml:"fib_bars" json:"fib_bars"`             // Bars searched for the swing measured by Fibonacci retracements
+
+	DivergenceLookback int `yaml:"divergence_lookback" json:"divergence_lookback"` // Bars on each side a divergence swing must beat
+	DivergenceWindow   int `yaml:"divergence_window" json:"divergence_window"`     // Most bars between the two swings of a divergence
+
+	MarketIndex string `yaml:"market_index" json:"market_index"` // Ticker of a scraped index to compare with; empty builds an equal-weighted composite
+	RSPeriod    int    `yaml:"rs_period" json:"rs_period"`       // Sessions of performance ranked for the RS rank
+	BetaWindow  int    `yaml:"beta_window" json:"beta_window"`   // Returns in the rolling beta and correlation
+
+	Timeframes     []string `yaml:"timeframes" json:"timeframes"`           // Extra bar periods beside daily: W (weekly) and/or M (monthly)
+	PartialPeriods bool     `yaml:"partial_periods" json:"partial_periods"` // Keep the unfinished week or month as the last bar
+}
+
+// LiquidityConfig holds the liquidity scoring settings
+type LiquidityConfig struct {
+	Weights LiquidityWeights `yaml:"weights" json:"weights"`
+}
 
-	// WebDriver Wait Configuration
-	WebDriverWaitTime int
+// LiquidityWeights are the factor weights of the enhanced liquidity score; they must sum to 1
+type LiquidityWeights struct {
+	TradingActivity       float64 `yaml:"trading_activity" json:"trading_activity"`
+	VolumeConsistency     float64 `yaml:"volume_consistency" json:"volume_consistency"`
+	RelativeVolume        float64 `yaml:"relative_volume" json:"relative_volume"`
+	MarketImpact          float64 `yaml:"market_impact" json:"market_impact"`
+	IntradayVolatility    float64 `yaml:"intraday_volatility" json:"intraday_volatility"`
+	TimeWeightedRelevance float64 `yaml:"time_weighted_relevance" json:"time_weighted_relevance"`
+}
 
-	// Data Fetching Configuration
-	DefaultSMAPeriod int
-	DefaultRowCount  int
+// RiskConfig holds the volatility and risk metric settings
+type RiskConfig struct {
+	Window         int     `yaml:"window" json:"window"`                     // Sessions in each rolling window
+	Confidence     float64 `yaml:"confidence" json:"confidence"`             // VaR and CVaR confidence level, e.g. 0.95
+	PeriodsPerYear int     `yaml:"periods_per_year" json:"periods_per_year"` // Sessions used to annualise volatility
+}
 
-	// Excel Configuration
-	ExcelEngine string
+// ServerConfig holds the web dashboard settings
+type ServerConfig struct {
+	Bind string `yaml:"bind" json:"bind"` // Empty listens on all interfaces
+	Port int    `yaml:"port" json:"port"`
 }
 
 // NewConfig creates a new configuration instance with default values
 func NewConfig() *Config {
-	// Get current directory
-	currentDir, err := filepath.Abs(filepath.Dir(os.Args[0]))
+	return &Config{
+		Workers:  1,
+		Language: i18n.English,
+
+		Log: LogConfig{
+			Filename:   "stock_analysis.log",
+			Level:      "INFO",
+			Format:     "text",
+			MaxSizeMB:  10,
+			MaxBackups: 5,
+			Daily:      true,
+		},
+
+		Scraper: ScraperConfig{
+			BaseURL:         "http://www.isx-iq.net/isxportal/portal/companyprofilecontainer.html",
+			FromDate:        "1/1/2010",
+			Headless:        false,
+			TimeoutSeconds:  1100,
+			PageWaitSeconds: 15,
+		},
+
+		Indicators: IndicatorsConfig{
+			SpecFile:    "indicator_specs.json",
+			CustomFile:  "custom_indicators.json",
+			BarCleaning: "substitute",
+			ProfileBars: 120,
+			ProfileBins: 24,
+
+			SwingLookback: 5,
+			ZoneTolerance: 1.5,
+			LevelBars:     250,
+			FibBars:       120,
+
+			DivergenceLookback: 5,
+			DivergenceWindow:   60,
+
+			RSPeriod:   63,
+			BetaWindow: 60,
+
+			Timeframes:     []string{"W", "M"},
+			PartialPeriods: true,
+		},
+
+		Strategies: StrategyConfig{
+			RSI:      Levels{20, 30, 40, 60, 70, 80},
+			RSI2:     Levels{15, 25, 35, 65, 75, 85},
+			CMF:      Levels{0.2, 0.1, 0.05, -0.05, -0.1, -0.2},
+			OBVRoC:   Levels{10, 5, 2, -2, -5, -10},
+			MACDHist: MACDHistLevels{0.1, 0.05},
+			Consensus: ConsensusConfig{
+				Weighting: "equal",
+				Levels:    Levels{0.6, 0.3, 0.1, -0.1, -0.3, -0.6},
+			},
+			RulesFile: "strategy_rules.yaml",
+		},
+
+		Backtest: BacktestConfig{
+			InitialCash:       decimal.NewFromInt(100000),
+			Commission:        decimal.NewFromInt(50),
+			CommissionPercent: decimal.NewFromFloat(0.0025),
+			MaxPositions:      10,
+			PositionSize:      decimal.NewFromInt(10),
+			RiskPerTrade:      decimal.NewFromInt(2),
+			StopLoss:          decimal.NewFromInt(5),
+			TakeProfit:        decimal.NewFromInt(15),
+			MaxHoldingDays:    90,
+			Strategies:        []string{"OBV Strategy", "RSI Strategy2"},
+			UseSignalStrength: true,
+		},
+
+		Liquidity: LiquidityConfig{
+			Weights: LiquidityWeights{
+				TradingActivity:       0.25, // trading frequency
+				VolumeConsistency:     0.20, // predictable volume
+				RelativeVolume:        0.20, // volume size
+				MarketImpact:          0.15, // price impact
+				IntradayVolatility:    0.10, // intraday stability
+				TimeWeightedRelevance: 0.10, // recent activity
+			},
+		},
+
+		Risk: RiskConfig{
+			Window:         60,
+			Confidence:     0.95,
+			PeriodsPerYear: 252,
+		},
+
+		Server: ServerConfig{
+			Port: 8080,
+		},
+
+		Sources: []string{"defaults"},
+	}
+}
+
+// LoadConfig builds the effective configuration.
+// path is the YAML file to read; a missing file is only an error when required is set.
+// overrides are key=value pairs from the command line and win over everything else.
+func LoadConfig(path string, required bool, overrides []string) (*Config, error) {
+	cfg := NewConfig()
+
+	// Legacy JSON files sit just above the defaults
+	if err := cfg.loadJSON(legacyStrategyConfigFile, &cfg.Strategies); err != nil {
+		return nil, err
+	}
+	if err := cfg.loadJSON(legacyBacktestConfigFile, &cfg.Backtest); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.loadYAML(path, required); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	for _, override := range overrides {
+		key, value, ok := strings.Cut(override, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid override %q: use key=value", override)
+		}
+		if err := cfg.Set(strings.TrimSpace(key), value); err != nil {
+			return nil, err
+		}
+		cfg.Sources = append(cfg.Sources, "flag "+strings.TrimSpace(key))
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadJSON decodes a legacy JSON file over the current values when it exists
+func (c *Config) loadJSON(path string, target interface{}) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("invalid %s: %w", path, err)
+	}
+	c.Sources = append(c.Sources, path)
+	return nil
+}
+
+// loadYAML decodes the config file over the current values
+func (c *Config) loadYAML(path string, required bool) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) && !required {
+		return nil
+	}
 	if err != nil {
-		currentDir = "."
+		return err
 	}
+	defer file.Close()
 
-	// Read Edge Driver path from environment variable, fall back to default
-	edgeDriverPath := os.Getenv("EDGE_DRIVER_PATH")
-	if edgeDriverPath == "" {
-		edgeDriverPath = filepath.Join(currentDir, "msedgedriver.exe")
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid %s: %w", path, err)
 	}
+	c.Sources = append(c.Sources, path)
+	return nil
+}
 
-	return &Config{
-		// Logging Configuration
-		Debug:       true,
-		LogFilename: "stock_analysis.log",
-		LogLevel:    "DEBUG",        // or "ERROR" based on Debug flag
-		LogFormat:   "%s - %s - %s", // timestamp - level - message
+// applyEnv applies ISX_<SECTION>_<KEY> environment variables, e.g. ISX_SERVER_PORT
+func (c *Config) applyEnv() error {
+	// EDGE_DRIVER_PATH predates the ISX_ prefix
+	if path := os.Getenv("EDGE_DRIVER_PATH"); path != "" {
+		c.Scraper.BrowserPath = path
+		c.Sources = append(c.Sources, "env EDGE_DRIVER_PATH")
+	}
+
+	for _, key := range c.Keys() {
+		name := EnvName(key)
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := c.Set(key, value); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		c.Sources = append(c.Sources, "env "+name)
+	}
+	return nil
+}
 
-		// Directory Configuration
-		CurrentDirectory: currentDir,
+// EnvName returns the environment variable that overrides a config key
+func EnvName(key string) string {
+	return "ISX_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
 
-		// Edge Driver Configuration
-		EdgeDriverPath: edgeDriverPath,
+// Keys lists every settable config key in dotted form, e.g. server.port
+func (c *Config) Keys() []string {
+	var keys []string
+	walkConfig(reflect.ValueOf(c).Elem(), "", func(key string, _ reflect.Value) {
+		keys = append(keys, key)
+	})
+	sort.Strings(keys)
+	return keys
+}
 
-		// URL Configuration
-		BaseURL:     "http://www.isx-iq.net/isxportal/portal/companyprofilecontainer.html",
-		BaseURLASE:  "https://www.ase.com.jo/en/company_historical/",
-		DefaultDate: "06/10/2010",
+// Set assigns a value to a dotted config key, parsing it for the field's type
+func (c *Config) Set(key, value string) error {
+	var target reflect.Value
+	walkConfig(reflect.ValueOf(c).Elem(), "", func(k string, field reflect.Value) {
+		if k == key {
+			target = field
+		}
+	})
+	if !target.IsValid() {
+		return fmt.Errorf("unknown config key %q", key)
+	}
+	if err := setField(target, strings.TrimSpace(value)); err != nil {
+		return fmt.Errorf("invalid value %q for %s: %w", value, key, err)
+	}
+	return nil
+}
 
-		// Table Configuration
-		TableSelector: "#dispTable",
+// walkConfig calls fn for every leaf field, keyed by its dotted yaml path
+func walkConfig(v reflect.Value, prefix string, fn func(key string, field reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
 
-		// WebDriver Wait Configuration
-		WebDriverWaitTime: 10,
+		field := v.Field(i)
+		switch field.Interface().(type) {
+		case decimal.Decimal, time.Time:
+			fn(key, field)
+			continue
+		}
+		if field.Kind() == reflect.Struct {
+			walkConfig(field, key, fn)
+			continue
+		}
+		fn(key, field)
+	}
+}
 
-		// Data Fetching Configuration
-		DefaultSMAPeriod: 10,
-		DefaultRowCount:  600,
+// setField parses a string into a config field
+func setField(field reflect.Value, value string) error {
+	switch field.Interface().(type) {
+	case decimal.Decimal:
+		d, err := decimal.NewFromString(value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(d))
+		return nil
+	case time.Time:
+		var t time.Time
+		if value != "" {
+			var err error
+			if t, err = time.Parse("2006-01-02", value); err != nil {
+				if t, err = time.Parse(time.RFC3339, value); err != nil {
+					return fmt.Errorf("use YYYY-MM-DD")
+				}
+			}
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
 
-		// Excel Configuration
-		ExcelEngine: "openpyxl",
+// problems checks the strategy thresholds; prefix names the section in the messages
+func (s StrategyConfig) problems(prefix string) []string {
+	var problems []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, prefix+"."+fmt.Sprintf(format, args...))
+		}
+	}
+
+	// Buy thresholds below sell thresholds for oscillators, above them for flow indicators
+	checkLevels := func(name string, l Levels, ascending bool) {
+		values := []float64{l.StrongBuy, l.Buy, l.WeakBuy, l.WeakSell, l.Sell, l.StrongSell}
+		for i := 1; i < len(values); i++ {
+			if (ascending && values[i] <= values[i-1]) || (!ascending && values[i] >= values[i-1]) {
+				order := "increase"
+				if !ascending {
+					order = "decrease"
+				}
+				check(false, "%s levels must %s from strong_buy to strong_sell", name, order)
+				return
+			}
+		}
+	}
+	checkLevels("rsi", s.RSI, true)
+	checkLevels("rsi2", s.RSI2, true)
+	checkLevels("cmf", s.CMF, false)
+	checkLevels("obvroc", s.OBVRoC, false)
+	check(s.MACDHist.Strong > s.MACDHist.Buy && s.MACDHist.Buy > 0,
+		"macd_hist.strong must be greater than %s.macd_hist.buy, which must be positive", prefix)
+	check(s.Filters.MinRSRank >= 0 && s.Filters.MinRSRank <= 99, "filters.min_rs_rank must be between 0 and 99")
+	check(s.Filters.MaxBeta >= 0, "filters.max_beta must not be negative")
+	checkLevels("consensus", s.Consensus.Levels, false)
+	check(s.Consensus.Levels.StrongBuy <= 1 && s.Consensus.Levels.StrongSell >= -1,
+		"consensus.levels must lie between -1 and 1")
+	weighting := s.Consensus.Weighting
+	check(weighting == "equal" || weighting == "sharpe" || weighting == "win_rate",
+		"consensus.weighting must be equal, sharpe or win_rate, got %q", weighting)
+	for _, name := range sortedKeys(s.Consensus.Weights) {
+		check(s.Consensus.Weights[name] >= 0, "consensus.weights.%s must not be negative", name)
 	}
+	return problems
 }
 
-// GetLogLevel returns the appropriate log level based on debug flag
-func (c *Config) GetLogLevel() string {
-	if c.Debug {
-		return "DEBUG"
+// Profile returns the strategy settings of a ticker in sector: the defaults, then the overrides of the
+// sector, then those of the ticker. The name tells which layers applied: default, sector:<Sector>,
+// ticker:<TICKER> or both joined by +.
+func (s StrategyConfig) Profile(sector, ticker string) (StrategyConfig, string, error) {
+	base := s
+	base.Sectors, base.Tickers = nil, nil
+
+	var names []string
+	var layers []StrategyOverride
+	if override, ok := s.Sectors[sector]; ok && sector != "" {
+		names = append(names, "sector:"+sector)
+		layers = append(layers, override)
+	}
+	if override, ok := s.Tickers[ticker]; ok && ticker != "" {
+		names = append(names, "ticker:"+ticker)
+		layers = append(layers, override)
+	}
+	if len(layers) == 0 {
+		return base, "default", nil
+	}
+	name := strings.Join(names, "+")
+	where := "strategies." + strings.ReplaceAll(strings.ReplaceAll(name, ":", "s."), "+", ", strategies.")
+
+	raw, err := json.Marshal(base)
+	if err != nil {
+		return base, name, err
+	}
+	var merged map[string]interface{}
+	if err := json.Unmarshal(raw, &merged); err != nil {
+		return base, name, err
+	}
+	for _, layer := range layers {
+		for _, key := range []string{"rules_file", "sectors", "tickers"} {
+			if _, ok := layer[key]; ok {
+				return base, name, fmt.Errorf("%s: %s cannot be overridden", where, key)
+			}
+		}
+		mergeOverride(merged, layer)
+	}
+
+	raw, err = json.Marshal(merged)
+	if err != nil {
+		return base, name, fmt.Errorf("%s: %w", where, err)
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(raw)))
+	decoder.DisallowUnknownFields()
+	var out StrategyConfig
+	if err := decoder.Decode(&out); err != nil {
+		return base, name, fmt.Errorf("%s: %w", where, err)
+	}
+	if problems := out.problems(where); len(problems) > 0 {
+		return base, name, errors.New(strings.Join(problems, "; "))
+	}
+	return out, name, nil
+}
+
+// mergeOverride writes override into settings, merging nested blocks key by key
+func mergeOverride(settings, override map[string]interface{}) {
+	for key, value := range override {
+		block, isBlock := asBlock(value)
+		current, hasBlock := settings[key].(map[string]interface{})
+		if isBlock && hasBlock {
+			mergeOverride(current, block)
+			continue
+		}
+		settings[key] = value
+	}
+}
+
+// asBlock returns value as a settings block; YAML decodes the nested blocks of an override as StrategyOverride
+func asBlock(value interface{}) (map[string]interface{}, bool) {
+	switch block := value.(type) {
+	case map[string]interface{}:
+		return block, true
+	case StrategyOverride:
+		return block, true
+	}
+	return nil, false
+}
+
+// sortedKeys returns the keys of m in order, so problems are reported the same way every run
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Validate checks that all values are usable and reports every problem found
+func (c *Config) Validate() error {
+	var problems []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(c.Workers >= 1, "workers must be at least 1")
+	check(i18n.Valid(c.Language), "language %q must be one of %s", c.Language, strings.Join(i18n.Supported(), ", "))
+
+	check(c.Log.Filename != "", "log.filename must not be empty")
+	if _, err := ParseLogLevel(c.Log.Level); err != nil {
+		problems = append(problems, fmt.Sprintf("log.level %q must be DEBUG, INFO, WARN or ERROR", c.Log.Level))
+	}
+	check(c.Log.Format == "text" || c.Log.Format == "json", "log.format %q must be text or json", c.Log.Format)
+	check(c.Log.MaxSizeMB >= 0, "log.max_size_mb must not be negative")
+	check(c.Log.MaxBackups >= 0, "log.max_backups must not be negative")
+
+	if u, err := url.Parse(c.Scraper.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		problems = append(problems, fmt.Sprintf("scraper.base_url %q must be an http(s) URL", c.Scraper.BaseURL))
+	}
+	if _, err := time.Parse("2/1/2006", c.Scraper.FromDate); err != nil {
+		problems = append(problems, fmt.Sprintf("scraper.from_date %q must be D/M/YYYY", c.Scraper.FromDate))
+	}
+	check(c.Scraper.TimeoutSeconds > 0, "scraper.timeout_seconds must be positive")
+	check(c.Scraper.PageWaitSeconds > 0, "scraper.page_wait_seconds must be positive")
+
+	check(c.Indicators.SpecFile != "", "indicators.spec_file must not be empty")
+	check(c.Indicators.CustomFile != "", "indicators.custom_file must not be empty")
+	cleaning := c.Indicators.BarCleaning
+	check(cleaning == "substitute" || cleaning == "ffill" || cleaning == "drop" || cleaning == "mark" || cleaning == "none",
+		"indicators.bar_cleaning %q must be substitute, ffill, drop, mark or none", cleaning)
+	check(c.Indicators.ProfileBars >= 1, "indicators.profile_bars must be at least 1")
+	check(c.Indicators.ProfileBins >= 1, "indicators.profile_bins must be at least 1")
+	check(c.Indicators.SwingLookback >= 1, "indicators.swing_lookback must be at least 1")
+	check(c.Indicators.ZoneTolerance > 0, "indicators.zone_tolerance must be positive")
+	check(c.Indicators.LevelBars >= 1, "indicators.level_bars must be at least 1")
+	check(c.Indicators.FibBars >= 2, "indicators.fib_bars must be at least 2")
+	check(c.Indicators.RSPeriod >= 1, "indicators.rs_period must be at least 1")
+	check(c.Indicators.BetaWindow >= 2, "indicators.beta_window must be at least 2")
+	check(c.Indicators.DivergenceLookback >= 1, "indicators.divergence_lookback must be at least 1")
+	check(c.Indicators.DivergenceWindow > 2*c.Indicators.DivergenceLookback,
+		"indicators.divergence_window must be longer than twice indicators.divergence_lookback")
+	for _, tf := range c.Indicators.Timeframes {
+		check(tf == "W" || tf == "M", "indicators.timeframes entry %q must be W or M", tf)
+	}
+
+	check(c.Strategies.RulesFile != "", "strategies.rules_file must not be empty")
+	problems = append(problems, c.Strategies.problems("strategies")...)
+	for _, sector := range sortedKeys(c.Strategies.Sectors) {
+		if _, _, err := c.Strategies.Profile(sector, ""); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	for _, ticker := range sortedKeys(c.Strategies.Tickers) {
+		if _, _, err := c.Strategies.Profile("", ticker); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	// A ticker of an overridden sector runs with both layers, so check them together where TICKERS.csv
+	// tells the sectors; without it the strategies check each ticker's profile before the run
+	if len(c.Strategies.Sectors) > 0 && len(c.Strategies.Tickers) > 0 {
+		if infos, err := LoadTickersWithInfo("TICKERS.csv"); err == nil {
+			for _, info := range infos {
+				_, sector := c.Strategies.Sectors[info.Sector]
+				_, ticker := c.Strategies.Tickers[info.Symbol]
+				if !sector || !ticker {
+					continue
+				}
+				if _, _, err := c.Strategies.Profile(info.Sector, info.Symbol); err != nil {
+					problems = append(problems, err.Error())
+				}
+			}
+		}
+	}
+
+	check(c.Backtest.InitialCash.IsPositive(), "backtest.initial_cash must be positive")
+	check(!c.Backtest.Commission.IsNegative(), "backtest.commission_per_trade must not be negative")
+	check(!c.Backtest.CommissionPercent.IsNegative(), "backtest.commission_percent must not be negative")
+	check(c.Backtest.MaxPositions >= 1, "backtest.max_positions must be at least 1")
+	check(c.Backtest.PositionSize.IsPositive() && c.Backtest.PositionSize.LessThanOrEqual(decimal.NewFromInt(100)),
+		"backtest.position_size_percent must be between 0 and 100")
+	check(!c.Backtest.StopLoss.IsNegative(), "backtest.stop_loss_percent must not be negative")
+	check(!c.Backtest.TakeProfit.IsNegative(), "backtest.take_profit_percent must not be negative")
+	check(c.Backtest.MaxHoldingDays >= 0, "backtest.max_holding_days must not be negative")
+	check(c.Backtest.StartDate.IsZero() || c.Backtest.EndDate.IsZero() || c.Backtest.StartDate.Before(c.Backtest.EndDate),
+		"backtest.start_date must be before backtest.end_date")
+	check(len(c.Backtest.Strategies) > 0, "backtest.strategies must list at least one strategy")
+
+	w := c.Liquidity.Weights
+	weights := []float64{w.TradingActivity, w.VolumeConsistency, w.RelativeVolume, w.MarketImpact, w.IntradayVolatility, w.TimeWeightedRelevance}
+	sum := 0.0
+	for _, weight := range weights {
+		check(weight >= 0, "liquidity.weights must not be negative")
+		sum += weight
+	}
+	check(math.Abs(sum-1) < 0.001, "liquidity.weights must sum to 1 (got %.3f)", sum)
+
+	check(c.Risk.Window >= 3, "risk.window must be at least 3")
+	check(c.Risk.Confidence > 0.5 && c.Risk.Confidence < 1, "risk.confidence must be between 0.5 and 1")
+	check(c.Risk.PeriodsPerYear >= 1, "risk.periods_per_year must be at least 1")
+
+	check(c.Server.Port >= 1 && c.Server.Port <= 65535, "server.port %d must be between 1 and 65535", c.Server.Port)
+
+	if len(problems) > 0 {
+		return errors.New("invalid configuration:\n  - " + strings.Join(problems, "\n  - "))
 	}
-	return "ERROR"
+	return nil
 }
 
 // Global configuration instance
@@ -0,0 +1,73 @@
+package common
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// inTempDir runs the test in an empty directory, where LoadConfig looks for the legacy JSON files
+func inTempDir(t *testing.T) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLegacyStrategyConfigOverrides(t *testing.T) {
+	inTempDir(t)
+	writeFile(t, legacyStrategyConfigFile, `{
+		"rsi": {"strong_buy": 20, "buy": 30, "weak_buy": 40, "weak_sell": 60, "sell": 70, "strong_sell": 80},
+		"sectors": {"Banks": {"rsi": {"buy": 25}, "consensus": {"weighting": "win_rate"}}},
+		"tickers": {"BBOB": {"rsi": {"buy": 28}}}
+	}`)
+	// isx.yaml overrides another ticker beside those of the JSON file
+	writeFile(t, "isx.yaml", "strategies:\n  tickers:\n    TASC:\n      cmf:\n        buy: 0.15\n")
+
+	cfg, err := LoadConfig("isx.yaml", true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		sector, ticker string
+		profile        string
+		rsiBuy         float64
+		weighting      string
+	}{
+		{"Telecom", "TASC", "ticker:TASC", 30, "equal"},
+		{"Banks", "BASH", "sector:Banks", 25, "win_rate"},
+		{"Banks", "BBOB", "sector:Banks+ticker:BBOB", 28, "win_rate"},
+	}
+	for _, tt := range tests {
+		got, profile, err := cfg.Strategies.Profile(tt.sector, tt.ticker)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.ticker, err)
+		}
+		if profile != tt.profile || got.RSI.Buy != tt.rsiBuy || got.Consensus.Weighting != tt.weighting {
+			t.Errorf("%s profile %s: rsi.buy %v, weighting %s; want %s: %v, %s",
+				tt.ticker, profile, got.RSI.Buy, got.Consensus.Weighting, tt.profile, tt.rsiBuy, tt.weighting)
+		}
+	}
+	if got, _, _ := cfg.Strategies.Profile("Telecom", "TASC"); got.CMF.Buy != 0.15 {
+		t.Errorf("TASC cmf.buy = %v, want 0.15 from isx.yaml", got.CMF.Buy)
+	}
+
+	// Overrides of the legacy file are validated at load like those of isx.yaml
+	writeFile(t, legacyStrategyConfigFile, `{"sectors": {"Banks": {"rsi": {"bye": 25}}}}`)
+	if _, err := LoadConfig("isx.yaml", true, nil); err == nil || !strings.Contains(err.Error(), "strategies.sectors.Banks") {
+		t.Errorf("unknown key in a legacy sector override gave %v, want an error naming the override", err)
+	}
+}
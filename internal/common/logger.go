@@ -1,51 +1,216 @@
 package common
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
-	"log"
-	"os"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
 	"time"
 )
 
-// Logger provides logging functionality similar to the Python version
+// Logger is a printf-style front end to a structured slog.Logger.
+// One logger is configured per process by SetupLogging and passed to every component;
+// With, WithTicker and WithStage derive loggers that add context fields to each record.
 type Logger struct {
-	infoLogger  *log.Logger
-	errorLogger *log.Logger
-	file        *os.File
+	slog *slog.Logger
 }
 
-// NewLogger creates a new logger instance
-func NewLogger() *Logger {
-	// Create or open log file using config
-	file, err := os.OpenFile(AppConfig.LogFilename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+// SetupLogging configures the process logger from cfg.
+// Records go to the rotating log file in cfg.Format and to console in a short human-readable form.
+// The returned closer flushes and closes the log file.
+func SetupLogging(cfg LogConfig, console io.Writer) (*Logger, io.Closer, error) {
+	level, err := ParseLogLevel(cfg.Level)
 	if err != nil {
-		log.Fatalln("Failed to open log file:", err)
+		return nil, nil, err
 	}
 
-	return &Logger{
-		infoLogger:  log.New(file, "INFO: ", log.Ldate|log.Ltime|log.Lshortfile),
-		errorLogger: log.New(file, "ERROR: ", log.Ldate|log.Ltime|log.Lshortfile),
-		file:        file,
+	file, err := NewRotatingWriter(cfg.Filename, cfg.MaxSizeMB, cfg.MaxBackups, cfg.Daily)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+	var fileHandler slog.Handler
+	if strings.EqualFold(cfg.Format, "json") {
+		fileHandler = slog.NewJSONHandler(file, options)
+	} else {
+		fileHandler = slog.NewTextHandler(file, options)
+	}
+
+	handler := &fanoutHandler{handlers: []slog.Handler{
+		fileHandler,
+		newConsoleHandler(console, level),
+	}}
+
+	logger := &Logger{slog: slog.New(handler).With("run_id", newRunID())}
+	slog.SetDefault(logger.slog)
+	return logger, file, nil
+}
+
+// NewLogger wraps an existing slog.Logger, e.g. slog.Default() in tools that skip SetupLogging
+func NewLogger(l *slog.Logger) *Logger {
+	return &Logger{slog: l}
+}
+
+// ParseLogLevel converts DEBUG, INFO, WARN or ERROR to a slog level
+func ParseLogLevel(level string) (slog.Level, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return 0, fmt.Errorf("unknown log level %q: use DEBUG, INFO, WARN or ERROR", level)
 	}
+	return l, nil
+}
+
+// newRunID returns a short id that ties together all records of one run
+func newRunID() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return time.Now().Format("20060102-150405") + "-" + hex.EncodeToString(b)
+}
+
+// With returns a logger that adds the given key/value pairs to every record
+func (l *Logger) With(args ...interface{}) *Logger {
+	return &Logger{slog: l.slog.With(args...)}
+}
+
+// WithTicker returns a logger tagged with a ticker symbol
+func (l *Logger) WithTicker(ticker string) *Logger {
+	return l.With("ticker", ticker)
+}
+
+// WithStage returns a logger tagged with a pipeline stage, e.g. fetch or calc
+func (l *Logger) WithStage(stage string) *Logger {
+	return l.With("stage", stage)
+}
+
+// Slog returns the underlying structured logger
+func (l *Logger) Slog() *slog.Logger {
+	return l.slog
+}
+
+// Debug logs a debug message
+func (l *Logger) Debug(format string, args ...interface{}) {
+	l.log(slog.LevelDebug, format, args...)
 }
 
 // Info logs an info message
 func (l *Logger) Info(format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	l.infoLogger.Println(message)
-	fmt.Printf("[%s] INFO: %s\n", time.Now().Format("2006-01-02 15:04:05"), message)
+	l.log(slog.LevelInfo, format, args...)
+}
+
+// Warn logs a warning message
+func (l *Logger) Warn(format string, args ...interface{}) {
+	l.log(slog.LevelWarn, format, args...)
 }
 
 // Error logs an error message
 func (l *Logger) Error(format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	l.errorLogger.Println(message)
-	fmt.Printf("[%s] ERROR: %s\n", time.Now().Format("2006-01-02 15:04:05"), message)
+	l.log(slog.LevelError, format, args...)
 }
 
-// Close closes the log file
-func (l *Logger) Close() {
-	if l.file != nil {
-		l.file.Close()
+// log formats the message only when the level is enabled
+func (l *Logger) log(level slog.Level, format string, args ...interface{}) {
+	ctx := context.Background()
+	if !l.slog.Enabled(ctx, level) {
+		return
 	}
+	l.slog.Log(ctx, level, fmt.Sprintf(format, args...))
+}
+
+// fanoutHandler sends every record to several handlers
+type fanoutHandler struct {
+	handlers []slog.Handler
+}
+
+func (h *fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *fanoutHandler) Handle(ctx context.Context, r slog.Record) error {
+	var firstErr error
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, r.Level) {
+			if err := handler.Handle(ctx, r.Clone()); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+func (h *fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+	return &fanoutHandler{handlers: handlers}
+}
+
+func (h *fanoutHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithGroup(name)
+	}
+	return &fanoutHandler{handlers: handlers}
+}
+
+// consoleHandler prints "[time] LEVEL: message" followed by the ticker and stage fields.
+// The run id and other fields are left to the log file to keep the console readable.
+type consoleHandler struct {
+	out   io.Writer
+	mu    *sync.Mutex
+	level slog.Level
+	attrs []slog.Attr
+}
+
+func newConsoleHandler(out io.Writer, level slog.Level) *consoleHandler {
+	return &consoleHandler{out: out, mu: &sync.Mutex{}, level: level}
+}
+
+func (h *consoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *consoleHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] %s: ", r.Time.Format("2006-01-02 15:04:05"), r.Level)
+	for _, attr := range h.attrs {
+		if attr.Key == "ticker" || attr.Key == "stage" {
+			fmt.Fprintf(&b, "[%s] ", attr.Value)
+		}
+	}
+	b.WriteString(r.Message)
+	r.Attrs(func(attr slog.Attr) bool {
+		fmt.Fprintf(&b, " %s=%v", attr.Key, attr.Value)
+		return true
+	})
+	b.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.out, b.String())
+	return err
+}
+
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &consoleHandler{
+		out:   h.out,
+		mu:    h.mu,
+		level: h.level,
+		attrs: append(append([]slog.Attr{}, h.attrs...), attrs...),
+	}
+}
+
+func (h *consoleHandler) WithGroup(string) slog.Handler {
+	// Groups are not used by the printf-style API; keep fields flat on the console
+	return h
 }
@@ -0,0 +1,147 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotatingWriter is an append-only log file that is rotated when it grows past
+// a size limit or, when daily is set, on the first write of a new day.
+// Rotated files are renamed to <name>-<timestamp><ext> and only the newest maxBackups are kept.
+type RotatingWriter struct {
+	mu         sync.Mutex
+	filename   string
+	maxSize    int64 // bytes; 0 disables size rotation
+	maxBackups int   // 0 keeps every rotated file
+	daily      bool
+
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+// NewRotatingWriter opens filename for appending, rotating it first if it is already due
+func NewRotatingWriter(filename string, maxSizeMB, maxBackups int, daily bool) (*RotatingWriter, error) {
+	w := &RotatingWriter{
+		filename:   filename,
+		maxSize:    int64(maxSizeMB) * 1024 * 1024,
+		maxBackups: maxBackups,
+		daily:      daily,
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// open opens the current log file and records its size and age
+func (w *RotatingWriter) open() error {
+	file, err := os.OpenFile(w.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	w.file = file
+	w.size = info.Size()
+	w.opened = time.Now()
+	if w.size > 0 {
+		// An existing file dates from its last write, so a stale file rotates on the next day
+		w.opened = info.ModTime()
+	}
+	return nil
+}
+
+// Write appends p, rotating the file first when the size or date limit is reached
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return 0, os.ErrClosed
+	}
+	if w.due(len(p)) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// due reports whether writing n more bytes should start a new file
+func (w *RotatingWriter) due(n int) bool {
+	if w.size == 0 {
+		return false
+	}
+	if w.maxSize > 0 && w.size+int64(n) > w.maxSize {
+		return true
+	}
+	if w.daily {
+		y1, m1, d1 := w.opened.Date()
+		y2, m2, d2 := time.Now().Date()
+		return y1 != y2 || m1 != m2 || d1 != d2
+	}
+	return false
+}
+
+// rotate renames the current file to a timestamped backup, opens a fresh one and prunes old backups
+func (w *RotatingWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	w.file = nil
+
+	ext := filepath.Ext(w.filename)
+	base := strings.TrimSuffix(w.filename, ext)
+	backup := fmt.Sprintf("%s-%s%s", base, time.Now().Format("2006-01-02T15-04-05.000"), ext)
+	if err := os.Rename(w.filename, backup); err != nil {
+		return err
+	}
+
+	if err := w.open(); err != nil {
+		return err
+	}
+	w.prune(base, ext)
+	return nil
+}
+
+// prune removes the oldest backups beyond maxBackups
+func (w *RotatingWriter) prune(base, ext string) {
+	if w.maxBackups <= 0 {
+		return
+	}
+	backups, err := filepath.Glob(base + "-*" + ext)
+	if err != nil || len(backups) <= w.maxBackups {
+		return
+	}
+	// Timestamps sort chronologically
+	sort.Strings(backups)
+	for _, old := range backups[:len(backups)-w.maxBackups] {
+		os.Remove(old)
+	}
+}
+
+// Close closes the current log file
+func (w *RotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
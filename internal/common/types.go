@@ -39,14 +39,10 @@ type StockData struct {
 	MACDSignal decimal.Decimal `csv:"MACDs_12_26_9"`
 	MACDHist   decimal.Decimal `csv:"MACDh_12_26_9"`
 
-	CMF   decimal.Decimal `csv:"CMF"`
 	OBV   decimal.Decimal `csv:"OBV"`
-	ATR   decimal.Decimal `csv:"ATR"`
-	PSAR  decimal.Decimal `csv:"PSAR"`
+	ATR   decimal.Decimal `csv:"ATR_14"`
 	PSAR2 decimal.Decimal `csv:"PSARl_0.01_0.1"`
 
-	RollingStd decimal.Decimal `csv:"Rolling_Std"`
-
 	// Crossover signals
 	GoldenCross          bool `csv:"Golden_Cross"`
 	DeathCross           bool `csv:"Death_Cross"`
@@ -178,24 +174,76 @@ type Portfolio struct {
 	DaysSinceStart  int             `csv:"Days_Since_Start"`
 }
 
+// Levels holds threshold levels for a strategy
+type Levels struct {
+	StrongBuy  float64 `json:"strong_buy" yaml:"strong_buy"`
+	Buy        float64 `json:"buy" yaml:"buy"`
+	WeakBuy    float64 `json:"weak_buy" yaml:"weak_buy"`
+	WeakSell   float64 `json:"weak_sell" yaml:"weak_sell"`
+	Sell       float64 `json:"sell" yaml:"sell"`
+	StrongSell float64 `json:"strong_sell" yaml:"strong_sell"`
+}
+
+// MACDHistLevels defines thresholds for MACD histogram strength
+type MACDHistLevels struct {
+	Strong float64 `json:"strong" yaml:"strong"`
+	Buy    float64 `json:"buy" yaml:"buy"`
+}
+
+// SignalFilters hold buy signals back to Hold for stocks that lag the market or swing more than it
+type SignalFilters struct {
+	MinRSRank float64 `json:"min_rs_rank" yaml:"min_rs_rank"` // Buy signals need at least this RS rank (1-99); 0 disables
+	MaxBeta   float64 `json:"max_beta" yaml:"max_beta"`       // Buy signals need a market beta of at most this; 0 disables
+}
+
+// ConsensusConfig weighs every strategy's signal into the consensus signal
+type ConsensusConfig struct {
+	Weighting string             `json:"weighting" yaml:"weighting"` // equal, sharpe or win_rate of each strategy's backtest on the ticker's earlier months
+	Weights   map[string]float64 `json:"weights" yaml:"weights"`     // Factor per strategy name; unlisted strategies weigh 1, 0 leaves one out
+	Levels    Levels             `json:"levels" yaml:"levels"`       // Score thresholds; the score runs from 1 (all Strong Buy) to -1
+}
+
+// StrategyConfig defines tunable strategy thresholds
+type StrategyConfig struct {
+	RSI      Levels         `json:"rsi" yaml:"rsi"`
+	RSI2     Levels         `json:"rsi2" yaml:"rsi2"`
+	CMF      Levels         `json:"cmf" yaml:"cmf"`
+	OBVRoC   Levels         `json:"obvroc" yaml:"obvroc"`
+	MACDHist MACDHistLevels `json:"macd_hist" yaml:"macd_hist"`
+	Filters  SignalFilters  `json:"filters" yaml:"filters"`
+
+	Consensus ConsensusConfig `json:"consensus" yaml:"consensus"`
+
+	// RulesFile holds declarative rule strategies (JSON or YAML) run after the built-in ones
+	RulesFile string `json:"rules_file" yaml:"rules_file"`
+
+	// Overrides of the settings above by TICKERS.csv sector and by ticker; see Profile
+	Sectors map[string]StrategyOverride `json:"sectors,omitempty" yaml:"sectors,omitempty"`
+	Tickers map[string]StrategyOverride `json:"tickers,omitempty" yaml:"tickers,omitempty"`
+}
+
+// StrategyOverride holds any part of the strategy settings, written like the strategies section,
+// e.g. {"rsi": {"buy": 25}}; blocks are merged key by key
+type StrategyOverride map[string]interface{}
+
 // BacktestConfig represents backtesting configuration
 type BacktestConfig struct {
-	InitialCash       decimal.Decimal `json:"initial_cash"`
-	Commission        decimal.Decimal `json:"commission_per_trade"`
-	CommissionPercent decimal.Decimal `json:"commission_percent"`
-	MaxPositions      int             `json:"max_positions"`
-	PositionSize      decimal.Decimal `json:"position_size_percent"`  // Percentage of portfolio per trade
-	RiskPerTrade      decimal.Decimal `json:"risk_per_trade_percent"` // Maximum risk per trade
-	StopLoss          decimal.Decimal `json:"stop_loss_percent"`      // Stop loss percentage
-	TakeProfit        decimal.Decimal `json:"take_profit_percent"`    // Take profit percentage
-	MaxHoldingDays    int             `json:"max_holding_days"`       // Maximum days to hold a position
-	StartDate         time.Time       `json:"start_date"`
-	EndDate           time.Time       `json:"end_date"`
-	Strategies        []string        `json:"strategies"`          // Which strategies to test
-	Tickers           []string        `json:"tickers"`             // Which tickers to include
-	UseSignalStrength bool            `json:"use_signal_strength"` // Whether to use signal strength (Strong Buy vs Buy)
-	ReinvestDividends bool            `json:"reinvest_dividends"`
-	Benchmark         string          `json:"benchmark"` // Benchmark ticker for comparison
+	InitialCash       decimal.Decimal `json:"initial_cash" yaml:"initial_cash"`
+	Commission        decimal.Decimal `json:"commission_per_trade" yaml:"commission_per_trade"`
+	CommissionPercent decimal.Decimal `json:"commission_percent" yaml:"commission_percent"`
+	MaxPositions      int             `json:"max_positions" yaml:"max_positions"`
+	PositionSize      decimal.Decimal `json:"position_size_percent" yaml:"position_size_percent"`   // Percentage of portfolio per trade
+	RiskPerTrade      decimal.Decimal `json:"risk_per_trade_percent" yaml:"risk_per_trade_percent"` // Maximum risk per trade
+	StopLoss          decimal.Decimal `json:"stop_loss_percent" yaml:"stop_loss_percent"`           // Stop loss percentage
+	TakeProfit        decimal.Decimal `json:"take_profit_percent" yaml:"take_profit_percent"`       // Take profit percentage
+	MaxHoldingDays    int             `json:"max_holding_days" yaml:"max_holding_days"`             // Maximum days to hold a position
+	StartDate         time.Time       `json:"start_date" yaml:"start_date"`
+	EndDate           time.Time       `json:"end_date" yaml:"end_date"`
+	Strategies        []string        `json:"strategies" yaml:"strategies"`                   // Which strategies to test
+	Tickers           []string        `json:"tickers" yaml:"tickers"`                         // Which tickers to include
+	UseSignalStrength bool            `json:"use_signal_strength" yaml:"use_signal_strength"` // Whether to use signal strength (Strong Buy vs Buy)
+	ReinvestDividends bool            `json:"reinvest_dividends" yaml:"reinvest_dividends"`
+	Benchmark         string          `json:"benchmark" yaml:"benchmark"` // Benchmark ticker for comparison
 }
 
 // StrategyPerformance represents performance metrics for a single strategy
@@ -268,3 +316,21 @@ type TimingReport struct {
 	BottleneckFunction     string        `csv:"Bottleneck_Function"`
 	OptimizationSuggestion string        `csv:"Optimization_Suggestion"`
 }
+
+// GapReport lists the trading sessions missing from a ticker's raw data
+type GapReport struct {
+	Ticker           string `csv:"Ticker"`
+	Sector           string `csv:"Sector"`
+	CompanyName      string `csv:"Company_Name"`
+	FirstDataDate    string `csv:"First_Data_Date"`
+	LastDataDate     string `csv:"Last_Data_Date"`
+	ExpectedSessions int    `csv:"Expected_Sessions"`
+	PresentSessions  int    `csv:"Present_Sessions"`
+	MissingSessions  int    `csv:"Missing_Sessions"`
+	CoveragePercent  string `csv:"Coverage_Percent"`
+	LongestGap       int    `csv:"Longest_Gap_Sessions"`
+	SessionsBehind   int    `csv:"Sessions_Behind"`
+	NonTradingRows   int    `csv:"Non_Trading_Day_Rows"`
+	MissingDates     string `csv:"Missing_Dates"`
+	Status           string `csv:"Status"` // COMPLETE, GAPS, STALE, NO_DATA
+}
@@ -3,6 +3,9 @@ package common
 import (
 	"encoding/csv"
 	"os"
+	"strings"
+
+	"isx-auto-scrapper/internal/i18n"
 )
 
 // TickerInfo represents complete ticker information
@@ -10,6 +13,7 @@ type TickerInfo struct {
 	Symbol      string    `csv:"Ticker" json:"symbol"`
 	Sector      string    `csv:"Sector" json:"sector"`
 	CompanyName string    `csv:"Name" json:"name"`
+	NameAr      string    `csv:"Name_Ar" json:"name_ar,omitempty"` // Optional Arabic company name
 	Date        string    `json:"date"`
 	Price       float64   `json:"price"`
 	Change      float64   `json:"change"`
@@ -21,6 +25,14 @@ type TickerInfo struct {
 	Sparkline   []float64 `json:"sparkline"`
 }
 
+// DisplayName returns the company name in lang, falling back to the English name
+func (t TickerInfo) DisplayName(lang string) string {
+	if lang == i18n.Arabic && t.NameAr != "" {
+		return t.NameAr
+	}
+	return t.CompanyName
+}
+
 // LoadTickers loads ticker symbols from CSV file
 func LoadTickers(filename string) ([]string, error) {
 	file, err := os.Open(filename)
@@ -30,6 +42,7 @@ func LoadTickers(filename string) ([]string, error) {
 	defer file.Close()
 
 	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1 // Name_Ar is optional
 	records, err := reader.ReadAll()
 	if err != nil {
 		return nil, err
@@ -55,20 +68,25 @@ func LoadTickersWithInfo(filename string) ([]TickerInfo, error) {
 	defer file.Close()
 
 	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1 // Name_Ar is optional
 	records, err := reader.ReadAll()
 	if err != nil {
 		return nil, err
 	}
 
 	var tickers []TickerInfo
-	// Skip header row (Ticker,Sector,Name)
+	// Skip header row (Ticker,Sector,Name[,Name_Ar])
 	for i := 1; i < len(records); i++ {
 		if len(records[i]) >= 3 {
-			tickers = append(tickers, TickerInfo{
+			info := TickerInfo{
 				Symbol:      records[i][0],
 				Sector:      records[i][1],
 				CompanyName: records[i][2],
-			})
+			}
+			if len(records[i]) >= 4 {
+				info.NameAr = strings.TrimSpace(records[i][3])
+			}
+			tickers = append(tickers, info)
 		}
 	}
 
@@ -0,0 +1,704 @@
+package doctor
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"isx-auto-scrapper/internal/calendar"
+	"isx-auto-scrapper/internal/common"
+	"isx-auto-scrapper/internal/indicators"
+	"isx-auto-scrapper/internal/liquidity"
+	"isx-auto-scrapper/internal/risk"
+	"isx-auto-scrapper/internal/scraper"
+	"isx-auto-scrapper/internal/strategies"
+)
+
+// Stage health values
+const (
+	StatusOK       = "OK"
+	StatusStale    = "STALE"
+	StatusMissing  = "MISSING"
+	StatusMismatch = "MISMATCH"
+	StatusInvalid  = "INVALID"
+	StatusSkipped  = "-"
+)
+
+const liquidityFile = "liquidity_scores.csv"
+
+// rawHeader is the column layout written by the scraper
+var rawHeader = []string{"Date", "Close", "Open", "High", "Low", "Change", "Change%", "T.Shares", "Volume", "No. Trades"}
+
+// fileInfo holds what the doctor needs to know about a generated CSV file
+type fileInfo struct {
+	Exists    bool
+	ModTime   time.Time
+	Header    []string
+	Rows      int
+	FirstDate time.Time
+	LastDate  time.Time
+}
+
+// statusRank orders the stage health values from nothing to check to the worst finding
+var statusRank = map[string]int{
+	StatusSkipped:  0,
+	StatusOK:       1,
+	StatusStale:    2,
+	StatusMismatch: 3,
+	StatusInvalid:  4,
+	StatusMissing:  5,
+}
+
+// worst returns the worse of two stage health values, so one column can cover every timeframe
+func worst(a, b string) string {
+	if statusRank[b] > statusRank[a] {
+		return b
+	}
+	return a
+}
+
+// flagged reports whether a stage health value needs the stage re-run
+func flagged(status string) bool {
+	return status != StatusOK && status != StatusSkipped
+}
+
+// TickerHealth is the health of every pipeline stage for one ticker. Indicators (with the
+// divergence list), Indicators2 and Strategies cover every configured timeframe.
+type TickerHealth struct {
+	Ticker     string   `json:"ticker"`
+	RawRows    int      `json:"raw_rows"`
+	LastDate   string   `json:"last_date"`
+	Raw        string   `json:"raw"`
+	Indicators string   `json:"indicators"`
+	Numeric    string   `json:"indicators2"`
+	Strategies string   `json:"strategies"`
+	Relative   string   `json:"relative"`
+	Risk       string   `json:"risk"`
+	Issues     []string `json:"issues,omitempty"`
+}
+
+// Diagnosis is the result of a full pipeline check
+type Diagnosis struct {
+	Tickers         []TickerHealth `json:"tickers"`
+	Orphans         []string       `json:"orphans,omitempty"`
+	TempFiles       []string       `json:"temp_files,omitempty"`
+	Summary         string         `json:"strategy_summary"`
+	SummaryIssues   []string       `json:"strategy_summary_issues,omitempty"`
+	Liquidity       string         `json:"liquidity"`
+	LiquidityIssues []string       `json:"liquidity_issues,omitempty"`
+	RiskSummary     string         `json:"risk_summary"`
+	RiskIssues      []string       `json:"risk_summary_issues,omitempty"`
+	ExpectedSession time.Time      `json:"expected_session"`
+	HolidayYears    string         `json:"holiday_years"`   // Years ISX_HOLIDAYS.csv covers
+	HolidaysListed  bool           `json:"holidays_listed"` // Whether they include the expected session's year
+}
+
+// Doctor checks freshness and consistency of all generated artifacts
+type Doctor struct {
+	logger   *common.Logger
+	calendar *calendar.Calendar
+}
+
+// NewDoctor creates a new Doctor instance
+func NewDoctor(logger *common.Logger) *Doctor {
+	return &Doctor{
+		logger:   logger,
+		calendar: calendar.Default(),
+	}
+}
+
+// Diagnose inspects the raw file of every ticker and each file built from it on every configured
+// timeframe, plus the shared outputs
+func (d *Doctor) Diagnose() (*Diagnosis, error) {
+	if err := calendar.DefaultError(); err != nil {
+		return nil, err
+	}
+	tickers, err := common.LoadTickers("TICKERS.csv")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tickers: %w", err)
+	}
+
+	known := make(map[string]bool, len(tickers))
+	for _, t := range tickers {
+		known[t] = true
+	}
+
+	now := time.Now()
+	diag := &Diagnosis{ExpectedSession: d.calendar.LastCompletedSession(now), HolidayYears: d.calendar.CoveredYears()}
+	diag.HolidaysListed = d.calendar.Covers(diag.ExpectedSession)
+	windowStart, _, _ := d.calendar.YearWindow(now)
+	timeframes := indicators.ConfiguredTimeframes()
+
+	// Indicator columns follow the spec file, so a changed spec shows up as an invalid header
+	specs, err := indicators.ConfiguredSpecs()
+	if err != nil {
+		return nil, err
+	}
+	indicatorHeader := indicators.Columns(specs, true)
+	numericHeader := indicators.Columns(specs, false)
+	configured, _, err := strategies.Configured(common.AppConfig.Strategies)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(configured))
+	for k, strategy := range configured {
+		names[k] = strategy.Name()
+	}
+	strategyHeader, err := strategies.Header(append(names, strategies.ConsensusName))
+	if err != nil {
+		return nil, err
+	}
+
+	var newestRaw time.Time
+	newestStrategies := make(map[indicators.Timeframe]time.Time)
+	for _, ticker := range tickers {
+		health := TickerHealth{
+			Ticker:     ticker,
+			Raw:        StatusOK,
+			Indicators: StatusSkipped,
+			Numeric:    StatusSkipped,
+			Strategies: StatusSkipped,
+			Relative:   StatusSkipped,
+			Risk:       StatusSkipped,
+		}
+
+		rawPath := fmt.Sprintf("raw_%s.csv", ticker)
+		raw := inspectCSV(rawPath)
+		if !raw.Exists {
+			health.Raw = StatusMissing
+			health.Issues = append(health.Issues, "no raw data")
+			diag.Tickers = append(diag.Tickers, health)
+			continue
+		}
+		if raw.ModTime.After(newestRaw) {
+			newestRaw = raw.ModTime
+		}
+
+		health.RawRows = raw.Rows
+		if !raw.LastDate.IsZero() {
+			health.LastDate = raw.LastDate.Format("2006-01-02")
+		}
+		if missing := missingColumns(raw.Header, rawHeader); len(missing) > 0 {
+			health.Raw = StatusInvalid
+			health.Issues = append(health.Issues, "raw header missing "+strings.Join(missing, ", "))
+		} else if raw.Rows == 0 {
+			health.Raw = StatusInvalid
+			health.Issues = append(health.Issues, "raw file has no rows")
+		} else if raw.LastDate.Before(diag.ExpectedSession) {
+			health.Raw = StatusStale
+			behind := d.calendar.CountTradingDays(raw.LastDate.AddDate(0, 0, 1), diag.ExpectedSession)
+			health.Issues = append(health.Issues, fmt.Sprintf("raw %d sessions behind %s", behind, diag.ExpectedSession.Format("2006-01-02")))
+		}
+
+		// The calculations see the raw bars after bar cleaning, which may drop some of them
+		frame, err := indicators.LoadRawFrame(rawPath)
+		if err != nil {
+			frame = nil
+		}
+
+		for _, tf := range timeframes {
+			// indicators_<T>.csv is derived from the cleaned bars of the timeframe row for row
+			source := raw
+			if frame != nil {
+				bars, _ := indicators.Resample(frame, tf, d.calendar, now, common.AppConfig.Indicators.PartialPeriods)
+				source = barsInfo(raw, bars)
+			}
+			suffix := tf.Suffix()
+			ind := inspectCSV(fmt.Sprintf("indicators_%s%s.csv", ticker, suffix))
+			health.Indicators = worst(health.Indicators,
+				checkDerived(ind, source, indicatorHeader, true, "indicators"+suffix, &health.Issues))
+
+			// The descriptive calculation also lists the divergences of its bars
+			if ind.Exists {
+				div := inspectCSV(indicators.DivergenceFile(ticker, tf))
+				switch {
+				case !div.Exists:
+					health.Indicators = worst(health.Indicators, StatusMissing)
+					health.Issues = append(health.Issues, "divergences"+suffix+" file missing")
+				case div.ModTime.Before(raw.ModTime):
+					health.Indicators = worst(health.Indicators, StatusStale)
+					health.Issues = append(health.Issues, "divergences"+suffix+" older than its source file")
+				}
+			}
+
+			// Indicators2_<T>.csv is optional; only check it when present
+			if num := inspectCSV(fmt.Sprintf("Indicators2_%s%s.csv", ticker, suffix)); num.Exists {
+				health.Numeric = worst(health.Numeric,
+					checkDerived(num, source, numericHeader, true, "Indicators2"+suffix, &health.Issues))
+			}
+
+			// Strategies_<T>.csv covers the trailing year of indicators_<T>.csv
+			strat := inspectCSV(fmt.Sprintf("Strategies_%s%s.csv", ticker, suffix))
+			switch {
+			case !ind.Exists:
+			case ind.LastDate.Before(windowStart) && !strat.Exists:
+				// No trades in the last 12 months, so no strategy sheet is expected
+			default:
+				status := checkDerived(strat, ind, strategyHeader, false, "strategies"+suffix, &health.Issues)
+				if strat.Exists && strat.Rows > ind.Rows {
+					status = StatusMismatch
+					health.Issues = append(health.Issues, fmt.Sprintf("strategies%s has %d rows, indicators only %d", suffix, strat.Rows, ind.Rows))
+				}
+				health.Strategies = worst(health.Strategies, status)
+			}
+			if strat.ModTime.After(newestStrategies[tf]) {
+				newestStrategies[tf] = strat.ModTime
+			}
+		}
+
+		// relative_<T>.csv ends on the last close and risk_<T>.csv on the last bar with every price,
+		// once the risk window is full
+		if frame != nil {
+			lastClose, lastPriced, priced := pricedDates(frame)
+			if rel := inspectCSV(indicators.RelativeFile(ticker)); rel.Exists || !lastClose.IsZero() {
+				health.Relative = checkDerived(rel, fileInfo{ModTime: raw.ModTime, LastDate: lastClose}, nil, false, "relative", &health.Issues)
+			}
+			if rsk := inspectCSV(risk.File(ticker)); rsk.Exists || priced > common.AppConfig.Risk.Window {
+				health.Risk = checkDerived(rsk, fileInfo{ModTime: raw.ModTime, LastDate: lastPriced}, nil, false, "risk", &health.Issues)
+			}
+		}
+
+		diag.Tickers = append(diag.Tickers, health)
+	}
+
+	diag.Orphans, diag.TempFiles = findOrphans(known)
+	diag.Summary = StatusSkipped
+	for _, tf := range timeframes {
+		status, issues := checkSummary(tf, known, newestStrategies[tf])
+		diag.Summary = worst(diag.Summary, status)
+		diag.SummaryIssues = append(diag.SummaryIssues, issues...)
+	}
+	diag.Liquidity, diag.LiquidityIssues = checkTickerTable(liquidityFile, known, newestRaw)
+	diag.RiskSummary, diag.RiskIssues = checkTickerTable(risk.SummaryFile, known, newestRaw)
+
+	return diag, nil
+}
+
+// barsInfo describes the bars a calculation reads from raw, so derived files can be checked against them
+func barsInfo(raw fileInfo, f *indicators.Frame) fileInfo {
+	info := fileInfo{Exists: true, ModTime: raw.ModTime, Rows: f.Len()}
+	if f.Len() > 0 {
+		info.FirstDate = f.Dates[0]
+		info.LastDate = f.Dates[f.Len()-1]
+	}
+	return info
+}
+
+// pricedDates returns the last bar with a close, the last bar with every price and the number of bars
+// with every price: the bars relative strength and risk are measured on
+func pricedDates(f *indicators.Frame) (lastClose, lastPriced time.Time, priced int) {
+	for i := 0; i < f.Len(); i++ {
+		if f.Close[i] > 0 {
+			lastClose = f.Dates[i]
+		}
+		if f.Open[i] > 0 && f.High[i] > 0 && f.Low[i] > 0 && f.Close[i] > 0 && f.High[i] >= f.Low[i] {
+			lastPriced = f.Dates[i]
+			priced++
+		}
+	}
+	return lastClose, lastPriced, priced
+}
+
+// summaryFile returns the strategy summary of timeframe tf
+func summaryFile(tf indicators.Timeframe) string {
+	return "Strategy_Summary" + tf.Suffix() + ".json"
+}
+
+// checkDerived compares a generated file with the bars it was built from.
+// When sameRows is set the derived file must contain one row per source bar.
+func checkDerived(derived, source fileInfo, header []string, sameRows bool, name string, issues *[]string) string {
+	if !derived.Exists {
+		*issues = append(*issues, name+" file missing")
+		return StatusMissing
+	}
+	if missing := missingColumns(derived.Header, header); len(missing) > 0 {
+		*issues = append(*issues, fmt.Sprintf("%s header missing %d columns (%s)", name, len(missing), missing[0]))
+		return StatusInvalid
+	}
+	if !derived.LastDate.Equal(source.LastDate) {
+		*issues = append(*issues, fmt.Sprintf("%s ends %s, source ends %s", name,
+			formatDate(derived.LastDate), formatDate(source.LastDate)))
+		return StatusStale
+	}
+	if sameRows && derived.Rows != source.Rows {
+		*issues = append(*issues, fmt.Sprintf("%s has %d rows, source %d", name, derived.Rows, source.Rows))
+		return StatusMismatch
+	}
+	if derived.ModTime.Before(source.ModTime) {
+		*issues = append(*issues, name+" older than its source file")
+		return StatusStale
+	}
+	return StatusOK
+}
+
+// checkSummary verifies the strategy summary of timeframe tf against its strategy sheets
+func checkSummary(tf indicators.Timeframe, known map[string]bool, newestStrategies time.Time) (string, []string) {
+	path := summaryFile(tf)
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		if newestStrategies.IsZero() {
+			return StatusSkipped, nil
+		}
+		return StatusMissing, []string{path + " missing"}
+	}
+	if err != nil {
+		return StatusInvalid, []string{err.Error()}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return StatusInvalid, []string{err.Error()}
+	}
+	var summary map[string]json.RawMessage
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return StatusInvalid, []string{fmt.Sprintf("cannot parse %s: %v", path, err)}
+	}
+
+	status := StatusOK
+	var issues []string
+	if info.ModTime().Before(newestStrategies) {
+		status = StatusStale
+		issues = append(issues, fmt.Sprintf("%s built before the latest Strategies_*%s.csv", path, tf.Suffix()))
+	}
+
+	var orphaned, absent []string
+	for ticker := range summary {
+		if !known[ticker] {
+			orphaned = append(orphaned, ticker)
+		}
+	}
+	for ticker := range known {
+		if _, ok := summary[ticker]; ok {
+			continue
+		}
+		if _, err := os.Stat(fmt.Sprintf("Strategies_%s%s.csv", ticker, tf.Suffix())); err == nil {
+			absent = append(absent, ticker)
+		}
+	}
+	sort.Strings(orphaned)
+	sort.Strings(absent)
+	if len(orphaned) > 0 {
+		status = StatusStale
+		issues = append(issues, path+" has entries for tickers not in TICKERS.csv: "+strings.Join(orphaned, ", "))
+	}
+	if len(absent) > 0 {
+		status = StatusStale
+		issues = append(issues, path+" has no entry for tickers with strategy sheets: "+strings.Join(absent, ", "))
+	}
+
+	return status, issues
+}
+
+// checkTickerTable verifies a table with one row per ticker, such as liquidity_scores.csv, is newer
+// than the raw data and only lists tickers from TICKERS.csv
+func checkTickerTable(path string, known map[string]bool, newestRaw time.Time) (string, []string) {
+	info := inspectCSV(path)
+	if !info.Exists {
+		if newestRaw.IsZero() {
+			return StatusSkipped, nil
+		}
+		return StatusMissing, []string{path + " missing"}
+	}
+
+	status := StatusOK
+	var issues []string
+	if info.ModTime.Before(newestRaw) {
+		status = StatusStale
+		issues = append(issues, "older than the newest raw_*.csv")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return StatusInvalid, []string{err.Error()}
+	}
+	defer file.Close()
+
+	records, err := readAll(file)
+	if err != nil {
+		return StatusInvalid, []string{err.Error()}
+	}
+	var orphaned []string
+	for i := 1; i < len(records); i++ {
+		if len(records[i]) > 0 && !known[records[i][0]] {
+			orphaned = append(orphaned, records[i][0])
+		}
+	}
+	if len(orphaned) > 0 {
+		status = StatusStale
+		issues = append(issues, "rows for tickers not in TICKERS.csv: "+strings.Join(orphaned, ", "))
+	}
+
+	return status, issues
+}
+
+// findOrphans lists per-ticker files whose ticker is no longer in TICKERS.csv and leftover temp files
+func findOrphans(known map[string]bool) (orphans, temps []string) {
+	temps, _ = filepath.Glob("raw_*_temp.csv")
+	sort.Strings(temps)
+
+	for _, prefix := range []string{"raw_", "indicators_", "Indicators2_", "Strategies_", "divergences_", "relative_", "risk_"} {
+		matches, _ := filepath.Glob(prefix + "*.csv")
+		for _, match := range matches {
+			if match == risk.SummaryFile {
+				continue
+			}
+			ticker := strings.TrimSuffix(strings.TrimPrefix(match, prefix), ".csv")
+			if prefix != "raw_" {
+				ticker = indicators.TrimTimeframe(ticker)
+			}
+			if strings.HasSuffix(ticker, "_temp") || known[ticker] {
+				continue
+			}
+			orphans = append(orphans, match)
+		}
+	}
+	sort.Strings(orphans)
+	return orphans, temps
+}
+
+// Healthy reports whether the diagnosis found nothing to repair
+func (diag *Diagnosis) Healthy() bool {
+	for _, h := range diag.Tickers {
+		if len(h.Issues) > 0 {
+			return false
+		}
+	}
+	return len(diag.Orphans) == 0 && len(diag.TempFiles) == 0 &&
+		len(diag.SummaryIssues) == 0 && len(diag.LiquidityIssues) == 0 && len(diag.RiskIssues) == 0
+}
+
+// PrintTable writes the per-ticker health table followed by pipeline-wide findings
+func (diag *Diagnosis) PrintTable(out io.Writer) {
+	fmt.Fprintf(out, "Expected last session: %s\n", diag.ExpectedSession.Format("2006-01-02"))
+	if !diag.HolidaysListed {
+		fmt.Fprintf(out, "%s covers %s only: sessions are expected on the unlisted lunar holidays of %d\n",
+			calendar.HolidaysFile, diag.HolidayYears, diag.ExpectedSession.Year())
+	}
+	fmt.Fprintln(out)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TICKER\tLAST DATE\tROWS\tRAW\tINDICATORS\tINDICATORS2\tSTRATEGIES\tRELATIVE\tRISK\tISSUES")
+	for _, h := range diag.Tickers {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", h.Ticker, h.LastDate, h.RawRows, h.Raw,
+			h.Indicators, h.Numeric, h.Strategies, h.Relative, h.Risk, strings.Join(h.Issues, "; "))
+	}
+	w.Flush()
+
+	fmt.Fprintf(out, "\nStrategy_Summary*.json: %s\n", diag.Summary)
+	for _, issue := range diag.SummaryIssues {
+		fmt.Fprintf(out, "  - %s\n", issue)
+	}
+	fmt.Fprintf(out, "%s: %s\n", liquidityFile, diag.Liquidity)
+	for _, issue := range diag.LiquidityIssues {
+		fmt.Fprintf(out, "  - %s\n", issue)
+	}
+	fmt.Fprintf(out, "%s: %s\n", risk.SummaryFile, diag.RiskSummary)
+	for _, issue := range diag.RiskIssues {
+		fmt.Fprintf(out, "  - %s\n", issue)
+	}
+
+	if len(diag.Orphans) > 0 {
+		fmt.Fprintf(out, "\nOrphaned files (ticker not in TICKERS.csv):\n")
+		for _, f := range diag.Orphans {
+			fmt.Fprintf(out, "  - %s\n", f)
+		}
+	}
+	if len(diag.TempFiles) > 0 {
+		fmt.Fprintf(out, "\nLeftover temp files:\n")
+		for _, f := range diag.TempFiles {
+			fmt.Fprintf(out, "  - %s\n", f)
+		}
+	}
+}
+
+// Fix re-runs only the pipeline stages the diagnosis found stale, and only for the tickers it flagged.
+// Orphaned and temp files are reported but never deleted.
+func (d *Doctor) Fix(diag *Diagnosis) error {
+	var fetch []string
+	for _, h := range diag.Tickers {
+		if h.Raw == StatusStale || h.Raw == StatusMissing {
+			fetch = append(fetch, h.Ticker)
+		}
+	}
+
+	fetched := make(map[string]bool)
+	for i, ticker := range fetch {
+		d.logger.Info("Fetching %s (%d/%d)", ticker, i+1, len(fetch))
+		dataFetcher := scraper.NewDataFetcher(d.logger.WithStage("fetch").WithTicker(ticker))
+		if err := dataFetcher.FetchData(ticker); err != nil {
+			d.logger.Error("Failed to fetch data for %s: %v", ticker, err)
+			continue
+		}
+		fetched[ticker] = true
+	}
+
+	// Freshly fetched bars extend the saved indicator state; files the diagnosis flagged are rebuilt
+	// from the whole history, since a file no newer than its state would be left as it is
+	var strategyTickers []string
+	rerunRelative, rerunRisk := len(fetched) > 0, len(fetched) > 0 || flagged(diag.RiskSummary)
+	for _, h := range diag.Tickers {
+		if h.Raw == StatusMissing && !fetched[h.Ticker] {
+			continue
+		}
+		calc := fetched[h.Ticker] || flagged(h.Indicators)
+		calcNum := (fetched[h.Ticker] && h.Numeric != StatusSkipped) || flagged(h.Numeric)
+		for _, tf := range indicators.ConfiguredTimeframes() {
+			tickerLogger := d.logger.WithStage("calc").WithTicker(h.Ticker)
+			if calc {
+				calculator := indicators.NewIndicatorsCalculator(tickerLogger)
+				calculator.SetFullRecompute(flagged(h.Indicators))
+				calculator.SetTimeframe(tf)
+				if err := calculator.CalculateAll(h.Ticker); err != nil {
+					d.logger.Error("Failed to calculate %s indicators for %s: %v", tf.Name(), h.Ticker, err)
+				}
+			}
+			if calcNum {
+				calculator := indicators.NewNumericalIndicatorsCalculator(tickerLogger)
+				calculator.SetFullRecompute(flagged(h.Numeric))
+				calculator.SetTimeframe(tf)
+				if err := calculator.CalculateAllNums(h.Ticker); err != nil {
+					d.logger.Error("Failed to calculate %s numerical indicators for %s: %v", tf.Name(), h.Ticker, err)
+				}
+			}
+		}
+		if calc || flagged(h.Strategies) || flagged(h.Relative) {
+			strategyTickers = append(strategyTickers, h.Ticker)
+		}
+		rerunRelative = rerunRelative || flagged(h.Relative)
+		rerunRisk = rerunRisk || flagged(h.Risk)
+	}
+
+	// Relative strength ranks the whole universe, and the strategies read it
+	if rerunRelative {
+		d.logger.Info("Re-running relative strength")
+		if err := indicators.NewRelativeStrengthCalc(d.logger.WithStage("calc")).CalculateAll(); err != nil {
+			return err
+		}
+	}
+
+	for _, tf := range indicators.ConfiguredTimeframes() {
+		stratService := strategies.NewStrategies(d.logger.WithStage("strategies"))
+		stratService.SetTimeframe(tf)
+		if len(strategyTickers) > 0 {
+			d.logger.Info("Re-running %s strategies for %s", tf.Name(), strings.Join(strategyTickers, ", "))
+			if err := stratService.ApplyStrategiesAndSaveFor(strategyTickers); err != nil {
+				return err
+			}
+			if err := stratService.ApplyConsensusFor(strategyTickers); err != nil {
+				return err
+			}
+		}
+		if len(strategyTickers) > 0 || flagged(diag.Summary) {
+			d.logger.Info("Re-building %s", summaryFile(tf))
+			if err := stratService.SummarizeStrategyActions(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(fetched) > 0 || flagged(diag.Liquidity) {
+		d.logger.Info("Re-running liquidity stage")
+		if err := liquidity.NewLiquidityCalc(d.logger.WithStage("liquidity")).CalculateScores(); err != nil {
+			return err
+		}
+	}
+
+	if rerunRisk {
+		d.logger.Info("Re-running risk stage")
+		if err := risk.NewRiskCalc(d.logger.WithStage("risk")).CalculateAll(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// inspectCSV reads the header, row count and date range of a CSV file
+func inspectCSV(path string) fileInfo {
+	var info fileInfo
+	stat, err := os.Stat(path)
+	if err != nil {
+		return info
+	}
+	info.Exists = true
+	info.ModTime = stat.ModTime()
+
+	file, err := os.Open(path)
+	if err != nil {
+		return info
+	}
+	defer file.Close()
+
+	records, err := readAll(file)
+	if err != nil || len(records) == 0 {
+		return info
+	}
+
+	info.Header = records[0]
+	for _, record := range records[1:] {
+		if len(record) == 0 || strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		info.Rows++
+		date, ok := parseDate(record[0])
+		if !ok {
+			continue
+		}
+		if info.FirstDate.IsZero() || date.Before(info.FirstDate) {
+			info.FirstDate = date
+		}
+		if date.After(info.LastDate) {
+			info.LastDate = date
+		}
+	}
+	return info
+}
+
+// readAll reads every record, tolerating ragged rows
+func readAll(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	return reader.ReadAll()
+}
+
+// parseDate accepts both raw (2006-01-02) and indicator (RFC3339) dates
+func parseDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), true
+	}
+	return time.Time{}, false
+}
+
+// missingColumns lists the expected columns absent from header
+func missingColumns(header, expected []string) []string {
+	present := make(map[string]bool, len(header))
+	for _, h := range header {
+		present[strings.TrimPrefix(h, "\ufeff")] = true
+	}
+	var missing []string
+	for _, e := range expected {
+		if !present[e] {
+			missing = append(missing, e)
+		}
+	}
+	return missing
+}
+
+// formatDate formats a date, showing "none" for the zero value
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "none"
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,216 @@
+package i18n
+
+// arabic translates the English catalogue, plus the signal labels, candlestick patterns and report
+// columns whose English text lives with the code
+var arabic = map[string]string{
+	// Indicator descriptions
+	"desc.cross.golden":               "شراء - رُصد تقاطع ذهبي: تجاوز المتوسط SMA50 قصير الأجل المتوسط SMA200 طويل الأجل صعوداً، وهي إشارة إيجابية. تاريخياً يدل هذا النمط على المراحل الأولى لسوق صاعدة طويلة. التفسير: قد يرتفع سعر السهم، ما يجعله وقتاً مناسباً للشراء أو لزيادة المركز.",
+	"desc.cross.death":                "بيع - رُصد تقاطع الموت: هبط المتوسط SMA50 قصير الأجل تحت المتوسط SMA200 طويل الأجل، وهي إشارة سلبية. غالباً ما يسبق هذا النمط سوقاً هابطة أو فترة بيع طويلة. التفسير: توخَّ الحذر، وفكّر في تقليل انكشافك أو التحوط من الخسائر.",
+	"desc.cross.none":                 "محايد - لا يوجد تقاطع مهم: لا تُظهر السوق إشارات صعود أو هبوط واضحة حالياً، وقد يدل ذلك على فترة تماسك أو حركة عرضية. التفسير: راقب المؤشرات الأخرى وتابع أخبار السوق وحافظ على استراتيجية متنوعة.",
+	"desc.sma10.up":                   "شراء - السعر تجاوز SMA10: ارتفع سعر السهم فوق متوسط آخر 10 فترات، وهذا التقاطع الصاعد علامة تاريخية على زخم صاعد قصير الأجل. التفسير: قد تكون فرصة للاستفادة من الزخم، مع التأكد من مؤشرات أخرى.",
+	"desc.sma10.down":                 "بيع - السعر هبط تحت SMA10: انخفض سعر السهم تحت متوسط آخر 10 فترات، وقد يشير ذلك إلى تراجع قصير الأجل أو تصحيح محتمل. التفسير: من الحكمة توخي الحذر وتعديل الاستراتيجية أو وضع أوامر وقف الخسارة.",
+	"desc.sma10.none":                 "محايد - السعر يتذبذب حول SMA10: يتحرك سعر السهم حول متوسط آخر 10 فترات، ما يدل على تردد السوق، وقد يكون علامة على التماسك. التفسير: ابقَ متيقظاً وراقب المؤشرات الأخرى واستعد لاختراق محتمل.",
+	"desc.major_sma.up":               "شراء؛ السعر تجاوز متوسطاً رئيسياً: تجاوز سعر السهم متوسطاً متحركاً مهماً، ما يدل على زخم صاعد قوي واهتمام مؤسسي واحتمال استمرار الاتجاه. التفسير: فكّر في فتح مراكز شراء مع إدارة سليمة للمخاطر.",
+	"desc.major_sma.down":             "بيع؛ السعر هبط تحت متوسط رئيسي: هبط سعر السهم تحت متوسط متحرك أساسي، ما يشير إلى ضعف محتمل وقد يكون بداية اتجاه هابط. التفسير: فكّر في تقليل المراكز أو وضع أوامر وقف حماية.",
+	"desc.major_sma.none":             "محايد؛ السعر يحترم مستويات المتوسطات: يتداول سعر السهم بانسجام مع المتوسطات المتحركة الرئيسية، ما يعكس توازن السوق. التفسير: انتظر إشارات اتجاه أوضح قبل إجراء تغييرات كبيرة على المراكز.",
+	"desc.rsi.overbought":             "بيع - تشبع شرائي في RSI: مؤشر RSI فوق 70، ما يعني أن السهم قد يكون في منطقة تشبع شرائي وقد يظهر ضغط بيع قريباً. التفسير: فكّر في جني الأرباح أو تقليل المراكز، مع مراقبة استمرار الاتجاه في الأسواق القوية.",
+	"desc.rsi.oversold":               "شراء - تشبع بيعي في RSI: مؤشر RSI تحت 30، ما يعني أن السهم قد يكون في منطقة تشبع بيعي مع احتمال ارتداد أو انعكاس. التفسير: ابحث عن فرص شراء، لكن تأكد بمؤشرات أخرى وبحركة السعر.",
+	"desc.rsi.above_mid":              "محايد مائل للصعود - RSI فوق خط المنتصف: مؤشر RSI فوق 50، ما يُظهر زخماً صاعداً غير مفرط، والاتجاه سليم مع مجال لمزيد من الارتفاع. التفسير: حافظ على النظرة الإيجابية وراقب ظهور التشبع الشرائي.",
+	"desc.rsi.below_mid":              "محايد مائل للهبوط - RSI تحت خط المنتصف: مؤشر RSI تحت 50، ما يُظهر زخماً هابطاً دون تشبع بيعي مفرط، والاتجاه ضعيف مع احتمال مزيد من التراجع. التفسير: توخَّ الحذر وانتظر التأكيد قبل الشراء.",
+	"desc.stoch.overbought":           "بيع؛ تشبع شرائي في الستوكاستك: الخطان %K و%D فوق 80، ما يدل على تشبع شرائي وقد يتباطأ الزخم. التفسير: فكّر في جني الأرباح أو تضييق أوامر الوقف فقد يكون التصحيح وشيكاً.",
+	"desc.stoch.oversold":             "شراء؛ تشبع بيعي في الستوكاستك: الخطان %K و%D تحت 20، ما يدل على تشبع بيعي وقد يتشكل ارتداد. التفسير: ابحث عن فرص شراء عند تأكد الزخم الصاعد.",
+	"desc.stoch.bullish_cross":        "محايد مائل للصعود؛ تقاطع صاعد في الستوكاستك: الخط %K فوق %D، ما يدل على زخم صاعد ويبدو أن الاتجاه يتقوى. التفسير: راقب استمرار الحركة الصاعدة.",
+	"desc.stoch.bearish_cross":        "محايد مائل للهبوط؛ تقاطع هابط في الستوكاستك: الخط %K تحت %D، ما يدل على زخم هابط وقد يتشكل ضعف. التفسير: توخَّ الحذر وفكّر في مراكز دفاعية.",
+	"desc.cmf.strong":                 "شراء؛ تدفق نقدي قوي: مؤشر CMF موجب وقوي، ما يدل على تجميع من المستثمرين المؤسسيين وتدفق الأموال إلى السهم. التفسير: يدعم هذا حركة سعرية صاعدة ويشير إلى اهتمام بالشراء.",
+	"desc.cmf.weak":                   "بيع؛ تدفق نقدي ضعيف: مؤشر CMF سالب وضعيف، ما يدل على تصريف من المستثمرين المؤسسيين وخروج الأموال من السهم. التفسير: يدعم هذا حركة سعرية هابطة ويشير إلى ضغط بيع.",
+	"desc.cmf.mild_accumulation":      "محايد مائل للصعود، تجميع خفيف: مؤشر CMF موجب قليلاً، ما يُظهر اهتماماً خفيفاً بالشراء، والاتجاه مدعوم لكن ليس بقوة. التفسير: نظرة إيجابية حذرة مع انتظار تأكيد أقوى.",
+	"desc.cmf.mild_distribution":      "محايد مائل للهبوط؛ تصريف خفيف: مؤشر CMF سالب قليلاً، ما يُظهر ضغط بيع خفيفاً وبعض الضعف في الاتجاه. التفسير: توخَّ الحذر وراقب تدهور الاتجاه.",
+	"desc.macd.bullish":               "شراء؛ MACD إيجابي: خط MACD فوق خط الإشارة مع مدرج تكراري موجب، ما يدل على زخم صاعد قوي واتجاه يتسارع صعوداً. التفسير: فكّر في فتح مراكز شراء أو زيادة المراكز القائمة.",
+	"desc.macd.bearish":               "بيع؛ MACD سلبي: خط MACD تحت خط الإشارة مع مدرج تكراري سالب، ما يدل على زخم هابط قوي واتجاه يتسارع هبوطاً. التفسير: فكّر في تقليل المراكز أو فتح مراكز بيع.",
+	"desc.macd.above_signal":          "محايد مائل للصعود - MACD فوق خط الإشارة: خط MACD فوق خط الإشارة لكن الزخم يضعف، وقد يفقد الاتجاه الصاعد قوته. التفسير: حافظ على النظرة الإيجابية وراقب إشارات الانعكاس.",
+	"desc.macd.below_signal":          "محايد مائل للهبوط - MACD تحت خط الإشارة: خط MACD تحت خط الإشارة لكن الزخم يضعف، وقد يفقد الاتجاه الهابط قوته. التفسير: حافظ على النظرة السلبية وراقب إشارات الانعكاس.",
+	"desc.obv.accumulation":           "شراء؛ تجميع قوي بالحجم: مؤشر OBV يرتفع بقوة، ما يدل على تجميع كثيف وشراء نشط من الأموال الذكية. التفسير: يدعم هذا حركة سعرية صاعدة ويشير إلى اهتمام مؤسسي قوي.",
+	"desc.obv.distribution":           "بيع؛ تصريف قوي بالحجم: مؤشر OBV ينخفض بقوة، ما يدل على تصريف كثيف وبيع نشط من الأموال الذكية. التفسير: يدعم هذا حركة سعرية هابطة ويشير إلى بيع مؤسسي.",
+	"desc.obv.mild_accumulation":      "محايد مائل للصعود؛ تجميع خفيف بالحجم: مؤشر OBV يرتفع باعتدال، ما يُظهر تجميعاً ثابتاً واهتماماً بالشراء غير طاغٍ. التفسير: نمط حجم إيجابي بحذر.",
+	"desc.obv.mild_distribution":      "محايد مائل للهبوط؛ تصريف خفيف بالحجم: مؤشر OBV ينخفض باعتدال، ما يُظهر تصريفاً ثابتاً وضغط بيع غير طاغٍ. التفسير: نمط حجم سلبي بحذر.",
+	"desc.psar.bullish":               "شراء؛ PSAR إيجابي: السعر فوق PSAR، ما يدل على اتجاه صاعد، ويشير مؤشر SAR المكافئ إلى استمرار الزخم الصاعد. التفسير: تقترح أنظمة تتبع الاتجاه الإبقاء على مراكز الشراء مع استخدام PSAR وقفاً متحركاً.",
+	"desc.psar.bearish":               "بيع؛ PSAR سلبي: السعر تحت PSAR، ما يدل على اتجاه هابط، ويشير مؤشر SAR المكافئ إلى استمرار الزخم الهابط. التفسير: تقترح أنظمة تتبع الاتجاه الإبقاء على مراكز البيع أو تجنب الشراء.",
+	"desc.atr.high":                   "تحذير من تقلب مرتفع: يشير ATR إلى تقلب مرتفع (أكثر من 5% من السعر)، والسوق غير مستقرة مع تأرجحات سعرية كبيرة. التفسير: استخدم أوامر وقف أوسع وقلّل أحجام المراكز وتوقع مخاطر أعلى.",
+	"desc.atr.moderate":               "تقلب معتدل: يُظهر ATR تقلباً معتدلاً (بين 2% و5% من السعر)، وظروف السوق طبيعية مع حركة سعرية معقولة. التفسير: تنطبق إدارة المخاطر المعتادة مع مراقبة تغيرات التقلب.",
+	"desc.atr.low":                    "تقلب منخفض: يشير ATR إلى تقلب منخفض (أقل من 2% من السعر)، والسوق هادئة نسبياً مع تحركات سعرية صغيرة. التفسير: فكّر في أوامر وقف أضيق، وراقب احتمال اندفاع التقلب.",
+	"desc.bollinger.above":            "بيع؛ فوق نطاق بولينجر العلوي: أغلق السعر فوق النطاق العلوي، فالحركة ممتدة بعيداً عن متوسطها. التفسير: توقع تراجعاً نحو النطاق الأوسط ما لم يبدأ اتجاه قوي.",
+	"desc.bollinger.below":            "شراء؛ تحت نطاق بولينجر السفلي: أغلق السعر تحت النطاق السفلي، فالحركة ممتدة بعيداً تحت متوسطها. التفسير: الارتداد نحو النطاق الأوسط مرجح، فتأكد من الزخم قبل الشراء.",
+	"desc.bollinger.upper_half":       "محايد مائل للصعود؛ النصف العلوي من نطاقات بولينجر: السعر بين النطاق الأوسط والنطاق العلوي. التفسير: المشترون يسيطرون، والنطاق العلوي هو المقاومة التالية.",
+	"desc.bollinger.lower_half":       "محايد مائل للهبوط؛ النصف السفلي من نطاقات بولينجر: السعر بين النطاق السفلي والنطاق الأوسط. التفسير: البائعون يسيطرون، والنطاق السفلي هو الدعم التالي.",
+	"desc.keltner.squeeze":            "محايد؛ انضغاط التقلب: نطاقات بولينجر داخل قناة كيلتنر، فالتقلب منضغط على نحو غير معتاد. التفسير: غالباً ما تتبعه حركة حادة، فانتظر انفراج الانضغاط وتداول في اتجاهه.",
+	"desc.keltner.above":              "شراء؛ فوق قناة كيلتنر: أغلق السعر فوق القناة العلوية، وهي حركة صاعدة قوية خارج نطاقها المعتاد. التفسير: يمكن لمتتبعي الاتجاه مجاراة الحركة مع وقف قرب الخط الأوسط.",
+	"desc.keltner.below":              "بيع؛ تحت قناة كيلتنر: أغلق السعر تحت القناة السفلية، وهي حركة هابطة قوية خارج نطاقها المعتاد. التفسير: تجنب فتح مراكز شراء جديدة حتى يعود السعر داخل القناة.",
+	"desc.keltner.inside":             "محايد؛ داخل قناة كيلتنر: يتحرك السعر ضمن نطاق تقلبه المعتاد. التفسير: لا يوجد اختراق، فاعتمد على مؤشرات أخرى لتحديد الاتجاه.",
+	"desc.donchian.breakout":          "شراء؛ اختراق دونشيان: بلغ السعر أعلى قناة دونشيان، وهي قمة جديدة للفترة. التفسير: الاختراقات إلى قمم جديدة كثيراً ما تبدأ اتجاهات، ففكّر في الدخول مع وقف عند الخط الأوسط.",
+	"desc.donchian.breakdown":         "بيع؛ كسر دونشيان: بلغ السعر أدنى قناة دونشيان، وهو قاع جديد للفترة. التفسير: الكسر إلى قيعان جديدة كثيراً ما يبدأ اتجاهات هابطة، ففكّر في تقليل المراكز.",
+	"desc.donchian.upper":             "محايد مائل للصعود؛ النصف العلوي من نطاق دونشيان: السعر في النصف العلوي من نطاقه الأخير. التفسير: الاتجاه يميل للصعود، فراقب كسر قمة القناة.",
+	"desc.donchian.lower":             "محايد مائل للهبوط؛ النصف السفلي من نطاق دونشيان: السعر في النصف السفلي من نطاقه الأخير. التفسير: الاتجاه يميل للهبوط، فراقب كسر قاع القناة.",
+	"desc.adx.uptrend":                "شراء؛ اتجاه صاعد قوي: ADX فوق 25 و+DI فوق -DI، فالاتجاه قوي والمشترون يسيطرون. التفسير: صفقات تتبع الاتجاه مدعومة، فحرّك أوامر الوقف بدلاً من جني الأرباح مبكراً.",
+	"desc.adx.downtrend":              "بيع؛ اتجاه هابط قوي: ADX فوق 25 و-DI فوق +DI، فالاتجاه قوي والبائعون يسيطرون. التفسير: لا تحاول التقاط السعر الهابط، وانتظر تراجع ADX قبل الشراء.",
+	"desc.adx.no_trend":               "محايد؛ لا اتجاه واضح: ADX تحت 20، فالسوق تتحرك في نطاق بلا اتجاه. التفسير: إشارات الاتجاه غير موثوقة الآن، والتداول ضمن النطاق أو الانتظار أفضل.",
+	"desc.adx.developing_up":          "محايد مائل للصعود؛ اتجاه صاعد قيد التشكل: ADX بين 20 و25 و+DI فوق -DI، وقد يتشكل اتجاه صاعد. التفسير: انتظر ارتفاع ADX فوق 25 للتأكيد.",
+	"desc.adx.developing_down":        "محايد مائل للهبوط؛ اتجاه هابط قيد التشكل: ADX بين 20 و25 و-DI فوق +DI، وقد يتشكل اتجاه هابط. التفسير: انتظر ارتفاع ADX فوق 25 للتأكيد.",
+	"desc.aroon.uptrend":              "شراء؛ اتجاه صاعد في أرون: أرون الصاعد فوق 70 وأرون الهابط تحت 30، فالقمم الجديدة حديثة والقيعان الجديدة قديمة. التفسير: اتجاه صاعد ناشئ أو سليم، ففضّل مراكز الشراء.",
+	"desc.aroon.downtrend":            "بيع؛ اتجاه هابط في أرون: أرون الهابط فوق 70 وأرون الصاعد تحت 30، فالقيعان الجديدة حديثة والقمم الجديدة قديمة. التفسير: اتجاه هابط ناشئ أو سليم، فتجنب مراكز الشراء.",
+	"desc.aroon.positive":             "محايد مائل للصعود؛ مذبذب أرون موجب: القمم الأخيرة أحدث من القيعان الأخيرة، وهو ضغط صاعد دون اتجاه واضح. التفسير: مِل إلى الإيجابية وانتظر ارتفاع أرون الصاعد فوق 70.",
+	"desc.aroon.not_positive":         "محايد مائل للهبوط؛ مذبذب أرون غير موجب: القيعان الأخيرة بحداثة القمم الأخيرة أو أحدث منها، وهو ضغط هابط دون اتجاه واضح. التفسير: مِل إلى الحذر وانتظر ارتفاع أرون الهابط فوق 70.",
+	"desc.ichimoku.bullish":           "شراء؛ فوق السحابة: السعر فوق سحابة إيشيموكو وتنكان فوق كيجن وخط تشيكو فوق الإغلاق الذي يتأخر عنه، فالاتجاه والزخم والخط المتأخر متفقة صعوداً. التفسير: إعداد إيجابي، وقمة السحابة هي أول دعم.",
+	"desc.ichimoku.bearish":           "بيع؛ تحت السحابة: السعر تحت سحابة إيشيموكو وتنكان تحت كيجن وخط تشيكو تحت الإغلاق الذي يتأخر عنه، فالاتجاه والزخم والخط المتأخر متفقة هبوطاً. التفسير: إعداد سلبي، وقاع السحابة هو أول مقاومة.",
+	"desc.ichimoku.above":             "محايد مائل للصعود؛ فوق السحابة: السعر فوق السحابة لكن تنكان ليس فوق كيجن أو خط تشيكو ليس فوق الإغلاق الذي يتأخر عنه، فالاتجاه صاعد بينما يخفت الزخم. التفسير: احتفظ بالمراكز وانتظر تأكيد تنكان وتشيكو قبل الزيادة.",
+	"desc.ichimoku.below":             "محايد مائل للهبوط؛ تحت السحابة: السعر تحت السحابة لكن تنكان ليس تحت كيجن أو خط تشيكو ليس تحت الإغلاق الذي يتأخر عنه، فالاتجاه هابط بينما يتباطأ البيع. التفسير: ابقَ حذراً حتى يستعيد السعر السحابة.",
+	"desc.ichimoku.inside":            "محايد؛ داخل السحابة: السعر داخل سحابة إيشيموكو، والسوق مترددة. التفسير: انتظر إغلاقاً فوق السحابة أو تحتها قبل التصرف.",
+	"desc.mfi.overbought":             "بيع؛ تشبع شرائي في MFI: مؤشر تدفق الأموال فوق 80، فقد دفع حجم شراء كبير السعر إلى الأعلى. التفسير: قد يكون الشراء قد استُنفد، ففكّر في جني الأرباح.",
+	"desc.mfi.oversold":               "شراء؛ تشبع بيعي في MFI: مؤشر تدفق الأموال تحت 20، فقد دفع حجم بيع كبير السعر إلى الأسفل. التفسير: قد يكون البيع قد استُنفد، فابحث عن ارتداد.",
+	"desc.mfi.above_mid":              "محايد مائل للصعود؛ MFI فوق خط المنتصف: الأموال الداخلة في أيام الصعود تفوق الخارجة في أيام الهبوط. التفسير: الحجم يدعم السعر.",
+	"desc.mfi.below_mid":              "محايد مائل للهبوط؛ MFI تحت خط المنتصف: الأموال الخارجة في أيام الهبوط تفوق الداخلة في أيام الصعود. التفسير: الحجم لا يدعم السعر.",
+	"desc.chaikin.positive":           "محايد مائل للصعود؛ مذبذب تشايكن موجب: التجميع يتسارع مع إغلاقات قرب القمم بحجم جيد. التفسير: ضغط الشراء يتزايد، وعبور الصفر من الأسفل يؤكده.",
+	"desc.chaikin.negative":           "محايد مائل للهبوط؛ مذبذب تشايكن سالب: التصريف يتسارع مع إغلاقات قرب القيعان بحجم جيد. التفسير: ضغط البيع يتزايد، وعبور الصفر من الأعلى يؤكده.",
+	"desc.vwap.above":                 "محايد مائل للصعود؛ فوق VWAP: السعر فوق متوسط السعر المرجح بالحجم، فالمشترون الجدد رابحون. التفسير: يعمل VWAP دعماً، وقد تكون التراجعات نحوه فرصاً للشراء.",
+	"desc.vwap.below":                 "محايد مائل للهبوط؛ تحت VWAP: السعر تحت متوسط السعر المرجح بالحجم، فالمشترون الجدد خاسرون. التفسير: يعمل VWAP مقاومة، وقد تُباع الارتفاعات نحوه.",
+	"desc.vwap.at":                    "محايد؛ عند VWAP: السعر يساوي متوسط السعر المرجح بالحجم. التفسير: قيمة عادلة بحسب الحجم، فانتظر ابتعاد السعر عنه.",
+	"desc.candlestick":                "%s؛ %s (القوة %g): %s",
+	"desc.levels.squeezed":            "محايد؛ بين مستويين قريبين: الدعم عند %g والمقاومة عند %g كلاهما ضمن 1%%، فالسعر محصور بين منطقتين. التفسير: انتظر إغلاقاً خارج أي من المنطقتين.",
+	"desc.levels.near_support":        "محايد مائل للصعود؛ قرب الدعم: السعر أعلى بنسبة %g%% من منطقة دعم عند %g ارتد منها سابقاً. التفسير: دافع المشترون عن هذه المنطقة، والارتداد مرجح ما لم تُكسر.",
+	"desc.levels.near_resistance":     "محايد مائل للهبوط؛ قرب المقاومة: السعر أدنى بنسبة %g%% من منطقة مقاومة عند %g ارتد منها سابقاً. التفسير: دافع البائعون عن هذه المنطقة، فتوقع توقفاً ما لم تُخترق.",
+	"desc.levels.below_all":           "بيع؛ تحت كل الدعوم: لم تبقَ منطقة دعم تحت السعر، وأقرب مقاومة عند %g. التفسير: كسر السعر المستويات المعروفة هبوطاً.",
+	"desc.levels.above_all":           "شراء؛ فوق كل المقاومات: لم تبقَ منطقة مقاومة فوق السعر، وأقرب دعم عند %g. التفسير: السعر في منطقة مفتوحة بعد الاختراق.",
+	"desc.levels.between":             "محايد؛ بين المناطق: الدعم عند %g (أدنى بنسبة %g%%) والمقاومة عند %g (أعلى بنسبة %g%%). التفسير: لا يوجد مستوى قريب، فاتبع مؤشرات الاتجاه.",
+	"desc.divergence":                 "%s؛ تباعد %s (القوة %g): %s",
+	"desc.divergence.regular_bullish": "سجّل السعر قاعاً أدنى بينما سجّل %[1]s قاعاً أعلى، فضغط البيع يتلاشى. التفسير: الانعكاس صعوداً مرجح، وإغلاق فوق آخر قمة يؤكده.",
+	"desc.divergence.hidden_bullish":  "سجّل السعر قاعاً أعلى بينما سجّل %[1]s قاعاً أدنى، فقد استوعب الاتجاه الصاعد تصحيحاً أعمق. التفسير: استمرار الاتجاه الصاعد مرجح.",
+	"desc.divergence.regular_bearish": "سجّل السعر قمة أعلى بينما سجّل %[1]s قمة أدنى، فضغط الشراء يتلاشى. التفسير: الانعكاس هبوطاً مرجح، وإغلاق تحت آخر قاع يؤكده.",
+	"desc.divergence.hidden_bearish":  "سجّل السعر قمة أدنى بينما سجّل %[1]s قمة أعلى، فقد استوعب الاتجاه الهابط ارتفاعاً أقوى. التفسير: استمرار الاتجاه الهابط مرجح.",
+	"desc.also":                       " أيضاً: %s.",
+	"desc.list_separator":             "، ",
+
+	"action.Buy":             "شراء",
+	"action.Sell":            "بيع",
+	"action.Neutral":         "محايد",
+	"action.Neutral-Bullish": "محايد مائل للصعود",
+	"action.Neutral-Bearish": "محايد مائل للهبوط",
+
+	"divergence.label":   "%[2]s %[1]s (%[3]s)",
+	"divergence.Regular": "منتظم",
+	"divergence.Hidden":  "مخفي",
+	"divergence.Bullish": "صاعد",
+	"divergence.Bearish": "هابط",
+
+	// Candlestick patterns
+	"pattern.Doji":                         "دوجي",
+	"pattern.Doji.meaning":                 "سعر الافتتاح والإغلاق متساويان تقريباً، فالمشترون والبائعون متوازنون. التفسير: تردد، وغالباً ما تحسم الشمعة التالية الاتجاه.",
+	"pattern.Dragonfly Doji":               "دوجي اليعسوب",
+	"pattern.Dragonfly Doji.meaning":       "دوجي بظل سفلي طويل، دفع البائعون السعر للأسفل لكن المشترين أعادوه إلى سعر الافتتاح. التفسير: رفض للأسعار الأدنى، وهو إيجابي بعد هبوط.",
+	"pattern.Gravestone Doji":              "دوجي شاهد القبر",
+	"pattern.Gravestone Doji.meaning":      "دوجي بظل علوي طويل، دفع المشترون السعر للأعلى لكن البائعين أعادوه إلى سعر الافتتاح. التفسير: رفض للأسعار الأعلى، وهو سلبي بعد ارتفاع.",
+	"pattern.Hammer":                       "المطرقة",
+	"pattern.Hammer.meaning":               "بعد هبوط تأتي شمعة بجسم صغير قرب القمة وظل سفلي لا يقل عن ضعف الجسم. التفسير: فقد البائعون السيطرة خلال الجلسة، وقد يكون قاعاً.",
+	"pattern.Hanging Man":                  "الرجل المشنوق",
+	"pattern.Hanging Man.meaning":          "بعد ارتفاع تأتي شمعة بجسم صغير قرب القمة وظل سفلي طويل. التفسير: ظهر بيع داخل الجلسة، وقد تكون قمة إذا أكدتها الشمعة التالية.",
+	"pattern.Inverted Hammer":              "المطرقة المقلوبة",
+	"pattern.Inverted Hammer.meaning":      "بعد هبوط تأتي شمعة بجسم صغير قرب القاع وظل علوي لا يقل عن ضعف الجسم. التفسير: اختبر المشترون أسعاراً أعلى، وقد يكون قاعاً إذا أكدته الشمعة التالية.",
+	"pattern.Shooting Star":                "الشهاب",
+	"pattern.Shooting Star.meaning":        "بعد ارتفاع تأتي شمعة بجسم صغير قرب القاع وظل علوي لا يقل عن ضعف الجسم. التفسير: رُفضت الأسعار الأعلى، وقد تكون قمة.",
+	"pattern.Bullish Marubozu":             "ماروبوزو صاعد",
+	"pattern.Bullish Marubozu.meaning":     "جسم صاعد طويل بلا ظلال تقريباً، سيطر المشترون على الجلسة كلها. التفسير: ضغط شراء قوي يستمر غالباً.",
+	"pattern.Bearish Marubozu":             "ماروبوزو هابط",
+	"pattern.Bearish Marubozu.meaning":     "جسم هابط طويل بلا ظلال تقريباً، سيطر البائعون على الجلسة كلها. التفسير: ضغط بيع قوي يستمر غالباً.",
+	"pattern.Bullish Engulfing":            "الابتلاع الصاعد",
+	"pattern.Bullish Engulfing.meaning":    "بعد هبوط يغطي جسم صاعد الجسم الهابط السابق بالكامل. التفسير: تغلب المشترون على البائعين، وهي إشارة انعكاس قوية.",
+	"pattern.Bearish Engulfing":            "الابتلاع الهابط",
+	"pattern.Bearish Engulfing.meaning":    "بعد ارتفاع يغطي جسم هابط الجسم الصاعد السابق بالكامل. التفسير: تغلب البائعون على المشترين، وهي إشارة انعكاس قوية.",
+	"pattern.Bullish Harami":               "هارامي صاعد",
+	"pattern.Bullish Harami.meaning":       "بعد هبوط يقع جسم صاعد صغير داخل الجسم الهابط الطويل السابق. التفسير: البيع يفقد زخمه، فانتظر التأكيد.",
+	"pattern.Bearish Harami":               "هارامي هابط",
+	"pattern.Bearish Harami.meaning":       "بعد ارتفاع يقع جسم هابط صغير داخل الجسم الصاعد الطويل السابق. التفسير: الشراء يفقد زخمه، فانتظر التأكيد.",
+	"pattern.Piercing Line":                "الخط الثاقب",
+	"pattern.Piercing Line.meaning":        "بعد شمعة هابطة طويلة يفتتح السعر أدنى لكنه يغلق فوق منتصفها. التفسير: دخل المشترون بقوة، وهي إشارة انعكاس صاعد.",
+	"pattern.Dark Cloud Cover":             "الغطاء السحابي الداكن",
+	"pattern.Dark Cloud Cover.meaning":     "بعد شمعة صاعدة طويلة يفتتح السعر أعلى لكنه يغلق تحت منتصفها. التفسير: دخل البائعون بقوة، وهي إشارة انعكاس هابط.",
+	"pattern.Morning Star":                 "نجمة الصباح",
+	"pattern.Morning Star.meaning":         "شمعة هابطة طويلة، ثم شمعة صغيرة تحت إغلاقها، ثم شمعة صاعدة تغلق فوق منتصف الأولى. التفسير: قاع من ثلاث شموع، ومن أوثق إشارات الانعكاس الصاعد.",
+	"pattern.Evening Star":                 "نجمة المساء",
+	"pattern.Evening Star.meaning":         "شمعة صاعدة طويلة، ثم شمعة صغيرة فوق إغلاقها، ثم شمعة هابطة تغلق تحت منتصف الأولى. التفسير: قمة من ثلاث شموع، ومن أوثق إشارات الانعكاس الهابط.",
+	"pattern.Three White Soldiers":         "الجنود البيض الثلاثة",
+	"pattern.Three White Soldiers.meaning": "ثلاث شموع صاعدة متتالية، تفتتح كل منها داخل جسم السابقة وتغلق قرب قمتها. التفسير: شراء ثابت، وهي إشارة صعود قوية.",
+	"pattern.Three Black Crows":            "الغربان السود الثلاثة",
+	"pattern.Three Black Crows.meaning":    "ثلاث شموع هابطة متتالية، تفتتح كل منها داخل جسم السابقة وتغلق قرب قاعها. التفسير: بيع ثابت، وهي إشارة هبوط قوية.",
+
+	// Strategy signals
+	"signal.Strong Buy":  "شراء قوي",
+	"signal.Buy":         "شراء",
+	"signal.Weak Buy":    "شراء ضعيف",
+	"signal.Hold":        "احتفاظ",
+	"signal.Weak Sell":   "بيع ضعيف",
+	"signal.Sell":        "بيع",
+	"signal.Strong Sell": "بيع قوي",
+
+	// Daily report page
+	"report.page_title":     "ISX Auto Scrapper – التقرير اليومي",
+	"report.subtitle":       "تقرير السوق اليومي",
+	"report.back":           "العودة إلى لوحة المعلومات ←",
+	"report.download":       "تنزيل Excel",
+	"report.title":          "التقرير اليومي – %s",
+	"report.top_volume":     "الأعلى 5 حجماً",
+	"report.top_value":      "الأعلى 5 قيمة",
+	"report.top_gain":       "الأكثر 5 ارتفاعاً",
+	"report.top_loss":       "الأكثر 5 انخفاضاً",
+	"report.traded":         "الشركات المتداولة",
+	"report.non_traded":     "الشركات غير المتداولة",
+	"report.divergences":    "تباعدات اليوم",
+	"report.no_divergences": "لم يتأكد أي تباعد في هذه الجلسة.",
+	"report.no_data":        "لا توجد بيانات.",
+	"report.volume_share":   "حصة الحجم",
+	"report.value_share":    "حصة القيمة",
+	"report.gain_pct":       "نسبة الارتفاع %",
+	"report.loss_pct":       "نسبة الانخفاض %",
+
+	// Daily report sheet names and column headings, keyed by their English text
+	"report.sheet.TopVolume":   "الأعلى حجماً",
+	"report.sheet.TopValue":    "الأعلى قيمة",
+	"report.sheet.TopGain":     "الأكثر ارتفاعاً",
+	"report.sheet.TopLoss":     "الأكثر انخفاضاً",
+	"report.sheet.Traded":      "المتداولة",
+	"report.sheet.NonTraded":   "غير المتداولة",
+	"report.sheet.Divergences": "التباعدات",
+
+	"report.column.Ticker":      "الرمز",
+	"report.column.Code":        "الرمز",
+	"report.column.Company":     "الشركة",
+	"report.column.Name":        "الشركة",
+	"report.column.Open":        "الافتتاح",
+	"report.column.High":        "الأعلى",
+	"report.column.Low":         "الأدنى",
+	"report.column.Close":       "الإغلاق",
+	"report.column.PrevClose":   "الإغلاق السابق",
+	"report.column.Prev Close":  "الإغلاق السابق",
+	"report.column.AvgPrice":    "متوسط السعر",
+	"report.column.Avg":         "المتوسط",
+	"report.column.PrevAvg":     "المتوسط السابق",
+	"report.column.Prev Avg":    "المتوسط السابق",
+	"report.column.Change%":     "التغير %",
+	"report.column.Trades":      "الصفقات",
+	"report.column.Volume":      "الحجم",
+	"report.column.Value":       "القيمة",
+	"report.column.Last Traded": "آخر تداول",
+	"report.column.Indicator":   "المؤشر",
+	"report.column.Type":        "النوع",
+	"report.column.Bias":        "الاتجاه",
+	"report.column.Start":       "البداية",
+	"report.column.From":        "من",
+	"report.column.End":         "النهاية",
+	"report.column.To":          "إلى",
+	"report.column.StartPrice":  "سعر البداية",
+	"report.column.EndPrice":    "سعر النهاية",
+	"report.column.Price":       "السعر",
+	"report.column.Strength":    "القوة",
+
+	"report.value.regular": "منتظم",
+	"report.value.hidden":  "مخفي",
+	"report.value.bullish": "صاعد",
+	"report.value.bearish": "هابط",
+}
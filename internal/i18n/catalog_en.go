@@ -0,0 +1,123 @@
+package i18n
+
+// english is the reference catalogue; other languages fall back to it key by key
+var english = map[string]string{
+	// Indicator descriptions (the *_Desc columns of indicators_<TICKER>.csv)
+	"desc.cross.golden":               "Buy - Golden Cross Detected: The shorter-term SMA50 has crossed above the longer-term SMA200; a bullish signal. Historically this pattern indicates the early stages of a prolonged bull market. Interpretation: The asset's price might rise; suggesting a favorable time to buy or add to your position.",
+	"desc.cross.death":                "Sell - Death Cross Detected: The shorter-term SMA50 has crossed below the longer-term SMA200; a bearish signal. This pattern often precedes a forthcoming bear market or a prolonged period of selling. Interpretation: Exercise caution; consider reducing exposure or hedge against losses.",
+	"desc.cross.none":                 "Neutral - No Significant Cross Detected: The market shows no clear bullish or bearish signals at the moment. This can indicate a period of consolidation or sideways movement. Interpretation: Monitor other indicators; stay updated with market news and maintain a diversified strategy.",
+	"desc.sma10.up":                   "Buy - Price Crossed Above SMA10: The asset's price has surged above its 10-period average. This upward crossover is historically a sign of short-term bullish momentum. Interpretation: It might be an opportunity to capitalize on the momentum; but also consider other indicators for confirmation.",
+	"desc.sma10.down":                 "Sell - Price Crossed Below SMA10: The asset's price is dipping below its recent 10-period average. This can hint at a short-term decline or a potential pullback. Interpretation: It might be wise to exercise caution; adjust strategies or set stop losses.",
+	"desc.sma10.none":                 "Neutral - Price Oscillating Around SMA10: The asset's price is weaving around its 10-period average; indicating market indecision. This pattern could be a sign of consolidation. Interpretation: Stay alert; monitor other indicators and be ready for a potential breakout.",
+	"desc.major_sma.up":               "Buy; Price Crossed Above Major SMA: The asset's price has crossed above a significant moving average - indicating strong bullish momentum. This suggests institutional interest and potential trend continuation. Interpretation: Consider entering long positions with proper risk management.",
+	"desc.major_sma.down":             "Sell; Price Crossed Below Major SMA: The asset's price has fallen below a key moving average - signaling potential weakness. This could indicate the start of a downtrend. Interpretation: Consider reducing positions or implementing protective stops.",
+	"desc.major_sma.none":             "Neutral; Price Respecting SMA Levels: The asset's price is trading in line with major moving averages - showing balanced market conditions. Interpretation: Wait for clearer directional signals before making significant position changes.",
+	"desc.rsi.overbought":             "Sell - RSI Overbought: RSI is above 70; indicating the asset may be overbought. This suggests selling pressure could emerge soon. Interpretation: Consider taking profits or reducing positions; but watch for trend continuation in strong markets.",
+	"desc.rsi.oversold":               "Buy - RSI Oversold: RSI is below 30; indicating the asset may be oversold. This suggests a potential bounce or reversal. Interpretation: Look for buying opportunities; but confirm with other indicators and price action.",
+	"desc.rsi.above_mid":              "Neutral-Bullish - RSI Above Midline: RSI is above 50; showing bullish momentum but not extreme. The trend appears healthy with room for further upside. Interpretation: Maintain bullish bias but monitor for overbought conditions.",
+	"desc.rsi.below_mid":              "Neutral-Bearish - RSI Below Midline: RSI is below 50; indicating bearish momentum but not extreme oversold. The trend shows weakness with potential for further decline. Interpretation: Exercise caution and look for confirmation before buying.",
+	"desc.stoch.overbought":           "Sell; Stochastic Overbought: Both %K and %D are above 80 - indicating overbought conditions. Momentum may be slowing. Interpretation: Consider profit-taking or tightening stops as a pullback may be imminent.",
+	"desc.stoch.oversold":             "Buy; Stochastic Oversold: Both %K and %D are below 20 - indicating oversold conditions. A bounce may be developing. Interpretation: Look for buying opportunities on confirmation of upward momentum.",
+	"desc.stoch.bullish_cross":        "Neutral-Bullish; Stochastic Bullish Crossover: %K is above %D - indicating bullish momentum. The trend appears to be strengthening. Interpretation: Monitor for continuation of upward movement.",
+	"desc.stoch.bearish_cross":        "Neutral-Bearish; Stochastic Bearish Crossover: %K is below %D - indicating bearish momentum. Weakness may be developing. Interpretation: Exercise caution and consider defensive positioning.",
+	"desc.cmf.strong":                 "Buy; Strong Money Flow: CMF is positive and strong - indicating accumulation by institutional investors. Money is flowing into the asset. Interpretation: This supports bullish price action and suggests buying interest.",
+	"desc.cmf.weak":                   "Sell; Weak Money Flow: CMF is negative and weak - indicating distribution by institutional investors. Money is flowing out of the asset. Interpretation: This supports bearish price action and suggests selling pressure.",
+	"desc.cmf.mild_accumulation":      "Neutral-Bullish, Mild Accumulation: CMF is slightly positive; showing mild buying interest. The trend is supported but not strongly. Interpretation: Cautiously bullish - but look for stronger confirmation.",
+	"desc.cmf.mild_distribution":      "Neutral-Bearish; Mild Distribution: CMF is slightly negative - showing mild selling pressure. The trend shows some weakness. Interpretation: Exercise caution and monitor for trend deterioration.",
+	"desc.macd.bullish":               "Buy; MACD Bullish: MACD line is above signal line with positive histogram - indicating strong bullish momentum. The trend is accelerating upward. Interpretation: Consider entering long positions or adding to existing bullish positions.",
+	"desc.macd.bearish":               "Sell; MACD Bearish: MACD line is below signal line with negative histogram - indicating strong bearish momentum. The trend is accelerating downward. Interpretation: Consider reducing positions or entering short positions.",
+	"desc.macd.above_signal":          "Neutral-Bullish - MACD Above Signal: MACD is above signal line but momentum is weakening. Bullish trend may be losing steam. Interpretation: Maintain bullish bias but watch for potential reversal signals.",
+	"desc.macd.below_signal":          "Neutral-Bearish - MACD Below Signal: MACD is below signal line but momentum is weakening. Bearish trend may be losing steam. Interpretation: Maintain bearish bias but watch for potential reversal signals.",
+	"desc.obv.accumulation":           "Buy; Strong Volume Accumulation: OBV is rising strongly - indicating heavy accumulation. Smart money is buying aggressively. Interpretation: This supports bullish price action and suggests strong institutional interest.",
+	"desc.obv.distribution":           "Sell; Strong Volume Distribution: OBV is falling strongly - indicating heavy distribution. Smart money is selling aggressively. Interpretation: This supports bearish price action and suggests institutional selling.",
+	"desc.obv.mild_accumulation":      "Neutral-Bullish; Mild Volume Accumulation: OBV is rising moderately - showing steady accumulation. Buying interest is present but not overwhelming. Interpretation: Cautiously bullish volume pattern.",
+	"desc.obv.mild_distribution":      "Neutral-Bearish; Mild Volume Distribution: OBV is declining moderately - showing steady distribution. Selling pressure is present but not overwhelming. Interpretation: Cautiously bearish volume pattern.",
+	"desc.psar.bullish":               "Buy; PSAR Bullish: Price is above PSAR - indicating an uptrend. The parabolic SAR suggests continued bullish momentum. Interpretation: Trend following systems suggest maintaining long positions with PSAR as trailing stop.",
+	"desc.psar.bearish":               "Sell; PSAR Bearish: Price is below PSAR - indicating a downtrend. The parabolic SAR suggests continued bearish momentum. Interpretation: Trend following systems suggest maintaining short positions or avoiding long positions.",
+	"desc.atr.high":                   "High Volatility Warning: ATR indicates high volatility (>5% of price). Market conditions are unstable with large price swings. Interpretation: Use wider stops; reduce position sizes - and expect increased risk.",
+	"desc.atr.moderate":               "Moderate Volatility: ATR shows moderate volatility (2-5% of price). Normal market conditions with reasonable price movement. Interpretation: Standard risk management applies - monitor for volatility changes.",
+	"desc.atr.low":                    "Low Volatility: ATR indicates low volatility (<2% of price). Market is relatively calm with small price movements. Interpretation: Consider tighter stops - but watch for potential volatility breakouts.",
+	"desc.bollinger.above":            "Sell; Above Upper Bollinger Band: The price closed above the upper band - the move is stretched far from its average. Interpretation: Expect a pullback toward the middle band unless a strong trend is starting.",
+	"desc.bollinger.below":            "Buy; Below Lower Bollinger Band: The price closed below the lower band - the move is stretched far below its average. Interpretation: A rebound toward the middle band is likely; confirm with momentum before buying.",
+	"desc.bollinger.upper_half":       "Neutral-Bullish; Upper Half of Bollinger Bands: The price is between the middle and upper band. Interpretation: Buyers are in control; the upper band is the next resistance.",
+	"desc.bollinger.lower_half":       "Neutral-Bearish; Lower Half of Bollinger Bands: The price is between the lower and middle band. Interpretation: Sellers are in control; the lower band is the next support.",
+	"desc.keltner.squeeze":            "Neutral; Volatility Squeeze: The Bollinger bands are inside the Keltner channel - volatility is unusually compressed. Interpretation: A sharp move often follows; wait for the squeeze to release and trade its direction.",
+	"desc.keltner.above":              "Buy; Above Keltner Channel: The price closed above the upper channel - a strong upward move beyond its normal range. Interpretation: Trend followers may join the move with a stop near the middle line.",
+	"desc.keltner.below":              "Sell; Below Keltner Channel: The price closed below the lower channel - a strong downward move beyond its normal range. Interpretation: Avoid new long positions until the price returns inside the channel.",
+	"desc.keltner.inside":             "Neutral; Inside Keltner Channel: The price is moving within its normal volatility range. Interpretation: No breakout - rely on other indicators for direction.",
+	"desc.donchian.breakout":          "Buy; Donchian Breakout: The price reached the top of its Donchian channel - a new high for the period. Interpretation: Breakouts to new highs often start trends; consider entries with a stop at the middle line.",
+	"desc.donchian.breakdown":         "Sell; Donchian Breakdown: The price reached the bottom of its Donchian channel - a new low for the period. Interpretation: Breakdowns to new lows often start downtrends; consider reducing positions.",
+	"desc.donchian.upper":             "Neutral-Bullish; Upper Donchian Range: The price is in the upper half of its recent range. Interpretation: The trend leans upward; watch for a break of the channel high.",
+	"desc.donchian.lower":             "Neutral-Bearish; Lower Donchian Range: The price is in the lower half of its recent range. Interpretation: The trend leans downward; watch for a break of the channel low.",
+	"desc.adx.uptrend":                "Buy; Strong Uptrend: ADX is above 25 with +DI above -DI - a strong trend with buyers in control. Interpretation: Trend following entries have the wind behind them; trail stops rather than taking early profits.",
+	"desc.adx.downtrend":              "Sell; Strong Downtrend: ADX is above 25 with -DI above +DI - a strong trend with sellers in control. Interpretation: Avoid catching the falling price; wait for ADX to turn down before buying.",
+	"desc.adx.no_trend":               "Neutral; No Clear Trend: ADX is below 20 - the market is ranging without direction. Interpretation: Trend signals are unreliable now; range trading or waiting works better.",
+	"desc.adx.developing_up":          "Neutral-Bullish; Developing Uptrend: ADX is between 20 and 25 with +DI above -DI - an upward trend may be forming. Interpretation: Watch for ADX to rise above 25 to confirm.",
+	"desc.adx.developing_down":        "Neutral-Bearish; Developing Downtrend: ADX is between 20 and 25 with -DI above +DI - a downward trend may be forming. Interpretation: Watch for ADX to rise above 25 to confirm.",
+	"desc.aroon.uptrend":              "Buy; Aroon Uptrend: Aroon Up is above 70 and Aroon Down below 30 - new highs are recent and new lows are old. Interpretation: A young or healthy uptrend; favor long positions.",
+	"desc.aroon.downtrend":            "Sell; Aroon Downtrend: Aroon Down is above 70 and Aroon Up below 30 - new lows are recent and new highs are old. Interpretation: A young or healthy downtrend; avoid long positions.",
+	"desc.aroon.positive":             "Neutral-Bullish; Aroon Oscillator Positive: Recent highs are newer than recent lows - upward pressure without a clear trend. Interpretation: Lean bullish but wait for Aroon Up above 70.",
+	"desc.aroon.not_positive":         "Neutral-Bearish; Aroon Oscillator Not Positive: Recent lows are as new or newer than recent highs - downward pressure without a clear trend. Interpretation: Lean cautious but wait for Aroon Down above 70.",
+	"desc.ichimoku.bullish":           "Buy; Above the Cloud: The price is above the Ichimoku cloud, Tenkan is above Kijun and the Chikou span is above the close it lags - trend, momentum and the lagging span agree upward. Interpretation: A bullish setup; the cloud top is the first support.",
+	"desc.ichimoku.bearish":           "Sell; Below the Cloud: The price is below the Ichimoku cloud, Tenkan is below Kijun and the Chikou span is below the close it lags - trend, momentum and the lagging span agree downward. Interpretation: A bearish setup; the cloud bottom is the first resistance.",
+	"desc.ichimoku.above":             "Neutral-Bullish; Above the Cloud: The price is above the cloud but Tenkan is not above Kijun or the Chikou span is not above the close it lags - the trend is up while momentum fades. Interpretation: Hold positions but wait for Tenkan and the Chikou span to confirm before adding.",
+	"desc.ichimoku.below":             "Neutral-Bearish; Below the Cloud: The price is below the cloud but Tenkan is not below Kijun or the Chikou span is not below the close it lags - the trend is down while selling slows. Interpretation: Stay cautious until the price regains the cloud.",
+	"desc.ichimoku.inside":            "Neutral; Inside the Cloud: The price is inside the Ichimoku cloud - the market is undecided. Interpretation: Wait for a close above or below the cloud before acting.",
+	"desc.mfi.overbought":             "Sell; MFI Overbought: Money flow index is above 80 - heavy buying volume has pushed the price up. Interpretation: Buying may be exhausted; consider taking profits.",
+	"desc.mfi.oversold":               "Buy; MFI Oversold: Money flow index is below 20 - heavy selling volume has pushed the price down. Interpretation: Selling may be exhausted; look for a rebound.",
+	"desc.mfi.above_mid":              "Neutral-Bullish; MFI Above Midline: More money flows in on up days than out on down days. Interpretation: Volume supports the price.",
+	"desc.mfi.below_mid":              "Neutral-Bearish; MFI Below Midline: More money flows out on down days than in on up days. Interpretation: Volume does not support the price.",
+	"desc.chaikin.positive":           "Neutral-Bullish; Chaikin Oscillator Positive: Accumulation is speeding up - closes near the highs on good volume. Interpretation: Buying pressure is building; a move across zero from below confirms it.",
+	"desc.chaikin.negative":           "Neutral-Bearish; Chaikin Oscillator Negative: Distribution is speeding up - closes near the lows on good volume. Interpretation: Selling pressure is building; a move across zero from above confirms it.",
+	"desc.vwap.above":                 "Neutral-Bullish; Above VWAP: The price is above the volume weighted average price - recent buyers are in profit. Interpretation: VWAP acts as support; dips toward it may be bought.",
+	"desc.vwap.below":                 "Neutral-Bearish; Below VWAP: The price is below the volume weighted average price - recent buyers are at a loss. Interpretation: VWAP acts as resistance; rallies toward it may be sold.",
+	"desc.vwap.at":                    "Neutral; At VWAP: The price equals the volume weighted average price. Interpretation: Fair value by volume - wait for a move away from it.",
+	"desc.candlestick":                "%s; %s (strength %g): %s",
+	"desc.levels.squeezed":            "Neutral; Between Close Levels: Support at %g and resistance at %g are both within 1%% - the price is squeezed between zones. Interpretation: Wait for a close beyond either zone.",
+	"desc.levels.near_support":        "Neutral-Bullish; Near Support: The price is %g%% above a support zone at %g where it turned before. Interpretation: Buyers have defended this zone; a bounce is likely unless it breaks.",
+	"desc.levels.near_resistance":     "Neutral-Bearish; Near Resistance: The price is %g%% below a resistance zone at %g where it turned before. Interpretation: Sellers have defended this zone; expect a pause unless it breaks.",
+	"desc.levels.below_all":           "Sell; Below All Support: No support zone is left below the price; the nearest resistance is %g. Interpretation: The price has broken down through known levels.",
+	"desc.levels.above_all":           "Buy; Above All Resistance: No resistance zone is left above the price; the nearest support is %g. Interpretation: The price is in open territory after breaking out.",
+	"desc.levels.between":             "Neutral; Between Zones: Support at %g (%g%% below) and resistance at %g (%g%% above). Interpretation: No level is close; follow the trend indicators.",
+	"desc.divergence":                 "%s; %s Divergence (strength %g): The %s",
+	"desc.divergence.regular_bullish": "price made a lower low while %[1]s made a higher low - selling pressure is fading. Interpretation: A reversal up is likely; look for a close above the last swing high to confirm.",
+	"desc.divergence.hidden_bullish":  "price made a higher low while %[1]s made a lower low - the uptrend absorbed a deeper pullback. Interpretation: The uptrend is likely to continue.",
+	"desc.divergence.regular_bearish": "price made a higher high while %[1]s made a lower high - buying pressure is fading. Interpretation: A reversal down is likely; look for a close below the last swing low to confirm.",
+	"desc.divergence.hidden_bearish":  "price made a lower high while %[1]s made a higher high - the downtrend absorbed a stronger rally. Interpretation: The downtrend is likely to continue.",
+	"desc.also":                       " Also: %s.",
+	"desc.list_separator":             " - ",
+
+	// Recommendation words that open the descriptions built from parts
+	"action.Buy":             "Buy",
+	"action.Sell":            "Sell",
+	"action.Neutral":         "Neutral",
+	"action.Neutral-Bullish": "Neutral-Bullish",
+	"action.Neutral-Bearish": "Neutral-Bearish",
+
+	// Divergence labels such as "Regular Bullish RSI"
+	"divergence.label":   "%s %s %s",
+	"divergence.Regular": "Regular",
+	"divergence.Hidden":  "Hidden",
+	"divergence.Bullish": "Bullish",
+	"divergence.Bearish": "Bearish",
+
+	// Daily report page
+	"report.page_title":     "ISX Auto Scrapper – Daily Report",
+	"report.subtitle":       "Daily Market Report",
+	"report.back":           "← Back to Dashboard",
+	"report.download":       "Download Excel",
+	"report.title":          "Daily Report – %s",
+	"report.top_volume":     "Top 5 by Volume",
+	"report.top_value":      "Top 5 by Value",
+	"report.top_gain":       "Top 5 Gainers",
+	"report.top_loss":       "Top 5 Losers",
+	"report.traded":         "Traded Companies",
+	"report.non_traded":     "Non-Traded Companies",
+	"report.divergences":    "Divergences Today",
+	"report.no_divergences": "No divergences confirmed in this session.",
+	"report.no_data":        "No data available.",
+	"report.volume_share":   "Volume Share",
+	"report.value_share":    "Value Share",
+	"report.gain_pct":       "Gain %",
+	"report.loss_pct":       "Loss %",
+}
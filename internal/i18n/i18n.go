@@ -0,0 +1,85 @@
+// Package i18n holds the message catalogues for the text the tool writes for people: indicator
+// descriptions, signal labels, and the daily report headings and sheet names.
+// Keys missing from a catalogue fall back to English, so a partial translation is still usable.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Supported languages
+const (
+	English = "en"
+	Arabic  = "ar"
+)
+
+var catalogs = map[string]map[string]string{
+	English: english,
+	Arabic:  arabic,
+}
+
+// Supported lists the language codes with a catalogue
+func Supported() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// Valid reports whether lang has a catalogue
+func Valid(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// RTL reports whether lang is written right to left
+func RTL(lang string) bool {
+	return lang == Arabic
+}
+
+// T returns the message key in lang, formatted with args when given.
+// A key missing from lang falls back to English, and one missing there too is returned as is.
+func T(lang, key string, args ...interface{}) string {
+	msg, ok := catalogs[lang][key]
+	if !ok {
+		msg, ok = english[key]
+	}
+	if !ok {
+		msg = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Or returns the message key in lang, or fallback when lang has no such message.
+// It suits text whose English form lives with the code, such as candlestick pattern names.
+func Or(lang, key, fallback string) string {
+	if msg, ok := catalogs[lang][key]; ok {
+		return msg
+	}
+	return fallback
+}
+
+// Signal translates a strategy signal such as "Strong Buy"; unknown signals are returned unchanged
+func Signal(lang, signal string) string {
+	return Or(lang, "signal."+signal, signal)
+}
+
+// Messages returns every message of lang whose key starts with prefix, English filling the gaps
+func Messages(lang, prefix string) map[string]string {
+	out := map[string]string{}
+	for _, catalog := range []map[string]string{english, catalogs[lang]} {
+		for key, msg := range catalog {
+			if strings.HasPrefix(key, prefix) {
+				out[key] = msg
+			}
+		}
+	}
+	return out
+}
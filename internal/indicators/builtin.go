@@ -0,0 +1,1243 @@
+package indicators
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"isx-auto-scrapper/internal/numeric"
+)
+
+// Built-in indicators. Rounding and warm-up rules follow the original fixed calculator,
+// so the default specs reproduce the long-standing values of indicators_<TICKER>.csv.
+func init() {
+	Register(&Definition{
+		Name:        "SMA",
+		Description: "Simple moving average of the close with slope, distance and price crossovers",
+		Params:      []Param{{Name: "period", Default: 20, Integer: true}},
+		Inputs:      []string{"close"},
+		Outputs: func(p Params) []string {
+			n := p.Format(0)
+			return []string{"SMA" + n, "SMA" + n + "_Up", "Price_Distance_SMA" + n, "Price_Cross_SMA" + n + "_Up", "Price_Cross_SMA" + n + "_Down"}
+		},
+		New: newSMA,
+	})
+	Register(&Definition{
+		Name:        "CROSS",
+		Description: "Golden and death crosses between a fast and a slow SMA",
+		Params:      []Param{{Name: "fast", Default: 50, Integer: true}, {Name: "slow", Default: 200, Integer: true}},
+		Inputs:      []string{"close"},
+		Outputs: func(p Params) []string {
+			return []string{"Golden_Cross", "Death_Cross", fmt.Sprintf("SMA%s_Above_SMA%s", p.Format(0), p.Format(1))}
+		},
+		New: newCross,
+	})
+	Register(&Definition{
+		Name:        "EMA",
+		Description: "Exponential moving average of the close, seeded with the first close",
+		Params:      []Param{{Name: "period", Default: 20, Integer: true}},
+		Inputs:      []string{"close"},
+		Outputs:     func(p Params) []string { return []string{"EMA" + p.Format(0)} },
+		MinBars:     func(p Params) int { return p.Int(0) },
+		New:         newEMA,
+	})
+	Register(&Definition{
+		Name:        "RSI",
+		Description: "Relative strength index using simple averages of gains and losses",
+		Params:      []Param{{Name: "period", Default: 14, Integer: true}},
+		Inputs:      []string{"close"},
+		Outputs:     func(p Params) []string { return []string{"RSI_" + p.Format(0)} },
+		MinBars:     func(p Params) int { return p.Int(0) + 1 },
+		New:         newRSI,
+	})
+	Register(&Definition{
+		Name:        "STOCH",
+		Description: "Stochastic oscillator: %K smoothed over smooth bars, %D averaged over d bars",
+		Params:      []Param{{Name: "k", Default: 14, Integer: true}, {Name: "d", Default: 3, Integer: true}, {Name: "smooth", Default: 3, Integer: true}},
+		Inputs:      []string{"high", "low", "close"},
+		Outputs: func(p Params) []string {
+			suffix := fmt.Sprintf("%s_%s_%s", p.Format(0), p.Format(1), p.Format(2))
+			return []string{"STOCHk_" + suffix, "STOCHd_" + suffix}
+		},
+		MinBars: func(p Params) int { return p.Int(0) },
+		New:     newStoch,
+	})
+	Register(&Definition{
+		Name:        "MACD",
+		Description: "MACD line, signal line and histogram",
+		Params:      []Param{{Name: "fast", Default: 12, Integer: true}, {Name: "slow", Default: 26, Integer: true}, {Name: "signal", Default: 9, Integer: true}},
+		Inputs:      []string{"close"},
+		Outputs: func(p Params) []string {
+			suffix := fmt.Sprintf("%s_%s_%s", p.Format(0), p.Format(1), p.Format(2))
+			return []string{"MACD_" + suffix, "MACDs_" + suffix, "MACDh_" + suffix}
+		},
+		MinBars: func(p Params) int { return p.Int(1) + p.Int(2) - 1 },
+		New:     newMACD,
+	})
+	Register(&Definition{
+		Name:        "CMF",
+		Description: "Chaikin money flow",
+		Params:      []Param{{Name: "period", Default: 20, Integer: true}},
+		Inputs:      []string{"high", "low", "close", "volume"},
+		Outputs:     func(p Params) []string { return []string{"CMF_" + p.Format(0)} },
+		MinBars:     func(p Params) int { return p.Int(0) },
+		New:         newCMF,
+	})
+	Register(&Definition{
+		Name:        "OBV",
+		Description: "On-balance volume and its rate of change in percent over period bars",
+		Params:      []Param{{Name: "period", Default: 10, Integer: true}},
+		Inputs:      []string{"close", "volume"},
+		Outputs:     func(p Params) []string { return []string{"OBV", "OBV_RoC"} },
+		New:         newOBV,
+	})
+	Register(&Definition{
+		Name:        "PSAR",
+		Description: "Parabolic SAR",
+		Params:      []Param{{Name: "step", Default: 0.02}, {Name: "max", Default: 0.2}},
+		Inputs:      []string{"high", "low"},
+		Outputs: func(p Params) []string {
+			return []string{fmt.Sprintf("PSARl_%s_%s", p.Format(0), p.Format(1))}
+		},
+		MinBars: func(p Params) int { return 2 },
+		New:     newPSAR,
+	})
+	Register(&Definition{
+		Name:        "ATR",
+		Description: "Average true range as a simple average",
+		Params:      []Param{{Name: "period", Default: 14, Integer: true}},
+		Inputs:      []string{"high", "low", "close"},
+		Outputs:     func(p Params) []string { return []string{"ATR_" + p.Format(0)} },
+		MinBars:     func(p Params) int { return p.Int(0) },
+		New:         newATR,
+	})
+	Register(&Definition{
+		Name:        "STD",
+		Description: "Rolling population standard deviation of the close",
+		Params:      []Param{{Name: "period", Default: 20, Integer: true}},
+		Inputs:      []string{"close"},
+		Outputs:     func(p Params) []string { return []string{"Rolling_Std_" + p.Format(0)} },
+		MinBars:     func(p Params) int { return p.Int(0) },
+		New:         newStd,
+	})
+	Register(&Definition{
+		Name:        "BBANDS",
+		Description: "Bollinger bands: SMA of the close plus and minus mult population deviations, with bandwidth and %B",
+		Params:      []Param{{Name: "period", Default: 20, Integer: true}, {Name: "mult", Default: 2}},
+		Inputs:      []string{"close"},
+		Outputs: func(p Params) []string {
+			suffix := fmt.Sprintf("%s_%s", p.Format(0), p.Format(1))
+			return []string{"BBL_" + suffix, "BBM_" + suffix, "BBU_" + suffix, "BBB_" + suffix, "BBP_" + suffix}
+		},
+		MinBars: func(p Params) int { return p.Int(0) },
+		New:     newBBands,
+	})
+	Register(&Definition{
+		Name:        "KC",
+		Description: "Keltner channels: EMA of the close plus and minus mult times the ATR",
+		Params:      []Param{{Name: "period", Default: 20, Integer: true}, {Name: "mult", Default: 1.5}, {Name: "atr", Default: 20, Integer: true}},
+		Inputs:      []string{"high", "low", "close"},
+		Outputs: func(p Params) []string {
+			suffix := fmt.Sprintf("%s_%s_%s", p.Format(0), p.Format(1), p.Format(2))
+			return []string{"KCL_" + suffix, "KCB_" + suffix, "KCU_" + suffix}
+		},
+		MinBars: func(p Params) int { return max(p.Int(0), p.Int(2)+1) },
+		New:     newKC,
+	})
+	Register(&Definition{
+		Name:        "DONCHIAN",
+		Description: "Donchian channels: highest high and lowest low of the last period bars and their midpoint",
+		Params:      []Param{{Name: "period", Default: 20, Integer: true}},
+		Inputs:      []string{"high", "low"},
+		Outputs: func(p Params) []string {
+			n := p.Format(0)
+			return []string{"DCL_" + n, "DCM_" + n, "DCU_" + n}
+		},
+		MinBars: func(p Params) int { return p.Int(0) },
+		New:     newDonchian,
+	})
+	Register(&Definition{
+		Name:        "ADX",
+		Description: "Average directional index with the +DI and -DI lines, Wilder smoothed",
+		Params:      []Param{{Name: "period", Default: 14, Integer: true}},
+		Inputs:      []string{"high", "low", "close"},
+		Outputs: func(p Params) []string {
+			n := p.Format(0)
+			return []string{"ADX_" + n, "DMP_" + n, "DMN_" + n}
+		},
+		MinBars: func(p Params) int { return 2 * p.Int(0) },
+		New:     newADX,
+	})
+	Register(&Definition{
+		Name:        "AROON",
+		Description: "Aroon down, up and oscillator from the bars since the lowest low and highest high",
+		Params:      []Param{{Name: "period", Default: 25, Integer: true}},
+		Inputs:      []string{"high", "low"},
+		Outputs: func(p Params) []string {
+			n := p.Format(0)
+			return []string{"AROOND_" + n, "AROONU_" + n, "AROONOSC_" + n}
+		},
+		MinBars: func(p Params) int { return p.Int(0) + 1 },
+		New:     newAroon,
+	})
+	Register(&Definition{
+		Name:        "ICHIMOKU",
+		Description: "Ichimoku cloud: Tenkan, Kijun, the Senkou spans in effect on each bar and the Chikou span against the close kijun bars back",
+		Params:      []Param{{Name: "tenkan", Default: 9, Integer: true}, {Name: "kijun", Default: 26, Integer: true}, {Name: "senkou", Default: 52, Integer: true}},
+		Inputs:      []string{"high", "low", "close"},
+		Outputs: func(p Params) []string {
+			return []string{"ITS_" + p.Format(0), "IKS_" + p.Format(1), "ISA_" + p.Format(0), "ISB_" + p.Format(1), "ICS_" + p.Format(1)}
+		},
+		MinBars: func(p Params) int { return max(p.Int(1), p.Int(2)) + p.Int(1) },
+		New:     newIchimoku,
+	})
+	Register(&Definition{
+		Name:        "MFI",
+		Description: "Money flow index: RSI of the typical price weighted by volume",
+		Params:      []Param{{Name: "period", Default: 14, Integer: true}},
+		Inputs:      []string{"high", "low", "close", "volume"},
+		Outputs:     func(p Params) []string { return []string{"MFI_" + p.Format(0)} },
+		MinBars:     func(p Params) int { return p.Int(0) + 1 },
+		New:         newMFI,
+	})
+	Register(&Definition{
+		Name:        "AD",
+		Description: "Accumulation/distribution line: running sum of volume weighted by the close location in the bar",
+		Inputs:      []string{"high", "low", "close", "volume"},
+		Outputs:     func(p Params) []string { return []string{"AD"} },
+		New:         newAD,
+	})
+	Register(&Definition{
+		Name:        "ADOSC",
+		Description: "Chaikin oscillator: fast EMA minus slow EMA of the accumulation/distribution line",
+		Params:      []Param{{Name: "fast", Default: 3, Integer: true}, {Name: "slow", Default: 10, Integer: true}},
+		Inputs:      []string{"high", "low", "close", "volume"},
+		Outputs: func(p Params) []string {
+			return []string{fmt.Sprintf("ADOSC_%s_%s", p.Format(0), p.Format(1))}
+		},
+		MinBars: func(p Params) int { return p.Int(1) },
+		New:     newADOSC,
+	})
+	Register(&Definition{
+		Name:        "VWAP",
+		Description: "Rolling volume weighted average of the typical price over period daily bars",
+		Params:      []Param{{Name: "period", Default: 20, Integer: true}},
+		Inputs:      []string{"high", "low", "close", "volume"},
+		Outputs:     func(p Params) []string { return []string{"VWAP_" + p.Format(0)} },
+		MinBars:     func(p Params) int { return p.Int(0) },
+		New:         newVWAP,
+	})
+	Register(&Definition{
+		Name:        "AVWAP",
+		Description: "Volume weighted average of the typical price since an anchor date given as YYYYMMDD",
+		Params:      []Param{{Name: "anchor", Default: 20240101, Integer: true}},
+		Inputs:      []string{"high", "low", "close", "volume"},
+		Outputs:     func(p Params) []string { return []string{"AVWAP_" + p.Format(0)} },
+		Validate: func(p Params) error {
+			if _, err := anchorDate(p.Int(0)); err != nil {
+				return fmt.Errorf("anchor %s is not a YYYYMMDD date", p.Format(0))
+			}
+			return nil
+		},
+		New: newAVWAP,
+	})
+	Register(&Definition{
+		Name:        "CDL",
+		Description: "Candlestick patterns; trend bars set the prior trend, average body and volume each pattern is judged against",
+		Params:      []Param{{Name: "trend", Default: DefaultPatternTrend, Integer: true}},
+		Inputs:      []string{"open", "high", "low", "close", "volume"},
+		Outputs:     func(p Params) []string { return []string{"CDL_Pattern", "CDL_Bias", "CDL_Strength"} },
+		Validate: func(p Params) error {
+			if p.Int(0) < 2 {
+				return fmt.Errorf("trend must be at least 2 bars")
+			}
+			return nil
+		},
+		New: newCDL,
+	})
+	Register(&Definition{
+		Name:        "SR",
+		Description: "Nearest support and resistance zones: swings beating lookback bars on each side, clustered within tolerance percent over the last bars bars",
+		Params: []Param{{Name: "lookback", Default: 5, Integer: true}, {Name: "tolerance", Default: 1.5},
+			{Name: "bars", Default: 250, Integer: true}},
+		Inputs: []string{"high", "low", "close"},
+		Outputs: func(p Params) []string {
+			suffix := fmt.Sprintf("%s_%s_%s", p.Format(0), p.Format(1), p.Format(2))
+			return []string{"SRS_" + suffix, "SRR_" + suffix, "SRSD_" + suffix, "SRRD_" + suffix}
+		},
+		New: newSR,
+	})
+	Register(&Definition{
+		Name:        "DIV",
+		Description: "Regular and hidden divergences between price and RSI(14), MACD(12,26,9) histogram, OBV and CMF(20) at swings beating lookback bars on each side, at most window bars apart",
+		Params: []Param{{Name: "lookback", Default: DefaultDivergenceLookback, Integer: true},
+			{Name: "window", Default: DefaultDivergenceWindow, Integer: true}},
+		Inputs:  []string{"high", "low", "close", "volume"},
+		Outputs: func(p Params) []string { return []string{"DIV_Type", "DIV_Bias", "DIV_Strength"} },
+		Validate: func(p Params) error {
+			if p.Int(1) <= 2*p.Int(0) {
+				return fmt.Errorf("window must be longer than twice the lookback")
+			}
+			return nil
+		},
+		New: newDIV,
+	})
+}
+
+// smaStepper feeds SMA(n)
+type smaStepper struct {
+	Seen      int            `json:"seen"`
+	Closes    numeric.Window `json:"closes"`
+	PrevSMA   float64        `json:"prev_sma"`
+	PrevClose float64        `json:"prev_close"`
+
+	period                 int
+	sma, distance          []float64
+	up, crossUp, crossDown []bool
+}
+
+func newSMA(f *Frame, p Params, total int) Stepper {
+	cols := registry["SMA"].Outputs(p)
+	return &smaStepper{
+		period:    p.Int(0),
+		sma:       f.AddNumber(cols[0]),
+		up:        f.AddFlag(cols[1]),
+		distance:  f.AddNumber(cols[2]),
+		crossUp:   f.AddFlag(cols[3]),
+		crossDown: f.AddFlag(cols[4]),
+	}
+}
+
+func (s *smaStepper) Step(f *Frame, i int) {
+	price := f.Close[i]
+	sma := 0.0
+	if s.Closes.Push(price, s.period) {
+		sma = numeric.Round(s.Closes.Mean(), 2)
+	}
+	s.sma[i] = sma
+
+	// Slopes, distances and crossovers start on the second bar
+	if s.Seen > 0 && sma != 0 {
+		s.distance[i] = numeric.Round(price-sma, 2)
+		if s.PrevSMA != 0 {
+			s.up[i] = sma > s.PrevSMA
+			s.crossUp[i] = price > sma && s.PrevClose <= s.PrevSMA
+			s.crossDown[i] = price < sma && s.PrevClose >= s.PrevSMA
+		}
+	}
+
+	s.Seen++
+	s.PrevSMA = sma
+	s.PrevClose = price
+}
+
+// crossStepper feeds CROSS(fast,slow)
+type crossStepper struct {
+	Seen     int            `json:"seen"`
+	Fast     numeric.Window `json:"fast"`
+	Slow     numeric.Window `json:"slow"`
+	PrevFast float64        `json:"prev_fast"`
+	PrevSlow float64        `json:"prev_slow"`
+
+	fastPeriod, slowPeriod int
+	golden, death, above   []bool
+}
+
+func newCross(f *Frame, p Params, total int) Stepper {
+	cols := registry["CROSS"].Outputs(p)
+	return &crossStepper{
+		fastPeriod: p.Int(0),
+		slowPeriod: p.Int(1),
+		golden:     f.AddFlag(cols[0]),
+		death:      f.AddFlag(cols[1]),
+		above:      f.AddFlag(cols[2]),
+	}
+}
+
+func (s *crossStepper) Step(f *Frame, i int) {
+	fast, slow := 0.0, 0.0
+	if s.Fast.Push(f.Close[i], s.fastPeriod) {
+		fast = numeric.Round(s.Fast.Mean(), 2)
+	}
+	if s.Slow.Push(f.Close[i], s.slowPeriod) {
+		slow = numeric.Round(s.Slow.Mean(), 2)
+	}
+
+	if s.Seen > 0 && fast != 0 && slow != 0 {
+		s.above[i] = fast > slow
+		if s.PrevFast != 0 && s.PrevSlow != 0 {
+			s.golden[i] = fast > slow && s.PrevFast <= s.PrevSlow
+			s.death[i] = fast < slow && s.PrevFast >= s.PrevSlow
+		}
+	}
+
+	s.Seen++
+	s.PrevFast = fast
+	s.PrevSlow = slow
+}
+
+// emaStepper feeds EMA(n); the column stays empty for histories shorter than n
+type emaStepper struct {
+	Active bool    `json:"active"`
+	Seen   int     `json:"seen"`
+	EMA    float64 `json:"ema"` // Unrounded
+
+	multiplier float64
+	out        []float64
+}
+
+func newEMA(f *Frame, p Params, total int) Stepper {
+	return &emaStepper{
+		Active:     total >= p.Int(0),
+		multiplier: numeric.EMAMultiplier(p.Int(0)),
+		out:        f.AddNumber(registry["EMA"].Outputs(p)[0]),
+	}
+}
+
+func (s *emaStepper) Step(f *Frame, i int) {
+	if !s.Active {
+		return
+	}
+	if s.Seen == 0 {
+		s.EMA = f.Close[i]
+	} else {
+		s.EMA = f.Close[i]*s.multiplier + s.EMA*(1-s.multiplier)
+		s.out[i] = numeric.Round(s.EMA, 2)
+	}
+	s.Seen++
+}
+
+// rsiStepper feeds RSI(n)
+type rsiStepper struct {
+	Active    bool           `json:"active"`
+	Seen      int            `json:"seen"`
+	PrevClose float64        `json:"prev_close"`
+	Gains     numeric.Window `json:"gains"`
+	Losses    numeric.Window `json:"losses"`
+
+	period int
+	out    []float64
+}
+
+func newRSI(f *Frame, p Params, total int) Stepper {
+	return &rsiStepper{
+		Active: total >= p.Int(0)+1,
+		period: p.Int(0),
+		out:    f.AddNumber(registry["RSI"].Outputs(p)[0]),
+	}
+}
+
+func (s *rsiStepper) Step(f *Frame, i int) {
+	if !s.Active {
+		return
+	}
+	price := f.Close[i]
+	if s.Seen > 0 {
+		change := price - s.PrevClose
+		s.Gains.Push(math.Max(change, 0), s.period)
+		if s.Losses.Push(math.Max(-change, 0), s.period) {
+			avgGain := s.Gains.Mean()
+			avgLoss := s.Losses.Mean()
+			if avgLoss > 0 {
+				s.out[i] = numeric.Round(100-100/(1+avgGain/avgLoss), 2)
+			}
+		}
+	}
+	s.Seen++
+	s.PrevClose = price
+}
+
+// stochStepper feeds STOCH(k,d,smooth)
+type stochStepper struct {
+	Active bool               `json:"active"`
+	Highs  numeric.RollingMax `json:"highs"`
+	Lows   numeric.RollingMin `json:"lows"`
+	FastK  numeric.Window     `json:"fast_k"`
+	SlowK  numeric.Window     `json:"slow_k"`
+
+	period, dPeriod, smooth int
+	outK, outD              []float64
+}
+
+func newStoch(f *Frame, p Params, total int) Stepper {
+	cols := registry["STOCH"].Outputs(p)
+	return &stochStepper{
+		Active:  total >= p.Int(0),
+		period:  p.Int(0),
+		dPeriod: p.Int(1),
+		smooth:  p.Int(2),
+		outK:    f.AddNumber(cols[0]),
+		outD:    f.AddNumber(cols[1]),
+	}
+}
+
+func (s *stochStepper) Step(f *Frame, i int) {
+	if !s.Active {
+		return
+	}
+	highest := s.Highs.Push(f.High[i], s.period)
+	lowest := s.Lows.Push(f.Low[i], s.period)
+	if s.Highs.Seen < s.period {
+		return
+	}
+
+	// Raw %K over the lookback window; a flat window reads as 0
+	fastK := 0.0
+	if highest != lowest {
+		fastK = (f.Close[i] - lowest) / (highest - lowest) * 100
+	}
+
+	if !s.FastK.Push(fastK, s.smooth) {
+		return
+	}
+	slowK := s.FastK.Mean()
+	s.outK[i] = numeric.Round(slowK, 2)
+	if s.SlowK.Push(slowK, s.dPeriod) {
+		s.outD[i] = numeric.Round(s.SlowK.Mean(), 2)
+	}
+}
+
+// macdStepper feeds MACD(fast,slow,signal)
+type macdStepper struct {
+	Active   bool    `json:"active"`
+	SignalOn bool    `json:"signal_on"` // History long enough for the signal line
+	Seen     int     `json:"seen"`
+	Fast     float64 `json:"fast"`   // Unrounded fast EMA
+	Slow     float64 `json:"slow"`   // Unrounded slow EMA
+	Signal   float64 `json:"signal"` // Unrounded signal of the previous bar
+
+	slowPeriod                  int
+	fastMult, slowMult, sigMult float64
+	macd, signal, hist          []float64
+}
+
+func newMACD(f *Frame, p Params, total int) Stepper {
+	cols := registry["MACD"].Outputs(p)
+	return &macdStepper{
+		Active:     total >= p.Int(1),
+		SignalOn:   total >= p.Int(1)+p.Int(2)-1,
+		slowPeriod: p.Int(1),
+		fastMult:   numeric.EMAMultiplier(p.Int(0)),
+		slowMult:   numeric.EMAMultiplier(p.Int(1)),
+		sigMult:    numeric.EMAMultiplier(p.Int(2)),
+		macd:       f.AddNumber(cols[0]),
+		signal:     f.AddNumber(cols[1]),
+		hist:       f.AddNumber(cols[2]),
+	}
+}
+
+func (s *macdStepper) Step(f *Frame, i int) {
+	if !s.Active {
+		return
+	}
+	if s.Seen == 0 {
+		s.Fast = f.Close[i]
+		s.Slow = f.Close[i]
+	} else {
+		s.Fast = f.Close[i]*s.fastMult + s.Fast*(1-s.fastMult)
+		s.Slow = f.Close[i]*s.slowMult + s.Slow*(1-s.slowMult)
+		if s.Seen >= s.slowPeriod-1 {
+			s.macd[i] = numeric.Round(s.Fast-s.Slow, 4)
+		}
+	}
+
+	// The signal line is seeded with the first MACD value and restarts from zero after a zero MACD
+	first := s.slowPeriod - 1
+	switch {
+	case !s.SignalOn || s.Seen < first:
+	case s.Seen == first:
+		s.Signal = s.macd[i]
+	case s.macd[i] == 0:
+		s.Signal = 0
+	default:
+		s.Signal = s.macd[i]*s.sigMult + s.Signal*(1-s.sigMult)
+		s.signal[i] = numeric.Round(s.Signal, 4)
+		s.hist[i] = numeric.Round(s.macd[i]-s.signal[i], 4)
+	}
+	s.Seen++
+}
+
+// cmfStepper feeds CMF(n)
+type cmfStepper struct {
+	Active bool           `json:"active"`
+	MFV    numeric.Window `json:"mfv"`
+	Volume numeric.Window `json:"volume"`
+
+	period int
+	out    []float64
+}
+
+func newCMF(f *Frame, p Params, total int) Stepper {
+	return &cmfStepper{
+		Active: total >= p.Int(0),
+		period: p.Int(0),
+		out:    f.AddNumber(registry["CMF"].Outputs(p)[0]),
+	}
+}
+
+func (s *cmfStepper) Step(f *Frame, i int) {
+	if !s.Active {
+		return
+	}
+
+	s.MFV.Push(moneyFlowVolume(f, i), s.period)
+	if s.Volume.Push(float64(f.Volume[i]), s.period) && s.Volume.Sum != 0 {
+		s.out[i] = numeric.Round(s.MFV.Sum/s.Volume.Sum, 4)
+	}
+}
+
+// obvStepper feeds OBV(n)
+type obvStepper struct {
+	Seen      int       `json:"seen"`
+	OBV       float64   `json:"obv"`
+	PrevClose float64   `json:"prev_close"`
+	Past      []float64 `json:"past"` // OBV of the previous n bars
+
+	period   int
+	obv, roc []float64
+}
+
+func newOBV(f *Frame, p Params, total int) Stepper {
+	cols := registry["OBV"].Outputs(p)
+	return &obvStepper{
+		period: p.Int(0),
+		obv:    f.AddNumber(cols[0]),
+		roc:    f.AddNumber(cols[1]),
+	}
+}
+
+func (s *obvStepper) Step(f *Frame, i int) {
+	volume := float64(f.Volume[i])
+	switch {
+	case s.Seen == 0:
+		s.OBV = volume
+	case f.Close[i] > s.PrevClose:
+		s.OBV += volume
+	case f.Close[i] < s.PrevClose:
+		s.OBV -= volume
+	}
+	s.obv[i] = s.OBV
+
+	if len(s.Past) == s.period && s.Past[0] != 0 {
+		base := s.Past[0]
+		s.roc[i] = numeric.Round((s.OBV-base)/math.Abs(base)*100, 2)
+	}
+	s.Past = append(s.Past, s.OBV)
+	if len(s.Past) > s.period {
+		s.Past = s.Past[1:]
+	}
+
+	s.Seen++
+	s.PrevClose = f.Close[i]
+}
+
+// psarStepper feeds PSAR(step,max)
+type psarStepper struct {
+	Active  bool    `json:"active"`
+	Seen    int     `json:"seen"`
+	PSAR    float64 `json:"psar"` // Rounded value of the previous bar
+	Uptrend bool    `json:"uptrend"`
+	AF      float64 `json:"af"`
+	EP      float64 `json:"ep"` // Extreme point
+
+	step, maxStep float64
+	out           []float64
+}
+
+func newPSAR(f *Frame, p Params, total int) Stepper {
+	return &psarStepper{
+		Active:  total >= 2,
+		step:    p.Float(0),
+		maxStep: p.Float(1),
+		out:     f.AddNumber(registry["PSAR"].Outputs(p)[0]),
+	}
+}
+
+func (s *psarStepper) Step(f *Frame, i int) {
+	if !s.Active {
+		return
+	}
+	if s.Seen == 0 {
+		s.PSAR = f.Low[i]
+		s.Uptrend = true
+		s.AF = s.step
+		s.EP = f.High[i]
+		s.out[i] = s.PSAR
+		s.Seen++
+		return
+	}
+
+	// Snap to the decimal grid so prices equal to the SAR compare as equal
+	psar := numeric.Round(s.PSAR+s.AF*(s.EP-s.PSAR), 8)
+	if s.Uptrend {
+		if f.Low[i] < psar {
+			s.Uptrend = false
+			psar = s.EP
+			s.EP = f.Low[i]
+			s.AF = s.step
+		} else if f.High[i] > s.EP {
+			s.EP = f.High[i]
+			s.AF = math.Min(numeric.Round(s.AF+s.step, 8), s.maxStep)
+		}
+	} else {
+		if f.High[i] > psar {
+			s.Uptrend = true
+			psar = s.EP
+			s.EP = f.High[i]
+			s.AF = s.step
+		} else if f.Low[i] < s.EP {
+			s.EP = f.Low[i]
+			s.AF = math.Min(numeric.Round(s.AF+s.step, 8), s.maxStep)
+		}
+	}
+
+	s.PSAR = numeric.Round(psar, 4)
+	s.out[i] = s.PSAR
+	s.Seen++
+}
+
+// atrStepper feeds ATR(n)
+type atrStepper struct {
+	Active    bool           `json:"active"`
+	Seen      int            `json:"seen"`
+	PrevClose float64        `json:"prev_close"`
+	Ranges    numeric.Window `json:"ranges"`
+
+	period int
+	out    []float64
+}
+
+func newATR(f *Frame, p Params, total int) Stepper {
+	return &atrStepper{
+		Active: total >= p.Int(0),
+		period: p.Int(0),
+		out:    f.AddNumber(registry["ATR"].Outputs(p)[0]),
+	}
+}
+
+func (s *atrStepper) Step(f *Frame, i int) {
+	if !s.Active {
+		return
+	}
+
+	// TR = max(High - Low, |High - PrevClose|, |Low - PrevClose|); the first bar has none
+	if s.Seen > 0 {
+		hl := f.High[i] - f.Low[i]
+		hpc := math.Abs(f.High[i] - s.PrevClose)
+		lpc := math.Abs(f.Low[i] - s.PrevClose)
+		if s.Ranges.Push(max(hl, hpc, lpc), s.period) {
+			s.out[i] = numeric.Round(s.Ranges.Mean(), 4)
+		}
+	}
+	s.Seen++
+	s.PrevClose = f.Close[i]
+}
+
+// stdStepper feeds STD(n)
+type stdStepper struct {
+	Active bool           `json:"active"`
+	Closes numeric.Window `json:"closes"`
+
+	period int
+	out    []float64
+}
+
+func newStd(f *Frame, p Params, total int) Stepper {
+	return &stdStepper{
+		Active: total >= p.Int(0),
+		period: p.Int(0),
+		out:    f.AddNumber(registry["STD"].Outputs(p)[0]),
+	}
+}
+
+func (s *stdStepper) Step(f *Frame, i int) {
+	if !s.Active || !s.Closes.Push(f.Close[i], s.period) {
+		return
+	}
+	s.out[i] = numeric.Round(s.Closes.Std(), 4)
+}
+
+// bbandsStepper feeds BBANDS(n,mult)
+type bbandsStepper struct {
+	Active bool           `json:"active"`
+	Closes numeric.Window `json:"closes"`
+
+	period                                   int
+	mult                                     float64
+	lower, middle, upper, bandwidth, percent []float64
+}
+
+func newBBands(f *Frame, p Params, total int) Stepper {
+	cols := registry["BBANDS"].Outputs(p)
+	return &bbandsStepper{
+		Active:    total >= p.Int(0),
+		period:    p.Int(0),
+		mult:      p.Float(1),
+		lower:     f.AddNumber(cols[0]),
+		middle:    f.AddNumber(cols[1]),
+		upper:     f.AddNumber(cols[2]),
+		bandwidth: f.AddNumber(cols[3]),
+		percent:   f.AddNumber(cols[4]),
+	}
+}
+
+func (s *bbandsStepper) Step(f *Frame, i int) {
+	if !s.Active || !s.Closes.Push(f.Close[i], s.period) {
+		return
+	}
+	middle := s.Closes.Mean()
+	width := s.mult * s.Closes.Std()
+	lower, upper := middle-width, middle+width
+	s.lower[i] = numeric.Round(lower, 4)
+	s.middle[i] = numeric.Round(middle, 4)
+	s.upper[i] = numeric.Round(upper, 4)
+
+	// Bandwidth is the band spread in percent of the middle; %B is 0 on the lower band and 1 on the upper
+	if middle != 0 {
+		s.bandwidth[i] = numeric.Round((upper-lower)/middle*100, 4)
+	}
+	if upper != lower {
+		s.percent[i] = numeric.Round((f.Close[i]-lower)/(upper-lower), 4)
+	}
+}
+
+// kcStepper feeds KC(n,mult,atr) from the same EMA and ATR as the EMA and ATR columns
+type kcStepper struct {
+	Active    bool           `json:"active"`
+	Seen      int            `json:"seen"`
+	EMA       float64        `json:"ema"` // Unrounded
+	PrevClose float64        `json:"prev_close"`
+	Ranges    numeric.Window `json:"ranges"`
+
+	period, atrPeriod   int
+	multiplier, mult    float64
+	lower, basis, upper []float64
+}
+
+func newKC(f *Frame, p Params, total int) Stepper {
+	cols := registry["KC"].Outputs(p)
+	return &kcStepper{
+		Active:     total >= max(p.Int(0), p.Int(2)+1),
+		period:     p.Int(0),
+		atrPeriod:  p.Int(2),
+		multiplier: numeric.EMAMultiplier(p.Int(0)),
+		mult:       p.Float(1),
+		lower:      f.AddNumber(cols[0]),
+		basis:      f.AddNumber(cols[1]),
+		upper:      f.AddNumber(cols[2]),
+	}
+}
+
+func (s *kcStepper) Step(f *Frame, i int) {
+	if !s.Active {
+		return
+	}
+	price := f.Close[i]
+	full := false
+	if s.Seen == 0 {
+		s.EMA = price
+	} else {
+		s.EMA = price*s.multiplier + s.EMA*(1-s.multiplier)
+		hl := f.High[i] - f.Low[i]
+		hpc := math.Abs(f.High[i] - s.PrevClose)
+		lpc := math.Abs(f.Low[i] - s.PrevClose)
+		full = s.Ranges.Push(max(hl, hpc, lpc), s.atrPeriod)
+	}
+
+	// Bands start once the EMA covers period bars and the ATR window is full
+	if full && s.Seen >= s.period-1 {
+		basis := numeric.Round(s.EMA, 2)
+		width := s.mult * numeric.Round(s.Ranges.Mean(), 4)
+		s.lower[i] = numeric.Round(basis-width, 4)
+		s.basis[i] = basis
+		s.upper[i] = numeric.Round(basis+width, 4)
+	}
+	s.Seen++
+	s.PrevClose = price
+}
+
+// donchianStepper feeds DONCHIAN(n); the channel includes the current bar
+type donchianStepper struct {
+	Active bool               `json:"active"`
+	Highs  numeric.RollingMax `json:"highs"`
+	Lows   numeric.RollingMin `json:"lows"`
+
+	period               int
+	lower, middle, upper []float64
+}
+
+func newDonchian(f *Frame, p Params, total int) Stepper {
+	cols := registry["DONCHIAN"].Outputs(p)
+	return &donchianStepper{
+		Active: total >= p.Int(0),
+		period: p.Int(0),
+		lower:  f.AddNumber(cols[0]),
+		middle: f.AddNumber(cols[1]),
+		upper:  f.AddNumber(cols[2]),
+	}
+}
+
+func (s *donchianStepper) Step(f *Frame, i int) {
+	if !s.Active {
+		return
+	}
+	highest := s.Highs.Push(f.High[i], s.period)
+	lowest := s.Lows.Push(f.Low[i], s.period)
+	if s.Highs.Seen < s.period {
+		return
+	}
+	s.lower[i] = lowest
+	s.middle[i] = numeric.Round((highest+lowest)/2, 4)
+	s.upper[i] = highest
+}
+
+// adxStepper feeds ADX(n)
+type adxStepper struct {
+	Seen      int     `json:"seen"`
+	PrevHigh  float64 `json:"prev_high"`
+	PrevLow   float64 `json:"prev_low"`
+	PrevClose float64 `json:"prev_close"`
+	TR        float64 `json:"tr"`       // Wilder smoothed true range
+	PlusDM    float64 `json:"plus_dm"`  // Wilder smoothed +DM
+	MinusDM   float64 `json:"minus_dm"` // Wilder smoothed -DM
+	DXSum     float64 `json:"dx_sum"`   // Sum of the first n DX values
+	ADX       float64 `json:"adx"`      // Unrounded
+
+	period               int
+	adx, plusDI, minusDI []float64
+}
+
+func newADX(f *Frame, p Params, total int) Stepper {
+	cols := registry["ADX"].Outputs(p)
+	return &adxStepper{
+		period:  p.Int(0),
+		adx:     f.AddNumber(cols[0]),
+		plusDI:  f.AddNumber(cols[1]),
+		minusDI: f.AddNumber(cols[2]),
+	}
+}
+
+func (s *adxStepper) Step(f *Frame, i int) {
+	high, low := f.High[i], f.Low[i]
+	if s.Seen > 0 {
+		// +DM counts only when the move up beats the move down, and the other way round
+		up, down := high-s.PrevHigh, s.PrevLow-low
+		plusDM, minusDM := 0.0, 0.0
+		if up > down && up > 0 {
+			plusDM = up
+		}
+		if down > up && down > 0 {
+			minusDM = down
+		}
+		tr := max(high-low, math.Abs(high-s.PrevClose), math.Abs(low-s.PrevClose))
+
+		// The first n bars are summed, later ones smoothed as sum - sum/n + value
+		n := float64(s.period)
+		if s.Seen <= s.period {
+			s.TR += tr
+			s.PlusDM += plusDM
+			s.MinusDM += minusDM
+		} else {
+			s.TR = s.TR - s.TR/n + tr
+			s.PlusDM = s.PlusDM - s.PlusDM/n + plusDM
+			s.MinusDM = s.MinusDM - s.MinusDM/n + minusDM
+		}
+
+		if s.Seen >= s.period {
+			plusDI, minusDI, dx := 0.0, 0.0, 0.0
+			if s.TR != 0 {
+				plusDI = 100 * s.PlusDM / s.TR
+				minusDI = 100 * s.MinusDM / s.TR
+			}
+			if plusDI+minusDI != 0 {
+				dx = 100 * math.Abs(plusDI-minusDI) / (plusDI + minusDI)
+			}
+			s.plusDI[i] = numeric.Round(plusDI, 2)
+			s.minusDI[i] = numeric.Round(minusDI, 2)
+
+			// ADX starts as the average of the first n DX values
+			switch k := s.Seen - s.period + 1; {
+			case k < s.period:
+				s.DXSum += dx
+			case k == s.period:
+				s.ADX = (s.DXSum + dx) / n
+				s.adx[i] = numeric.Round(s.ADX, 2)
+			default:
+				s.ADX = (s.ADX*(n-1) + dx) / n
+				s.adx[i] = numeric.Round(s.ADX, 2)
+			}
+		}
+	}
+
+	s.Seen++
+	s.PrevHigh, s.PrevLow, s.PrevClose = high, low, f.Close[i]
+}
+
+// aroonStepper feeds AROON(n) over the last n+1 bars; ties count from the most recent extreme
+type aroonStepper struct {
+	Highs numeric.RollingMax `json:"highs"`
+	Lows  numeric.RollingMin `json:"lows"`
+
+	period        int
+	down, up, osc []float64
+}
+
+func newAroon(f *Frame, p Params, total int) Stepper {
+	cols := registry["AROON"].Outputs(p)
+	return &aroonStepper{
+		period: p.Int(0),
+		down:   f.AddNumber(cols[0]),
+		up:     f.AddNumber(cols[1]),
+		osc:    f.AddNumber(cols[2]),
+	}
+}
+
+func (s *aroonStepper) Step(f *Frame, i int) {
+	s.Highs.Push(f.High[i], s.period+1)
+	s.Lows.Push(f.Low[i], s.period+1)
+	if s.Highs.Seen <= s.period {
+		return
+	}
+
+	n := float64(s.period)
+	sinceHigh := float64(s.Highs.Seen - 1 - s.Highs.Bars[0])
+	sinceLow := float64(s.Lows.Seen - 1 - s.Lows.Bars[0])
+	up := numeric.Round((n-sinceHigh)/n*100, 2)
+	down := numeric.Round((n-sinceLow)/n*100, 2)
+	s.up[i] = up
+	s.down[i] = down
+	s.osc[i] = numeric.Round(up-down, 2)
+}
+
+// ichimokuStepper feeds ICHIMOKU(tenkan,kijun,senkou).
+// The Senkou spans are computed kijun bars ahead of where they apply, so each bar shows the cloud
+// projected onto it. The Chikou span plots the close kijun bars back; the column holds its distance
+// from the close it is plotted against, the close minus the close kijun bars earlier, known on the bar.
+type ichimokuStepper struct {
+	TenkanHighs numeric.RollingMax `json:"tenkan_highs"`
+	TenkanLows  numeric.RollingMin `json:"tenkan_lows"`
+	KijunHighs  numeric.RollingMax `json:"kijun_highs"`
+	KijunLows   numeric.RollingMin `json:"kijun_lows"`
+	SenkouHighs numeric.RollingMax `json:"senkou_highs"`
+	SenkouLows  numeric.RollingMin `json:"senkou_lows"`
+	SpanA       []float64          `json:"span_a"` // Projected spans of the previous kijun bars, 0 before warm-up
+	SpanB       []float64          `json:"span_b"`
+	Closes      []float64          `json:"closes"` // Closes of the previous kijun bars for the Chikou span
+
+	tenkanPeriod, kijunPeriod, senkouPeriod int
+	tenkan, kijun, spanA, spanB, chikou     []float64
+}
+
+func newIchimoku(f *Frame, p Params, total int) Stepper {
+	cols := registry["ICHIMOKU"].Outputs(p)
+	return &ichimokuStepper{
+		tenkanPeriod: p.Int(0),
+		kijunPeriod:  p.Int(1),
+		senkouPeriod: p.Int(2),
+		tenkan:       f.AddNumber(cols[0]),
+		kijun:        f.AddNumber(cols[1]),
+		spanA:        f.AddNumber(cols[2]),
+		spanB:        f.AddNumber(cols[3]),
+		chikou:       f.AddNumber(cols[4]),
+	}
+}
+
+func (s *ichimokuStepper) Step(f *Frame, i int) {
+	// midpoint returns the middle of the highest high and lowest low once the window is full
+	midpoint := func(highs *numeric.RollingMax, lows *numeric.RollingMin, size int) float64 {
+		highest := highs.Push(f.High[i], size)
+		lowest := lows.Push(f.Low[i], size)
+		if highs.Seen < size {
+			return 0
+		}
+		return (highest + lowest) / 2
+	}
+	tenkan := midpoint(&s.TenkanHighs, &s.TenkanLows, s.tenkanPeriod)
+	kijun := midpoint(&s.KijunHighs, &s.KijunLows, s.kijunPeriod)
+	senkouB := midpoint(&s.SenkouHighs, &s.SenkouLows, s.senkouPeriod)
+	senkouA := 0.0
+	if tenkan != 0 && kijun != 0 {
+		senkouA = (tenkan + kijun) / 2
+	}
+
+	s.tenkan[i] = numeric.Round(tenkan, 4)
+	s.kijun[i] = numeric.Round(kijun, 4)
+	if len(s.Closes) == s.kijunPeriod {
+		s.chikou[i] = numeric.Round(f.Close[i]-s.Closes[0], 4)
+		s.Closes = s.Closes[1:]
+	}
+	s.Closes = append(s.Closes, f.Close[i])
+
+	// The spans computed kijun bars ago apply to this bar
+	s.SpanA = append(s.SpanA, senkouA)
+	s.SpanB = append(s.SpanB, senkouB)
+	if len(s.SpanA) > s.kijunPeriod {
+		s.spanA[i] = numeric.Round(s.SpanA[0], 4)
+		s.spanB[i] = numeric.Round(s.SpanB[0], 4)
+		s.SpanA = s.SpanA[1:]
+		s.SpanB = s.SpanB[1:]
+	}
+}
+
+// typicalPrice returns (high + low + close) / 3 of bar i
+func typicalPrice(f *Frame, i int) float64 {
+	return (f.High[i] + f.Low[i] + f.Close[i]) / 3
+}
+
+// moneyFlowVolume returns the volume of bar i weighted by where the close sits in its range, from -1 to 1:
+// Money Flow Multiplier = ((Close - Low) - (High - Close)) / (High - Low)
+func moneyFlowVolume(f *Frame, i int) float64 {
+	high, low, price := f.High[i], f.Low[i], f.Close[i]
+	if high == low {
+		return 0
+	}
+	return ((price - low) - (high - price)) / (high - low) * float64(f.Volume[i])
+}
+
+// mfiStepper feeds MFI(n)
+type mfiStepper struct {
+	Seen     int            `json:"seen"`
+	PrevTP   float64        `json:"prev_tp"`
+	Positive numeric.Window `json:"positive"`
+	Negative numeric.Window `json:"negative"`
+
+	period int
+	out    []float64
+}
+
+func newMFI(f *Frame, p Params, total int) Stepper {
+	return &mfiStepper{
+		period: p.Int(0),
+		out:    f.AddNumber(registry["MFI"].Outputs(p)[0]),
+	}
+}
+
+func (s *mfiStepper) Step(f *Frame, i int) {
+	tp := typicalPrice(f, i)
+	if s.Seen > 0 {
+		// Money flow counts as positive when the typical price rose and negative when it fell
+		flow := tp * float64(f.Volume[i])
+		positive, negative := 0.0, 0.0
+		if tp > s.PrevTP {
+			positive = flow
+		} else if tp < s.PrevTP {
+			negative = flow
+		}
+		s.Positive.Push(positive, s.period)
+		if s.Negative.Push(negative, s.period) && s.Positive.Sum+s.Negative.Sum > 0 {
+			s.out[i] = numeric.Round(100*s.Positive.Sum/(s.Positive.Sum+s.Negative.Sum), 2)
+		}
+	}
+	s.Seen++
+	s.PrevTP = tp
+}
+
+// adStepper feeds AD
+type adStepper struct {
+	AD float64 `json:"ad"`
+
+	out []float64
+}
+
+func newAD(f *Frame, p Params, total int) Stepper {
+	return &adStepper{out: f.AddNumber("AD")}
+}
+
+func (s *adStepper) Step(f *Frame, i int) {
+	s.AD += moneyFlowVolume(f, i)
+	s.out[i] = numeric.Round(s.AD, 2)
+}
+
+// adoscStepper feeds ADOSC(fast,slow); both EMAs are seeded with the first A/D value
+type adoscStepper struct {
+	Seen int     `json:"seen"`
+	AD   float64 `json:"ad"`
+	Fast float64 `json:"fast"` // Unrounded fast EMA
+	Slow float64 `json:"slow"` // Unrounded slow EMA
+
+	slowPeriod         int
+	fastMult, slowMult float64
+	out                []float64
+}
+
+func newADOSC(f *Frame, p Params, total int) Stepper {
+	return &adoscStepper{
+		slowPeriod: p.Int(1),
+		fastMult:   numeric.EMAMultiplier(p.Int(0)),
+		slowMult:   numeric.EMAMultiplier(p.Int(1)),
+		out:        f.AddNumber(registry["ADOSC"].Outputs(p)[0]),
+	}
+}
+
+func (s *adoscStepper) Step(f *Frame, i int) {
+	s.AD += moneyFlowVolume(f, i)
+	if s.Seen == 0 {
+		s.Fast, s.Slow = s.AD, s.AD
+	} else {
+		s.Fast = s.AD*s.fastMult + s.Fast*(1-s.fastMult)
+		s.Slow = s.AD*s.slowMult + s.Slow*(1-s.slowMult)
+	}
+	if s.Seen >= s.slowPeriod-1 {
+		s.out[i] = numeric.Round(s.Fast-s.Slow, 2)
+	}
+	s.Seen++
+}
+
+// vwapStepper feeds VWAP(n); each daily bar trades at its typical price
+type vwapStepper struct {
+	Value  numeric.Window `json:"value"`
+	Volume numeric.Window `json:"volume"`
+
+	period int
+	out    []float64
+}
+
+func newVWAP(f *Frame, p Params, total int) Stepper {
+	return &vwapStepper{
+		period: p.Int(0),
+		out:    f.AddNumber(registry["VWAP"].Outputs(p)[0]),
+	}
+}
+
+func (s *vwapStepper) Step(f *Frame, i int) {
+	volume := float64(f.Volume[i])
+	s.Value.Push(typicalPrice(f, i)*volume, s.period)
+	if s.Volume.Push(volume, s.period) && s.Volume.Sum > 0 {
+		s.out[i] = numeric.Round(s.Value.Sum/s.Volume.Sum, 4)
+	}
+}
+
+// anchorDate parses a YYYYMMDD anchor
+func anchorDate(anchor int) (time.Time, error) {
+	return time.Parse("20060102", strconv.Itoa(anchor))
+}
+
+// avwapStepper feeds AVWAP(anchor); bars before the anchor stay empty
+type avwapStepper struct {
+	Value  float64 `json:"value"`
+	Volume float64 `json:"volume"`
+
+	anchor time.Time
+	out    []float64
+}
+
+func newAVWAP(f *Frame, p Params, total int) Stepper {
+	anchor, _ := anchorDate(p.Int(0)) // Checked by Validate
+	return &avwapStepper{
+		anchor: anchor,
+		out:    f.AddNumber(registry["AVWAP"].Outputs(p)[0]),
+	}
+}
+
+func (s *avwapStepper) Step(f *Frame, i int) {
+	if f.Dates[i].Before(s.anchor) {
+		return
+	}
+	volume := float64(f.Volume[i])
+	s.Value += typicalPrice(f, i) * volume
+	s.Volume += volume
+	if s.Volume > 0 {
+		s.out[i] = numeric.Round(s.Value/s.Volume, 4)
+	}
+}
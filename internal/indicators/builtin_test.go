@@ -0,0 +1,85 @@
+package indicators
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// testFrame builds a frame of daily bars around closes, each bar spanning 1 above and below its close
+func testFrame(closes []float64) *Frame {
+	f := NewFrame(len(closes))
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, c := range closes {
+		f.Dates = append(f.Dates, start.AddDate(0, 0, i))
+		f.Open = append(f.Open, c)
+		f.High = append(f.High, c+1)
+		f.Low = append(f.Low, c-1)
+		f.Close = append(f.Close, c)
+		f.Change = append(f.Change, 0)
+		f.ChangePercent = append(f.ChangePercent, 0)
+		f.Volume = append(f.Volume, 1000)
+		f.Trades = append(f.Trades, 10)
+	}
+	return f
+}
+
+func mustSpecs(t *testing.T, texts ...string) []Spec {
+	t.Helper()
+	specs, err := ParseSpecs(texts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return specs
+}
+
+func TestIchimokuChikouSpan(t *testing.T) {
+	closes := []float64{10, 11, 13, 12, 15, 14, 18, 17, 16, 20}
+	f := testFrame(closes)
+	if _, err := ApplySpecs(f, mustSpecs(t, "ICHIMOKU(2,3,4)")); err != nil {
+		t.Fatal(err)
+	}
+
+	chikou := f.Number("ICS_3")
+	for i, c := range closes {
+		want := 0.0
+		if i >= 3 {
+			want = c - closes[i-3]
+		}
+		if chikou[i] != want {
+			t.Errorf("ICS_3[%d] = %v, want close %v minus close %d bars back = %v", i, chikou[i], c, 3, want)
+		}
+	}
+}
+
+func TestIchimokuChikouSpanExtends(t *testing.T) {
+	closes := []float64{10, 11, 13, 12, 15, 14, 18, 17, 16, 20}
+	specs := mustSpecs(t, "ICHIMOKU(2,3,4)")
+
+	full := testFrame(closes)
+	if _, err := ApplySpecs(full, specs); err != nil {
+		t.Fatal(err)
+	}
+
+	// Continue from the state saved after the first five bars, as calc does for appended sessions
+	head := testFrame(closes[:5])
+	steppers, err := ApplySpecs(head, specs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, err := json.Marshal(steppers[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	tail := testFrame(closes[5:])
+	if _, err := ExtendSpecs(tail, specs, map[string]json.RawMessage{specs[0].String(): state}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := full.Number("ICS_3")[5:]
+	for i, got := range tail.Number("ICS_3") {
+		if got != want[i] {
+			t.Errorf("extended ICS_3[%d] = %v, want %v", i+5, got, want[i])
+		}
+	}
+}
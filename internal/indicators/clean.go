@@ -0,0 +1,204 @@
+package indicators
+
+import (
+	"os"
+	"strings"
+
+	"github.com/gocarina/gocsv"
+
+	"isx-auto-scrapper/internal/common"
+	"isx-auto-scrapper/internal/numeric"
+)
+
+// Bar cleaning policies for raw rows with a missing (zero) price
+const (
+	CleanSubstitute  = "substitute" // Fill missing prices from the bar's own prices: close from open, or the high-low average
+	CleanForwardFill = "ffill"      // Fill missing prices with the previous close
+	CleanDrop        = "drop"       // Leave the bar out
+	CleanMark        = "mark"       // Keep the bar as a non-trading day: flat at the previous close without volume
+	CleanNone        = "none"       // Keep the raw values
+)
+
+// BarAdjustment is one row of adjustments_<TICKER>.csv: a raw bar with a missing price and what
+// cleaning made of it. The cleaned prices are 0 for a dropped bar.
+type BarAdjustment struct {
+	Date     string  `csv:"Date" json:"date"`
+	Action   string  `csv:"Action" json:"action"`   // Policy applied; substitute and ffill fall back when they lack prices
+	Missing  string  `csv:"Missing" json:"missing"` // Raw prices that were zero, e.g. "Close" or "Open High"
+	RawOpen  float64 `csv:"Raw_Open" json:"raw_open"`
+	RawHigh  float64 `csv:"Raw_High" json:"raw_high"`
+	RawLow   float64 `csv:"Raw_Low" json:"raw_low"`
+	RawClose float64 `csv:"Raw_Close" json:"raw_close"`
+	Open     float64 `csv:"Open" json:"open"`
+	High     float64 `csv:"High" json:"high"`
+	Low      float64 `csv:"Low" json:"low"`
+	Close    float64 `csv:"Close" json:"close"`
+}
+
+// AdjustmentsFile returns the bar cleaning report of ticker, e.g. adjustments_BBOB.csv
+func AdjustmentsFile(ticker string) string {
+	return "adjustments_" + ticker + ".csv"
+}
+
+// LoadRawFrame reads raw_<TICKER>.csv into a frame cleaned with the configured indicators.bar_cleaning
+// policy, so every reader of the raw files sees the same bars
+func LoadRawFrame(filePath string) (*Frame, error) {
+	f, err := readRawFrame(filePath)
+	if err != nil {
+		return nil, err
+	}
+	f, _ = CleanBars(f, common.AppConfig.Indicators.BarCleaning)
+	return f, nil
+}
+
+// CleanBars applies policy to the bars with a zero open, high, low or close and returns the cleaned
+// frame with one adjustment per such bar. Bars that cannot be filled, such as the first bar under
+// ffill or mark, are dropped. Adjusted bars get their change recomputed from the previous close.
+func CleanBars(f *Frame, policy string) (*Frame, []*BarAdjustment) {
+	if policy == CleanNone {
+		return f, nil
+	}
+	clean := true
+	for i := 0; i < f.Len() && clean; i++ {
+		clean = f.Open[i] > 0 && f.High[i] > 0 && f.Low[i] > 0 && f.Close[i] > 0
+	}
+	if clean {
+		return f, nil
+	}
+
+	out := NewFrame(f.Len())
+	var adjustments []*BarAdjustment
+	prevClose := 0.0
+	for i := 0; i < f.Len(); i++ {
+		o, h, l, c := f.Open[i], f.High[i], f.Low[i], f.Close[i]
+		volume, trades := f.Volume[i], f.Trades[i]
+		missing := missingPrices(o, h, l, c)
+		if missing == "" {
+			out.appendBar(f, i, o, h, l, c, f.Change[i], f.ChangePercent[i], volume, trades)
+			prevClose = c
+			continue
+		}
+
+		adj := &BarAdjustment{Date: f.Dates[i].Format("2006-01-02"), Missing: missing, RawOpen: o, RawHigh: h, RawLow: l, RawClose: c}
+		adjustments = append(adjustments, adj)
+
+		// Substitution needs a price on the bar, and the previous close is needed otherwise
+		action := policy
+		if action == CleanSubstitute && o <= 0 && h <= 0 && l <= 0 && c <= 0 {
+			action = CleanForwardFill
+		}
+		if action != CleanSubstitute && action != CleanDrop && prevClose == 0 {
+			action = CleanDrop
+		}
+		adj.Action = action
+
+		switch action {
+		case CleanDrop:
+			continue
+		case CleanMark:
+			o, h, l, c = prevClose, prevClose, prevClose, prevClose
+			volume, trades = 0, 0
+		case CleanForwardFill:
+			if c <= 0 {
+				c = prevClose
+			}
+			if o <= 0 {
+				o = prevClose
+			}
+		case CleanSubstitute:
+			if c <= 0 {
+				c = o
+			}
+			if c <= 0 {
+				c = averagePositive(h, l)
+			}
+			if o <= 0 {
+				o = c
+			}
+		}
+		if h <= 0 {
+			h = max(o, c)
+		}
+		if l <= 0 {
+			l = min(o, c)
+		}
+		h, l = max(h, o, c), min(l, o, c)
+
+		change, changePercent := 0.0, 0.0
+		if prevClose > 0 {
+			change = numeric.Round(c-prevClose, 4)
+			changePercent = numeric.Round((c/prevClose-1)*100, 2)
+		}
+		out.appendBar(f, i, o, h, l, c, change, changePercent, volume, trades)
+		adj.Open, adj.High, adj.Low, adj.Close = o, h, l, c
+		prevClose = c
+	}
+	return out, adjustments
+}
+
+// appendBar adds bar i of src with the given prices, change and activity
+func (f *Frame) appendBar(src *Frame, i int, o, h, l, c, change, changePercent float64, volume, trades int64) {
+	f.Dates = append(f.Dates, src.Dates[i])
+	f.Open = append(f.Open, o)
+	f.High = append(f.High, h)
+	f.Low = append(f.Low, l)
+	f.Close = append(f.Close, c)
+	f.Change = append(f.Change, change)
+	f.ChangePercent = append(f.ChangePercent, changePercent)
+	f.Volume = append(f.Volume, volume)
+	f.Trades = append(f.Trades, trades)
+}
+
+// missingPrices names the zero prices of a bar, or returns "" when all are set
+func missingPrices(o, h, l, c float64) string {
+	var names []string
+	for _, p := range []struct {
+		name  string
+		value float64
+	}{{"Open", o}, {"High", h}, {"Low", l}, {"Close", c}} {
+		if p.value <= 0 {
+			names = append(names, p.name)
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+// averagePositive returns the mean of the positive values, or 0 when there are none
+func averagePositive(values ...float64) float64 {
+	sum, n := 0.0, 0
+	for _, v := range values {
+		if v > 0 {
+			sum += v
+			n++
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n)
+}
+
+// SaveAdjustments writes the bar cleaning report of one ticker to filePath
+func SaveAdjustments(adjustments []*BarAdjustment, filePath string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return gocsv.MarshalFile(&adjustments, file)
+}
+
+// LoadAdjustments reads an adjustments_<TICKER>.csv file
+func LoadAdjustments(filePath string) ([]*BarAdjustment, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var adjustments []*BarAdjustment
+	if err := gocsv.UnmarshalFile(file, &adjustments); err != nil {
+		return nil, err
+	}
+	return adjustments, nil
+}
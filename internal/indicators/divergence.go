@@ -0,0 +1,275 @@
+package indicators
+
+import (
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gocarina/gocsv"
+
+	"isx-auto-scrapper/internal/numeric"
+)
+
+// Default swing lookback and the most bars between the two swings of a divergence
+const (
+	DefaultDivergenceLookback = 5
+	DefaultDivergenceWindow   = 60
+)
+
+// oscillators compared against price; warmup is the first bar with a settled value
+var oscillators = []struct {
+	Name   string
+	warmup int
+}{
+	{"RSI", 14},  // RSI(14)
+	{"MACD", 34}, // MACD(12,26,9) histogram
+	{"OBV", 1},
+	{"CMF", 19}, // CMF(20)
+}
+
+// DivergenceEvent is a divergence between price and one oscillator across two swings
+type DivergenceEvent struct {
+	Indicator  string  `csv:"Indicator" json:"indicator"` // RSI, MACD (histogram), OBV or CMF
+	Type       string  `csv:"Type" json:"type"`           // regular or hidden
+	Bias       string  `csv:"Bias" json:"bias"`           // bullish (swing lows) or bearish (swing highs)
+	Start      string  `csv:"Start" json:"start"`         // Date of the earlier swing
+	End        string  `csv:"End" json:"end"`             // Date of the later swing
+	Detected   string  `csv:"Detected" json:"detected"`   // Date the later swing was confirmed
+	StartPrice float64 `csv:"Start_Price" json:"start_price"`
+	EndPrice   float64 `csv:"End_Price" json:"end_price"`
+	StartValue float64 `csv:"Start_Value" json:"start_value"`
+	EndValue   float64 `csv:"End_Value" json:"end_value"`
+	Strength   float64 `csv:"Strength" json:"strength"`
+}
+
+// Label returns the event as written in the DIV_Type column, e.g. "Regular Bullish RSI"
+func (e DivergenceEvent) Label() string {
+	return strings.ToUpper(e.Type[:1]) + e.Type[1:] + " " + strings.ToUpper(e.Bias[:1]) + e.Bias[1:] + " " + e.Indicator
+}
+
+// divergenceDetector finds divergences one bar at a time. It computes its own RSI(14), MACD(12,26,9),
+// OBV and CMF(20) and compares them at consecutive swing lows (bullish) and swing highs (bearish)
+// no more than window bars apart. Its exported fields are saved with the DIV indicator state.
+type divergenceDetector struct {
+	Swings  swingTracker `json:"swings"`
+	Checked int          `json:"checked"` // Swings up to this bar were compared
+	RSI     rsiStepper   `json:"rsi"`
+	MACD    macdStepper  `json:"macd"`
+	OBV     obvStepper   `json:"obv"`
+	CMF     cmfStepper   `json:"cmf"`
+	Values  [][]float64  `json:"values"` // Oscillator values of the last window bars, one slice per oscillator
+	Dates   []time.Time  `json:"dates"`  // Dates of the same bars
+
+	window int
+	osc    [][]float64 // Oscillator outputs for the frame being stepped
+}
+
+func newDivergenceDetector(lookback, window, bars int) divergenceDetector {
+	osc := make([][]float64, 5)
+	for k := range osc {
+		osc[k] = make([]float64, bars)
+	}
+	return divergenceDetector{
+		Swings:  swingTracker{lookback: lookback, keep: window},
+		Checked: -1,
+		RSI:     rsiStepper{Active: true, period: 14, out: osc[0]},
+		MACD: macdStepper{Active: true, SignalOn: true, slowPeriod: 26,
+			fastMult: numeric.EMAMultiplier(12), slowMult: numeric.EMAMultiplier(26), sigMult: numeric.EMAMultiplier(9),
+			macd: make([]float64, bars), signal: make([]float64, bars), hist: osc[1]},
+		OBV:    obvStepper{period: 1, obv: osc[2], roc: osc[4]},
+		CMF:    cmfStepper{Active: true, period: 20, out: osc[3]},
+		Values: make([][]float64, len(oscillators)),
+		window: window,
+		osc:    osc,
+	}
+}
+
+// step adds bar i and returns the divergences confirmed on it, strongest first
+func (d *divergenceDetector) step(f *Frame, i int) []DivergenceEvent {
+	d.RSI.Step(f, i)
+	d.MACD.Step(f, i)
+	d.OBV.Step(f, i)
+	d.CMF.Step(f, i)
+
+	for k := range oscillators {
+		d.Values[k] = append(d.Values[k], d.osc[k][i])
+		if len(d.Values[k]) > d.window {
+			d.Values[k] = d.Values[k][1:]
+		}
+	}
+	d.Dates = append(d.Dates, f.Dates[i])
+	if len(d.Dates) > d.window {
+		d.Dates = d.Dates[1:]
+	}
+	d.Swings.step(f.High[i], f.Low[i])
+
+	var events []DivergenceEvent
+	for n, s := range d.Swings.Swings {
+		if s.Bar <= d.Checked {
+			continue
+		}
+		// The previous swing of the same kind, if still inside the window
+		for p := n - 1; p >= 0; p-- {
+			if prev := d.Swings.Swings[p]; prev.High == s.High {
+				events = append(events, d.compare(prev, s, f.Dates[i])...)
+				break
+			}
+		}
+	}
+	if n := len(d.Swings.Swings); n > 0 {
+		d.Checked = max(d.Checked, d.Swings.Swings[n-1].Bar)
+	}
+	sort.SliceStable(events, func(a, b int) bool { return events[a].Strength > events[b].Strength })
+	return events
+}
+
+// compare returns the divergences between the swings prev and cur, one per oscillator at most
+func (d *divergenceDetector) compare(prev, cur swingPoint, detected time.Time) []DivergenceEvent {
+	first := d.Swings.Bars - len(d.Dates) // Bar of the oldest held value
+	a, b := prev.Bar-first, cur.Bar-first
+	if a < 0 {
+		return nil
+	}
+
+	bias := "bullish"
+	if cur.High {
+		bias = "bearish"
+	}
+	var events []DivergenceEvent
+	for k, osc := range oscillators {
+		values := d.Values[k]
+		v1, v2 := values[a], values[b]
+		if !d.settled(k, prev.Bar, v1) || !d.settled(k, cur.Bar, v2) || v1 == v2 || prev.Price == cur.Price {
+			continue
+		}
+
+		// Lows: a lower price low with a higher oscillator low is regular, the reverse hidden; highs mirror it
+		priceUp, oscUp := cur.Price > prev.Price, v2 > v1
+		if priceUp == oscUp {
+			continue
+		}
+		kind := "regular"
+		if priceUp != cur.High {
+			kind = "hidden"
+		}
+
+		low, high := math.Inf(1), math.Inf(-1)
+		for j, v := range values[a : b+1] {
+			if d.settled(k, first+a+j, v) {
+				low, high = math.Min(low, v), math.Max(high, v)
+			}
+		}
+		events = append(events, DivergenceEvent{
+			Indicator:  osc.Name,
+			Type:       kind,
+			Bias:       bias,
+			Start:      d.Dates[a].Format("2006-01-02"),
+			End:        d.Dates[b].Format("2006-01-02"),
+			Detected:   detected.Format("2006-01-02"),
+			StartPrice: prev.Price,
+			EndPrice:   cur.Price,
+			StartValue: v1,
+			EndValue:   v2,
+			Strength:   divergenceStrength(kind, prev.Price, cur.Price, v2-v1, high-low),
+		})
+	}
+	return events
+}
+
+// settled reports whether oscillator k has a value at bar; RSI stays 0 until it has seen a loss
+func (d *divergenceDetector) settled(k, bar int, value float64) bool {
+	return bar >= oscillators[k].warmup && (oscillators[k].Name != "RSI" || value != 0)
+}
+
+// divergenceStrength scores a divergence from 0 to 100: 20 for a regular and 10 for a hidden divergence,
+// up to 40 as the price moves up to 10% between the swings and up to 40 as the oscillator moves
+// across the whole range it covered between them
+func divergenceStrength(kind string, price1, price2, move, span float64) float64 {
+	strength := 10.0
+	if kind == "regular" {
+		strength = 20
+	}
+	strength += math.Min(math.Abs(price2-price1)/price1/0.10, 1) * 40
+	if span > 0 {
+		strength += math.Min(math.Abs(move)/span, 1) * 40
+	}
+	return numeric.Round(strength, 0)
+}
+
+// divStepper feeds DIV(lookback,window): the divergences confirmed on each bar,
+// and the bias and strength of the strongest
+type divStepper struct {
+	divergenceDetector
+
+	types          []string
+	bias, strength []float64
+}
+
+func newDIV(f *Frame, p Params, total int) Stepper {
+	cols := registry["DIV"].Outputs(p)
+	return &divStepper{
+		divergenceDetector: newDivergenceDetector(p.Int(0), p.Int(1), f.Len()),
+		types:              f.AddText(cols[0]),
+		bias:               f.AddNumber(cols[1]),
+		strength:           f.AddNumber(cols[2]),
+	}
+}
+
+func (s *divStepper) Step(f *Frame, i int) {
+	events := s.step(f, i)
+	if len(events) == 0 {
+		return
+	}
+	labels := make([]string, len(events))
+	for k, e := range events {
+		labels[k] = e.Label()
+	}
+	s.types[i] = strings.Join(labels, "; ")
+	s.bias[i] = 1
+	if events[0].Bias == "bearish" {
+		s.bias[i] = -1
+	}
+	s.strength[i] = events[0].Strength
+}
+
+// DetectDivergences returns every divergence in the frame, oldest first
+func DetectDivergences(f *Frame, lookback, window int) []DivergenceEvent {
+	d := newDivergenceDetector(lookback, window, f.Len())
+	events := make([]DivergenceEvent, 0)
+	for i := 0; i < f.Len(); i++ {
+		events = append(events, d.step(f, i)...)
+	}
+	return events
+}
+
+// DivergenceFile returns the divergence list of ticker for a timeframe, e.g. divergences_BBOB.csv
+func DivergenceFile(ticker string, tf Timeframe) string {
+	return "divergences_" + ticker + tf.Suffix() + ".csv"
+}
+
+// SaveDivergences writes the events to filePath
+func SaveDivergences(events []DivergenceEvent, filePath string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return gocsv.MarshalFile(&events, file)
+}
+
+// LoadDivergences reads the events written by SaveDivergences
+func LoadDivergences(filePath string) ([]DivergenceEvent, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var events []DivergenceEvent
+	if err := gocsv.UnmarshalFile(file, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,152 @@
+package indicators
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// The files in testdata/decimal were written by the decimal calculator the float64 engine replaced
+// (commit 22ca65d, `calc --numeric`) from testdata/raw_<TICKER>.csv, with its Newton square root run
+// to convergence: the early stop was the fault the port fixed on purpose. A value may differ from
+// them by one unit in the last decimal place its column is written with, where the float64 and
+// decimal results round to either side of a half.
+
+// decimalTickers are the fixtures: a liquid bank near 4, a thin bank below 1 and a telecom near 12
+var decimalTickers = []string{"BBOB", "BASH", "TASC"}
+
+// convertedSpecs are the indicators that ran in decimal, with the parameters of their columns then
+var convertedSpecs = []string{
+	"SMA(10)", "SMA(50)", "SMA(200)", "CROSS(50,200)",
+	"EMA(5)", "EMA(10)", "EMA(20)", "EMA(50)", "EMA(200)",
+	"RSI(14)", "RSI(9)", "RSI(25)",
+	"STOCH(9,6,3)",
+	"MACD(12,26,9)",
+	"CMF(20)",
+	"OBV(10)",
+	"PSAR(0.02,0.2)", "PSAR(0.01,0.1)",
+	"ATR(14)",
+	"STD(10)", "STD(50)",
+}
+
+// goldenFile is one decimal output file by column
+type goldenFile struct {
+	rows   int
+	cells  map[string][]string
+	places map[string]int // Most decimal places written in the column
+}
+
+func loadGolden(t testing.TB, path string) *goldenFile {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := &goldenFile{rows: len(records) - 1, cells: make(map[string][]string), places: make(map[string]int)}
+	for k, name := range records[0] {
+		for _, record := range records[1:] {
+			cell := record[k]
+			g.cells[name] = append(g.cells[name], cell)
+			if _, fraction, ok := strings.Cut(cell, "."); ok {
+				g.places[name] = max(g.places[name], len(fraction))
+			}
+		}
+	}
+	return g
+}
+
+// loadFixture reads the raw bars of a fixture ticker
+func loadFixture(t testing.TB, ticker string) *Frame {
+	t.Helper()
+	f, err := LoadRawFrame(filepath.Join("testdata", fmt.Sprintf("raw_%s.csv", ticker)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestSpecsMatchDecimalEngine(t *testing.T) {
+	for _, ticker := range decimalTickers {
+		bars := loadFixture(t, ticker)
+		golden := loadGolden(t, filepath.Join("testdata", "decimal", fmt.Sprintf("Indicators2_%s.csv", ticker)))
+		if golden.rows != bars.Len() {
+			t.Fatalf("%s: golden file has %d rows for %d bars", ticker, golden.rows, bars.Len())
+		}
+
+		for _, text := range convertedSpecs {
+			t.Run(ticker+"/"+text, func(t *testing.T) {
+				specs := mustSpecs(t, text)
+				f := bars.Slice(0)
+				if _, err := ApplySpecs(f, specs); err != nil {
+					t.Fatal(err)
+				}
+
+				for _, name := range specs[0].Columns() {
+					want, ok := golden.cells[name]
+					if !ok {
+						t.Fatalf("golden file has no column %s", name)
+					}
+					tolerance := math.Pow10(-golden.places[name]) + 1e-9
+					column := f.Column(name)
+					for i, cell := range want {
+						if column.Flag != nil {
+							if got := strconv.FormatBool(column.Flag[i]); got != cell {
+								t.Errorf("%s on %s = %s, decimal engine %s", name, bars.Dates[i].Format("2006-01-02"), got, cell)
+							}
+							continue
+						}
+						wantValue, err := strconv.ParseFloat(cell, 64)
+						if err != nil {
+							t.Fatalf("%s row %d: %v", name, i, err)
+						}
+						if got := column.Num[i]; math.Abs(got-wantValue) > tolerance {
+							t.Errorf("%s on %s = %v, decimal engine %s (tolerance %g)",
+								name, bars.Dates[i].Format("2006-01-02"), got, cell, tolerance)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkSpecs(b *testing.B) {
+	bars := loadFixture(b, "BBOB")
+	for _, text := range convertedSpecs {
+		specs, err := ParseSpecs([]string{text})
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(text, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := ApplySpecs(bars.Slice(0), specs); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDefaultSpecs(b *testing.B) {
+	bars := loadFixture(b, "BBOB")
+	specs, err := ParseSpecs(DefaultSpecTexts)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < b.N; i++ {
+		if _, err := ApplySpecs(bars.Slice(0), specs); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
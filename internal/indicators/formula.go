@@ -0,0 +1,722 @@
+package indicators
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"isx-auto-scrapper/internal/numeric"
+)
+
+// Custom indicators are formulas over the bar and indicator columns, read from custom_indicators.json:
+//
+//	{ "indicators": [ { "name": "ATR_Distance_SMA50", "formula": "(Close - SMA50) / ATR_14" } ] }
+//
+// Formulas combine numbers and columns with + - * /, comparisons (> < >= <= == !=), AND, OR, NOT and
+// the functions SMA, EMA, STD, MAX, MIN (x, n), LAG(x, n) and CROSS(a, b). Each formula writes one
+// column after the spec columns: a number, or true/false when it is a comparison or CROSS.
+// They run as specs, so their state is saved and extended like any other indicator.
+
+// CustomIndicator is one user-defined column and the formula computing it
+type CustomIndicator struct {
+	Name        string `json:"name"`
+	Formula     string `json:"formula"`
+	Description string `json:"description,omitempty"`
+}
+
+// customFile is the layout of the custom indicator file
+type customFile struct {
+	Indicators []CustomIndicator `json:"indicators"`
+}
+
+// customNamePattern matches the names formulas may give their columns
+var customNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// readCustomFile reads the custom indicator file; a missing file defines none
+func readCustomFile(path string) ([]CustomIndicator, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file customFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return file.Indicators, nil
+}
+
+// LoadCustomIndicators reads the custom indicator file and compiles its formulas against the columns
+// of specs and of the formulas before them. A missing file gives no specs.
+func LoadCustomIndicators(path string, specs []Spec) ([]Spec, error) {
+	custom, err := readCustomFile(path)
+	if err != nil || len(custom) == 0 {
+		return nil, err
+	}
+
+	probe, columns := probeColumns(specs)
+	taken := func(name string) bool {
+		return probe.Column(name) != nil || slices.Contains(baseColumns, name) || slices.Contains(descriptionColumns, name)
+	}
+
+	out := make([]Spec, 0, len(custom))
+	for _, ci := range custom {
+		if !customNamePattern.MatchString(ci.Name) {
+			return nil, fmt.Errorf("invalid %s: custom indicator name %q must start with a letter and hold only letters, digits, _ and .", path, ci.Name)
+		}
+		if taken(ci.Name) {
+			return nil, fmt.Errorf("invalid %s: custom indicator %s would replace an existing column", path, ci.Name)
+		}
+		if _, dup := columns[ci.Name]; dup {
+			return nil, fmt.Errorf("invalid %s: custom indicator %s is defined twice", path, ci.Name)
+		}
+
+		root, calls, refs, err := compileFormula(ci.Formula, columns)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s: %w", path, ci.Name, err)
+		}
+		columns[ci.Name] = root.flag
+		out = append(out, Spec{Def: formulaDefinition(ci, root, calls, refs)})
+	}
+	return out, nil
+}
+
+// probeColumns runs the specs on an empty frame to tell which columns they write. It returns the
+// frame and every column a formula can read, mapped to whether it is a flag.
+func probeColumns(specs []Spec) (*Frame, map[string]bool) {
+	probe := NewFrame(0)
+	for _, spec := range specs {
+		spec.Def.New(probe, spec.Params, 0)
+	}
+	columns := make(map[string]bool)
+	for field := range barFields {
+		columns[field] = false
+	}
+	for _, c := range probe.columns {
+		if c.Text == nil {
+			columns[c.Name] = c.Flag != nil
+		}
+	}
+	return probe, columns
+}
+
+// FormulaColumns returns the columns a formula can read once specs are computed, mapped to whether each is a flag
+func FormulaColumns(specs []Spec) map[string]bool {
+	_, columns := probeColumns(specs)
+	return columns
+}
+
+// Condition is a formula giving true or false, judged bar by bar outside a frame
+type Condition struct {
+	root  *formulaNode
+	calls int
+	refs  []string
+}
+
+// CompileCondition compiles a true/false formula over columns, which maps every readable column to
+// whether it is a flag
+func CompileCondition(text string, columns map[string]bool) (*Condition, error) {
+	root, calls, refs, err := compileFormula(text, columns)
+	if err != nil {
+		return nil, err
+	}
+	if !root.flag {
+		return nil, fmt.Errorf("%s is a number, not true/false; compare it with > or <", root)
+	}
+	return &Condition{root: root, calls: calls, refs: refs}, nil
+}
+
+// Columns returns the columns the condition reads, in the order its judge takes their values
+func (c *Condition) Columns() []string {
+	return c.refs
+}
+
+// String writes the condition in canonical form
+func (c *Condition) String() string {
+	return c.root.String()
+}
+
+// Judge returns a judge starting at the first bar; each call takes the values of Columns on the next bar
+func (c *Condition) Judge() func(values []float64) bool {
+	var current []float64
+	s := &formulaStepper{Calls: make([]formulaCall, c.calls), root: c.root}
+	for k := range c.refs {
+		s.refs = append(s.refs, func(int) float64 { return current[k] })
+	}
+	return func(values []float64) bool {
+		current = values
+		return s.eval(c.root, 0) != 0
+	}
+}
+
+// formulaDefinition wraps a compiled formula as an unregistered indicator definition.
+// Its name holds the formula, so editing a formula invalidates the saved state.
+func formulaDefinition(ci CustomIndicator, root *formulaNode, calls int, refs []string) *Definition {
+	description := ci.Description
+	if description == "" {
+		description = "Custom formula " + root.String()
+	}
+	return &Definition{
+		Name:        ci.Name + " = " + root.String(),
+		Description: description,
+		Outputs:     func(p Params) []string { return []string{ci.Name} },
+		New: func(f *Frame, p Params, total int) Stepper {
+			s := &formulaStepper{Calls: make([]formulaCall, calls), root: root}
+			for _, name := range refs {
+				s.refs = append(s.refs, bindColumn(f, name))
+			}
+			if root.flag {
+				s.flag = f.AddFlag(ci.Name)
+			} else {
+				s.out = f.AddNumber(ci.Name)
+			}
+			return s
+		},
+	}
+}
+
+// barFields are the bar columns a formula can read
+var barFields = map[string]func(f *Frame, i int) float64{
+	"Open":           func(f *Frame, i int) float64 { return f.Open[i] },
+	"High":           func(f *Frame, i int) float64 { return f.High[i] },
+	"Low":            func(f *Frame, i int) float64 { return f.Low[i] },
+	"Close":          func(f *Frame, i int) float64 { return f.Close[i] },
+	"Volume":         func(f *Frame, i int) float64 { return float64(f.Volume[i]) },
+	"Trades":         func(f *Frame, i int) float64 { return float64(f.Trades[i]) },
+	"Change":         func(f *Frame, i int) float64 { return f.Change[i] },
+	"Change_Percent": func(f *Frame, i int) float64 { return f.ChangePercent[i] },
+}
+
+// bindColumn returns a reader of column name in f; flags read as 1 or 0
+func bindColumn(f *Frame, name string) func(i int) float64 {
+	if field, ok := barFields[name]; ok {
+		return func(i int) float64 { return field(f, i) }
+	}
+	c := f.Column(name)
+	if c.Flag != nil {
+		return func(i int) float64 { return truth(c.Flag[i]) }
+	}
+	return func(i int) float64 { return c.Num[i] }
+}
+
+// truth turns a flag into the 1 or 0 formulas compute with
+func truth(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// formulaStepper feeds one custom indicator; Calls holds the state of its windowed functions
+type formulaStepper struct {
+	Calls []formulaCall `json:"calls"`
+
+	root *formulaNode
+	refs []func(i int) float64
+	out  []float64
+	flag []bool
+}
+
+// formulaCall is the running state of one SMA, EMA, STD, MAX, MIN, LAG or CROSS call
+type formulaCall struct {
+	Seen   int                 `json:"seen"`
+	Window *numeric.Window     `json:"window,omitempty"` // SMA, STD
+	Max    *numeric.RollingMax `json:"max,omitempty"`
+	Min    *numeric.RollingMin `json:"min,omitempty"`
+	EMA    float64             `json:"ema,omitempty"`  // Unrounded
+	Past   []float64           `json:"past,omitempty"` // LAG: the last n values, oldest first
+	PrevA  float64             `json:"prev_a,omitempty"`
+	PrevB  float64             `json:"prev_b,omitempty"`
+}
+
+func (s *formulaStepper) Step(f *Frame, i int) {
+	v := s.eval(s.root, i)
+	if s.flag != nil {
+		s.flag[i] = v != 0
+		return
+	}
+	s.out[i] = numeric.Round(v, 4)
+}
+
+// eval computes node n on bar i. Every call is evaluated on every bar so its window stays complete.
+func (s *formulaStepper) eval(n *formulaNode, i int) float64 {
+	switch n.op {
+	case "num":
+		return n.value
+	case "col":
+		return s.refs[n.slot](i)
+	}
+
+	a := s.eval(n.args[0], i)
+	var b float64
+	if len(n.args) > 1 {
+		b = s.eval(n.args[1], i)
+	}
+
+	switch n.op {
+	case "neg":
+		return -a
+	case "+":
+		return a + b
+	case "-":
+		return a - b
+	case "*":
+		return a * b
+	case "/":
+		if b == 0 {
+			return 0
+		}
+		return a / b
+	case ">":
+		return truth(a > b)
+	case "<":
+		return truth(a < b)
+	case ">=":
+		return truth(a >= b)
+	case "<=":
+		return truth(a <= b)
+	case "==":
+		return truth(a == b)
+	case "!=":
+		return truth(a != b)
+	case "AND":
+		return truth(a != 0 && b != 0)
+	case "OR":
+		return truth(a != 0 || b != 0)
+	case "NOT":
+		return truth(a == 0)
+	}
+	return s.Calls[n.slot].step(n.op, int(n.value), a, b)
+}
+
+// step feeds value a (and b for CROSS) to a windowed function over period bars.
+// Windows give 0 until they are full, as the built-in indicators do.
+func (c *formulaCall) step(function string, period int, a, b float64) float64 {
+	defer func() { c.Seen++ }()
+	switch function {
+	case "SMA", "STD":
+		if c.Window == nil {
+			c.Window = &numeric.Window{}
+		}
+		if !c.Window.Push(a, period) {
+			return 0
+		}
+		if function == "SMA" {
+			return c.Window.Mean()
+		}
+		return c.Window.Std()
+	case "MAX":
+		if c.Max == nil {
+			c.Max = &numeric.RollingMax{}
+		}
+		if v := c.Max.Push(a, period); c.Seen+1 >= period {
+			return v
+		}
+		return 0
+	case "MIN":
+		if c.Min == nil {
+			c.Min = &numeric.RollingMin{}
+		}
+		if v := c.Min.Push(a, period); c.Seen+1 >= period {
+			return v
+		}
+		return 0
+	case "EMA":
+		// Seeded with the first value and written from the second, like EMA(n)
+		if c.Seen == 0 {
+			c.EMA = a
+			return 0
+		}
+		m := numeric.EMAMultiplier(period)
+		c.EMA = a*m + c.EMA*(1-m)
+		return c.EMA
+	case "LAG":
+		v := 0.0
+		if len(c.Past) == period {
+			v = c.Past[0]
+		}
+		c.Past = append(c.Past, a)
+		if len(c.Past) > period {
+			c.Past = c.Past[1:]
+		}
+		return v
+	case "CROSS":
+		crossed := c.Seen > 0 && a > b && c.PrevA <= c.PrevB
+		c.PrevA, c.PrevB = a, b
+		return truth(crossed)
+	}
+	panic("unknown formula function " + function)
+}
+
+// formulaNode is one node of a parsed formula
+type formulaNode struct {
+	op     string  // "num", "col", "neg", an operator or a function name
+	value  float64 // Constant of "num", period of a windowed function
+	column string
+	args   []*formulaNode
+	flag   bool // Evaluates to true/false rather than a number
+	slot   int  // Column reader of "col", call state of a function
+}
+
+// formulaFunctions are the functions formulas may call and whether they take a period
+var formulaFunctions = map[string]bool{
+	"SMA": true, "EMA": true, "STD": true, "MAX": true, "MIN": true, "LAG": true, "CROSS": false,
+}
+
+// precedence orders the operators from loosest to tightest binding
+var precedence = map[string]int{
+	"OR": 1, "AND": 2, "NOT": 3,
+	">": 4, "<": 4, ">=": 4, "<=": 4, "==": 4, "!=": 4,
+	"+": 5, "-": 5, "*": 6, "/": 6, "neg": 7,
+}
+
+// String writes the node in canonical form, with parentheses only where needed
+func (n *formulaNode) String() string {
+	switch n.op {
+	case "num":
+		return strconv.FormatFloat(n.value, 'f', -1, 64)
+	case "col":
+		return n.column
+	case "neg":
+		return "-" + n.operand(n.args[0], 7)
+	case "NOT":
+		return "NOT " + n.operand(n.args[0], 3)
+	}
+	if p, ok := precedence[n.op]; ok {
+		return n.operand(n.args[0], p) + " " + n.op + " " + n.operand(n.args[1], p+1)
+	}
+	args := make([]string, len(n.args))
+	for k, arg := range n.args {
+		args[k] = arg.String()
+	}
+	if formulaFunctions[n.op] {
+		args = append(args, strconv.Itoa(int(n.value)))
+	}
+	return n.op + "(" + strings.Join(args, ", ") + ")"
+}
+
+// operand writes an operand, in parentheses when it binds looser than min
+func (n *formulaNode) operand(arg *formulaNode, min int) string {
+	if p, ok := precedence[arg.op]; ok && p < min {
+		return "(" + arg.String() + ")"
+	}
+	return arg.String()
+}
+
+// formulaParser is a recursive descent parser over the tokens of one formula
+type formulaParser struct {
+	tokens  []string
+	pos     int
+	columns map[string]bool // Known columns and whether each is a flag
+	refs    []string        // Columns read, in slot order
+	calls   int
+}
+
+// compileFormula parses text and checks its columns and types.
+// It returns the root node, the number of function calls keeping state and the columns read.
+func compileFormula(text string, columns map[string]bool) (*formulaNode, int, []string, error) {
+	tokens, err := tokenizeFormula(text)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, 0, nil, fmt.Errorf("empty formula")
+	}
+
+	p := &formulaParser{tokens: tokens, columns: columns}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, 0, nil, fmt.Errorf("unexpected %q in %q", p.tokens[p.pos], text)
+	}
+	return root, p.calls, p.refs, nil
+}
+
+// tokenizeFormula splits a formula into numbers, names and operators
+func tokenizeFormula(text string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case isDigit(c) || c == '.':
+			j := i
+			for j < len(text) && (isDigit(text[j]) || text[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, text[i:j])
+			i = j
+		case isLetter(c):
+			j := i
+			for j < len(text) && (isLetter(text[j]) || isDigit(text[j]) || text[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, text[i:j])
+			i = j
+		case strings.ContainsRune("<>=!", rune(c)) && i+1 < len(text) && text[i+1] == '=':
+			tokens = append(tokens, text[i:i+2])
+			i += 2
+		case strings.ContainsRune("+-*/(),<>", rune(c)):
+			tokens = append(tokens, text[i:i+1])
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected character %q in %q", c, text)
+		}
+	}
+	return tokens, nil
+}
+
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+func isLetter(c byte) bool { return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+
+// peek returns the next token, or "" at the end
+func (p *formulaParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// keyword reports whether the next token is the keyword word, in any case
+func (p *formulaParser) keyword(word string) bool {
+	return strings.EqualFold(p.peek(), word)
+}
+
+// expect consumes token or fails
+func (p *formulaParser) expect(token string) error {
+	if p.peek() != token {
+		if p.peek() == "" {
+			return fmt.Errorf("expected %q at the end of the formula", token)
+		}
+		return fmt.Errorf("expected %q, got %q", token, p.peek())
+	}
+	p.pos++
+	return nil
+}
+
+// binary builds an operator node after checking its operand types
+func binary(op string, a, b *formulaNode) (*formulaNode, error) {
+	n := &formulaNode{op: op, args: []*formulaNode{a, b}}
+	switch op {
+	case "AND", "OR":
+		if !a.flag || !b.flag {
+			return nil, fmt.Errorf("%s needs true/false operands, e.g. comparisons", op)
+		}
+		n.flag = true
+	case "==", "!=":
+		if a.flag != b.flag {
+			return nil, fmt.Errorf("%s compares a number with true/false", op)
+		}
+		n.flag = true
+	case ">", "<", ">=", "<=":
+		if a.flag || b.flag {
+			return nil, fmt.Errorf("%s needs numbers", op)
+		}
+		n.flag = true
+	default:
+		if a.flag || b.flag {
+			return nil, fmt.Errorf("%s needs numbers, not true/false", op)
+		}
+	}
+	return n, nil
+}
+
+func (p *formulaParser) parseOr() (*formulaNode, error) {
+	n, err := p.parseAnd()
+	for err == nil && p.keyword("OR") {
+		p.pos++
+		var rhs *formulaNode
+		if rhs, err = p.parseAnd(); err == nil {
+			n, err = binary("OR", n, rhs)
+		}
+	}
+	return n, err
+}
+
+func (p *formulaParser) parseAnd() (*formulaNode, error) {
+	n, err := p.parseNot()
+	for err == nil && p.keyword("AND") {
+		p.pos++
+		var rhs *formulaNode
+		if rhs, err = p.parseNot(); err == nil {
+			n, err = binary("AND", n, rhs)
+		}
+	}
+	return n, err
+}
+
+func (p *formulaParser) parseNot() (*formulaNode, error) {
+	if !p.keyword("NOT") {
+		return p.parseComparison()
+	}
+	p.pos++
+	n, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	if !n.flag {
+		return nil, fmt.Errorf("NOT needs a true/false operand")
+	}
+	return &formulaNode{op: "NOT", args: []*formulaNode{n}, flag: true}, nil
+}
+
+func (p *formulaParser) parseComparison() (*formulaNode, error) {
+	n, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if op := p.peek(); precedence[op] == 4 {
+		p.pos++
+		rhs, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		return binary(op, n, rhs)
+	}
+	return n, nil
+}
+
+func (p *formulaParser) parseSum() (*formulaNode, error) {
+	n, err := p.parseTerm()
+	for err == nil && (p.peek() == "+" || p.peek() == "-") {
+		op := p.peek()
+		p.pos++
+		var rhs *formulaNode
+		if rhs, err = p.parseTerm(); err == nil {
+			n, err = binary(op, n, rhs)
+		}
+	}
+	return n, err
+}
+
+func (p *formulaParser) parseTerm() (*formulaNode, error) {
+	n, err := p.parseUnary()
+	for err == nil && (p.peek() == "*" || p.peek() == "/") {
+		op := p.peek()
+		p.pos++
+		var rhs *formulaNode
+		if rhs, err = p.parseUnary(); err == nil {
+			n, err = binary(op, n, rhs)
+		}
+	}
+	return n, err
+}
+
+func (p *formulaParser) parseUnary() (*formulaNode, error) {
+	if p.peek() != "-" {
+		return p.parsePrimary()
+	}
+	p.pos++
+	n, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if n.flag {
+		return nil, fmt.Errorf("cannot negate true/false")
+	}
+	if n.op == "num" {
+		n.value = -n.value
+		return n, nil
+	}
+	return &formulaNode{op: "neg", args: []*formulaNode{n}}, nil
+}
+
+func (p *formulaParser) parsePrimary() (*formulaNode, error) {
+	token := p.peek()
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("formula ends early")
+	case token == "(":
+		p.pos++
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return n, p.expect(")")
+	case isDigit(token[0]) || token[0] == '.':
+		v, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", token)
+		}
+		p.pos++
+		return &formulaNode{op: "num", value: v}, nil
+	case !isLetter(token[0]):
+		return nil, fmt.Errorf("unexpected %q", token)
+	}
+
+	p.pos++
+	if p.peek() == "(" {
+		return p.parseCall(token)
+	}
+	flag, ok := p.columns[token]
+	if !ok {
+		return nil, fmt.Errorf("unknown column %s", token)
+	}
+	slot := slices.Index(p.refs, token)
+	if slot < 0 {
+		slot = len(p.refs)
+		p.refs = append(p.refs, token)
+	}
+	return &formulaNode{op: "col", column: token, flag: flag, slot: slot}, nil
+}
+
+// parseCall parses the arguments of a function call; the opening parenthesis is next
+func (p *formulaParser) parseCall(name string) (*formulaNode, error) {
+	function := strings.ToUpper(name)
+	windowed, ok := formulaFunctions[function]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s; use SMA, EMA, STD, MAX, MIN, LAG or CROSS", name)
+	}
+	p.pos++
+
+	n := &formulaNode{op: function, slot: p.calls}
+	p.calls++
+	arg, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	n.args = append(n.args, arg)
+	if err := p.expect(","); err != nil {
+		if function != "LAG" {
+			return nil, fmt.Errorf("%s takes two arguments: %w", function, err)
+		}
+		n.value = 1 // LAG(x) is the previous bar
+		n.flag = arg.flag
+		return n, p.expect(")")
+	}
+
+	if windowed {
+		period, err := strconv.Atoi(p.peek())
+		if err != nil || period < 1 {
+			return nil, fmt.Errorf("%s period must be a whole number of at least 1, got %q", function, p.peek())
+		}
+		p.pos++
+		n.value = float64(period)
+		if function == "LAG" {
+			n.flag = arg.flag
+		} else if arg.flag {
+			return nil, fmt.Errorf("%s needs a number, not true/false", function)
+		}
+	} else {
+		other, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if arg.flag || other.flag {
+			return nil, fmt.Errorf("CROSS needs numbers, not true/false")
+		}
+		n.args = append(n.args, other)
+		n.flag = true
+	}
+	return n, p.expect(")")
+}
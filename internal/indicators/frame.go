@@ -0,0 +1,288 @@
+package indicators
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gocarina/gocsv"
+
+	"isx-auto-scrapper/internal/numeric"
+)
+
+// baseColumns are the bar columns written before the indicator columns
+var baseColumns = []string{"Date", "Open", "High", "Low", "Close", "Volume", "Trades", "Change", "Change_Percent"}
+
+// Frame is a columnar table of daily bars plus the indicator columns computed from them.
+// Prices and indicator values are float64; they become decimal text only when written.
+type Frame struct {
+	Dates         []time.Time
+	Open          []float64
+	High          []float64
+	Low           []float64
+	Close         []float64
+	Change        []float64
+	ChangePercent []float64
+	Volume        []int64
+	Trades        []int64
+
+	columns []*Column
+	byName  map[string]*Column
+}
+
+// Column is one output column; exactly one of Num, Flag or Text is set
+type Column struct {
+	Name string
+	Num  []float64
+	Flag []bool
+	Text []string
+}
+
+// NewFrame creates an empty frame with room for n bars
+func NewFrame(n int) *Frame {
+	return &Frame{
+		Dates:         make([]time.Time, 0, n),
+		Open:          make([]float64, 0, n),
+		High:          make([]float64, 0, n),
+		Low:           make([]float64, 0, n),
+		Close:         make([]float64, 0, n),
+		Change:        make([]float64, 0, n),
+		ChangePercent: make([]float64, 0, n),
+		Volume:        make([]int64, 0, n),
+		Trades:        make([]int64, 0, n),
+		byName:        make(map[string]*Column),
+	}
+}
+
+// readRawFrame reads raw_<TICKER>.csv into a frame as written by the scraper
+func readRawFrame(filePath string) (*Frame, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var rawData []*StockDataCSV
+	if err := gocsv.UnmarshalFile(file, &rawData); err != nil {
+		return nil, err
+	}
+
+	f := NewFrame(len(rawData))
+	for _, data := range rawData {
+		f.Dates = append(f.Dates, data.Date.Time)
+		f.Open = append(f.Open, numeric.Float(data.Open))
+		f.High = append(f.High, numeric.Float(data.High))
+		f.Low = append(f.Low, numeric.Float(data.Low))
+		f.Close = append(f.Close, numeric.Float(data.Close))
+		f.Change = append(f.Change, numeric.Float(data.Change))
+		f.ChangePercent = append(f.ChangePercent, numeric.Float(ParsePercentage(data.ChangePercent)))
+		f.Volume = append(f.Volume, data.Volume)
+		f.Trades = append(f.Trades, parseCount(data.NoTrades))
+	}
+	return f, nil
+}
+
+// parseCount parses a count like "1,234"; empty or invalid values give 0
+func parseCount(s string) int64 {
+	n, err := strconv.ParseInt(strings.ReplaceAll(strings.TrimSpace(s), ",", ""), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// Slice returns a frame holding the bars from index from onwards, without indicator columns
+func (f *Frame) Slice(from int) *Frame {
+	out := NewFrame(0)
+	out.Dates = f.Dates[from:]
+	out.Open = f.Open[from:]
+	out.High = f.High[from:]
+	out.Low = f.Low[from:]
+	out.Close = f.Close[from:]
+	out.Change = f.Change[from:]
+	out.ChangePercent = f.ChangePercent[from:]
+	out.Volume = f.Volume[from:]
+	out.Trades = f.Trades[from:]
+	return out
+}
+
+// Len returns the number of bars
+func (f *Frame) Len() int {
+	return len(f.Dates)
+}
+
+// add registers a new column, replacing any column of the same name
+func (f *Frame) add(c *Column) {
+	if old, ok := f.byName[c.Name]; ok {
+		*old = *c
+		return
+	}
+	f.columns = append(f.columns, c)
+	f.byName[c.Name] = c
+}
+
+// AddNumber adds a numeric column filled with zeros and returns its values
+func (f *Frame) AddNumber(name string) []float64 {
+	values := make([]float64, f.Len())
+	f.add(&Column{Name: name, Num: values})
+	return values
+}
+
+// AddFlag adds a boolean column and returns its values
+func (f *Frame) AddFlag(name string) []bool {
+	values := make([]bool, f.Len())
+	f.add(&Column{Name: name, Flag: values})
+	return values
+}
+
+// AddText adds a text column and returns its values
+func (f *Frame) AddText(name string) []string {
+	values := make([]string, f.Len())
+	f.add(&Column{Name: name, Text: values})
+	return values
+}
+
+// Column returns the named column or nil
+func (f *Frame) Column(name string) *Column {
+	return f.byName[name]
+}
+
+// Number returns the values of a numeric column, or nil when it does not exist
+func (f *Frame) Number(name string) []float64 {
+	if c := f.byName[name]; c != nil {
+		return c.Num
+	}
+	return nil
+}
+
+// Flag returns the values of a boolean column, or nil when it does not exist
+func (f *Frame) Flag(name string) []bool {
+	if c := f.byName[name]; c != nil {
+		return c.Flag
+	}
+	return nil
+}
+
+// Header returns the CSV header: the bar columns followed by every added column
+func (f *Frame) Header() []string {
+	header := append([]string{}, baseColumns...)
+	for _, c := range f.columns {
+		header = append(header, c.Name)
+	}
+	return header
+}
+
+// WriteCSV writes the frame with RFC3339 dates, as the indicator files always used
+func (f *Frame) WriteCSV(filePath string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	if err := w.Write(f.Header()); err != nil {
+		return err
+	}
+	return f.writeRows(w)
+}
+
+// AppendCSV appends the rows of the frame to an indicator file with the same header
+func (f *Frame) AppendCSV(filePath string) error {
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return f.writeRows(csv.NewWriter(file))
+}
+
+// writeRows writes every bar with its indicator columns and flushes the writer
+func (f *Frame) writeRows(w *csv.Writer) error {
+	record := make([]string, 0, len(baseColumns)+len(f.columns))
+	for i := 0; i < f.Len(); i++ {
+		record = append(record[:0],
+			f.Dates[i].Format(time.RFC3339),
+			formatNumber(f.Open[i]),
+			formatNumber(f.High[i]),
+			formatNumber(f.Low[i]),
+			formatNumber(f.Close[i]),
+			strconv.FormatInt(f.Volume[i], 10),
+			strconv.FormatInt(f.Trades[i], 10),
+			formatNumber(f.Change[i]),
+			formatNumber(f.ChangePercent[i]),
+		)
+		for _, c := range f.columns {
+			switch {
+			case c.Num != nil:
+				record = append(record, formatNumber(c.Num[i]))
+			case c.Flag != nil:
+				record = append(record, strconv.FormatBool(c.Flag[i]))
+			default:
+				record = append(record, c.Text[i])
+			}
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// formatNumber writes v as decimal text, the shortest form that reads back as the same float64
+func formatNumber(v float64) string {
+	return numeric.Decimal(v).String()
+}
+
+// csvSummary is the header, row count and last row date of an indicator file
+type csvSummary struct {
+	Header   []string
+	Rows     int
+	LastDate time.Time
+}
+
+// readCSVSummary reads the header, row count and last row date of an indicator file
+func readCSVSummary(filePath string) (*csvSummary, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	r := csv.NewReader(file)
+	r.FieldsPerRecord = -1
+	r.ReuseRecord = true
+	header, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	summary := &csvSummary{Header: append([]string{}, header...)}
+
+	var lastDate string
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		summary.Rows++
+		lastDate = record[0]
+	}
+	if summary.Rows == 0 {
+		return nil, fmt.Errorf("%s has no rows", filePath)
+	}
+
+	if summary.LastDate, err = time.Parse(time.RFC3339, lastDate); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
@@ -1,14 +1,24 @@
 package indicators
 
 import (
+	"bytes"
+	"cmp"
+	"encoding/csv"
 	"fmt"
+	"io"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gocarina/gocsv"
 	"github.com/shopspring/decimal"
 
+	"isx-auto-scrapper/internal/calendar"
 	"isx-auto-scrapper/internal/common"
+	"isx-auto-scrapper/internal/i18n"
+	"isx-auto-scrapper/internal/numeric"
 )
 
 // CSVDate is a custom type for parsing dates from CSV
@@ -45,7 +55,8 @@ type StockDataCSV struct {
 	NoTrades      string          `csv:"No. Trades"` // String because it might be empty
 }
 
-// StockDataWithIndicators extends StockData with technical indicators
+// StockDataWithIndicators is a row of an indicator file: the fixed view of its long-standing columns, which
+// the strategy files keep, and every column through Value and Cell. Fixed columns missing from it read as zero.
 type StockDataWithIndicators struct {
 	common.StockData
 
@@ -57,8 +68,7 @@ type StockDataWithIndicators struct {
 	CMF20 decimal.Decimal `csv:"CMF_20"`
 
 	// OBV additional indicators
-	OBVSMADiff decimal.Decimal `csv:"OBV_SMA_Diff"`
-	OBVRoC     decimal.Decimal `csv:"OBV_RoC"`
+	OBVRoC decimal.Decimal `csv:"OBV_RoC"`
 
 	// Additional PSAR
 	PSAR1 decimal.Decimal `csv:"PSARl_0.02_0.2"`
@@ -66,1027 +76,878 @@ type StockDataWithIndicators struct {
 	// Additional Rolling Standard Deviation periods
 	RollingStd10 decimal.Decimal `csv:"Rolling_Std_10"`
 	RollingStd50 decimal.Decimal `csv:"Rolling_Std_50"`
-}
 
-// IndicatorsCalculator handles technical indicator calculations
-type IndicatorsCalculator struct {
-	logger     *common.Logger
-	indicators *TechnicalIndicators
+	// Cells of the row in every column of its file, by column index
+	columns map[string]int
+	record  []string
 }
 
-// NewIndicatorsCalculator creates a new IndicatorsCalculator instance
-func NewIndicatorsCalculator() *IndicatorsCalculator {
-	return &IndicatorsCalculator{
-		logger:     common.NewLogger(),
-		indicators: NewTechnicalIndicators(),
-	}
-}
-
-// CalculateAll calculates all technical indicators for a ticker
-func (ic *IndicatorsCalculator) CalculateAll(ticker string) error {
-	ic.logger.Info("Calculating indicators for ticker %s", ticker)
-
-	// Check if the raw data CSV file exists
-	rawFilePath := fmt.Sprintf("raw_%s.csv", ticker)
-	if _, err := os.Stat(rawFilePath); os.IsNotExist(err) {
-		ic.logger.Info("File %s does not exist.", rawFilePath)
-		return fmt.Errorf("raw data file does not exist: %s", rawFilePath)
-	}
-
-	// Read the raw data from CSV file
-	stockData, err := ic.loadStockData(rawFilePath)
+// LoadIndicatorFile reads an indicator file into the fixed view used by the strategies;
+// every column of the file, whichever specs wrote it, reads through Value and Cell
+func LoadIndicatorFile(filePath string) ([]*StockDataWithIndicators, error) {
+	data, err := os.ReadFile(filePath)
 	if err != nil {
-		ic.logger.Error("Failed to load stock data: %v", err)
-		return err
-	}
-
-	if len(stockData) == 0 {
-		ic.logger.Error("The DataFrame from raw data is empty.")
-		return fmt.Errorf("no stock data found")
-	}
-
-	// Define the path for the indicators CSV file
-	indicatorsFilePath := fmt.Sprintf("indicators_%s.csv", ticker)
-
-	// Check if the indicators CSV file already exists and is up-to-date
-	if ic.isDataUpToDate(indicatorsFilePath, stockData) {
-		ic.logger.Info("The data is up to date.")
-		return nil
-	}
-
-	// Calculate all the indicators
-	ic.logger.Info("Calculating technical indicators...")
-
-	// Calculate SMA indicators
-	if err := ic.calculateSMA(stockData); err != nil {
-		return fmt.Errorf("failed to calculate SMA: %w", err)
-	}
-
-	// Calculate RSI indicators
-	if err := ic.calculateRSI(stockData); err != nil {
-		return fmt.Errorf("failed to calculate RSI: %w", err)
-	}
-
-	// Calculate Stochastic Oscillator
-	if err := ic.calculateStochasticOscillator(stockData); err != nil {
-		return fmt.Errorf("failed to calculate Stochastic: %w", err)
+		return nil, err
 	}
-
-	// Calculate CMF
-	if err := ic.calculateCMF(stockData); err != nil {
-		return fmt.Errorf("failed to calculate CMF: %w", err)
+	var rows []*StockDataWithIndicators
+	if err := gocsv.UnmarshalBytes(data, &rows); err != nil {
+		return nil, err
 	}
 
-	// Calculate MACD
-	if err := ic.calculateMACD(stockData); err != nil {
-		return fmt.Errorf("failed to calculate MACD: %w", err)
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
 	}
-
-	// Calculate OBV
-	if err := ic.calculateOBV(stockData); err != nil {
-		return fmt.Errorf("failed to calculate OBV: %w", err)
+	if len(records) != len(rows)+1 {
+		return nil, fmt.Errorf("%s: %d records for %d rows", filePath, len(records), len(rows))
 	}
-
-	// Calculate EMA indicators
-	if err := ic.calculateEMAs(stockData); err != nil {
-		return fmt.Errorf("failed to calculate EMAs: %w", err)
+	columns := make(map[string]int, len(records[0]))
+	for k, name := range records[0] {
+		columns[name] = k
 	}
-
-	// Calculate PSAR
-	if err := ic.calculatePSAR(stockData); err != nil {
-		return fmt.Errorf("failed to calculate PSAR: %w", err)
+	for r, row := range rows {
+		row.columns, row.record = columns, records[r+1]
 	}
+	return rows, nil
+}
 
-	// Calculate ATR
-	if err := ic.calculateATR(stockData); err != nil {
-		return fmt.Errorf("failed to calculate ATR: %w", err)
-	}
+// HasColumn reports whether the row's file has the column
+func (d *StockDataWithIndicators) HasColumn(name string) bool {
+	_, ok := d.columns[name]
+	return ok
+}
 
-	// Calculate Rolling Standard Deviation
-	if err := ic.calculateRollingStd(stockData); err != nil {
-		return fmt.Errorf("failed to calculate Rolling Std: %w", err)
+// Cell returns the text of the row in a column, empty when its file has no such column
+func (d *StockDataWithIndicators) Cell(name string) string {
+	if k, ok := d.columns[name]; ok && k < len(d.record) {
+		return d.record[k]
 	}
+	return ""
+}
 
-	// Add descriptions for full mode
-	ic.addDescriptions(stockData)
+// Value returns the number of the row in a column; flags read as 1 or 0, missing and text cells as 0
+func (d *StockDataWithIndicators) Value(name string) float64 {
+	return cellNumber(d.Cell(name))
+}
 
-	// Save the updated data to CSV file
-	if err := ic.saveIndicatorsData(stockData, indicatorsFilePath); err != nil {
-		return fmt.Errorf("failed to save indicators data: %w", err)
+// cellNumber reads a number or flag cell
+func cellNumber(cell string) float64 {
+	if cell == "true" || cell == "false" {
+		return truth(cell == "true")
 	}
-
-	ic.logger.Info("Data calculation completed and saved to %s.", indicatorsFilePath)
-	return nil
+	v, _ := strconv.ParseFloat(cell, 64)
+	return v
 }
 
-// loadStockData loads stock data from CSV file
-func (ic *IndicatorsCalculator) loadStockData(filePath string) ([]*StockDataWithIndicators, error) {
+// LoadIndicatorColumns reads the named columns of an indicator file by bar date (YYYY-MM-DD);
+// flags read as 1 or 0 and columns the file lacks are left out
+func LoadIndicatorColumns(filePath string, names []string) (map[string]map[string]float64, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
 
-	var rawData []*StockDataCSV
-	if err := gocsv.UnmarshalFile(file, &rawData); err != nil {
+	r := csv.NewReader(file)
+	header, err := r.Read()
+	if err != nil {
 		return nil, err
 	}
-
-	// Convert to StockDataWithIndicators
-	stockData := make([]*StockDataWithIndicators, len(rawData))
-	for i, data := range rawData {
-		stockData[i] = &StockDataWithIndicators{
-			StockData: common.StockData{
-				Date:   data.Date.Time,
-				Close:  data.Close,
-				Open:   data.Open,
-				High:   data.High,
-				Low:    data.Low,
-				Volume: data.Volume,
-				Change: data.Change,
-				// Parse ChangePercent (remove % and convert)
-				ChangePercent: ParsePercentage(data.ChangePercent),
-
-				// Initialize all technical indicator fields
-				SMA10:      decimal.Zero,
-				SMA50:      decimal.Zero,
-				SMA200:     decimal.Zero,
-				EMA5:       decimal.Zero,
-				EMA10:      decimal.Zero,
-				EMA20:      decimal.Zero,
-				EMA50:      decimal.Zero,
-				EMA200:     decimal.Zero,
-				RSI14:      decimal.Zero,
-				StochK:     decimal.Zero,
-				StochD:     decimal.Zero,
-				MACD:       decimal.Zero,
-				MACDSignal: decimal.Zero,
-				MACDHist:   decimal.Zero,
-				CMF:        decimal.Zero,
-				OBV:        decimal.Zero,
-				ATR:        decimal.Zero,
-				PSAR:       decimal.Zero,
-				PSAR2:      decimal.Zero,
-				RollingStd: decimal.Zero,
-
-				// Initialize crossover signals
-				GoldenCross:          false,
-				DeathCross:           false,
-				PriceCrossSMA10Up:    false,
-				PriceCrossSMA10Down:  false,
-				PriceCrossSMA50Up:    false,
-				PriceCrossSMA50Down:  false,
-				PriceCrossSMA200Up:   false,
-				PriceCrossSMA200Down: false,
-
-				// Initialize trend indicators
-				SMA10Up:  false,
-				SMA50Up:  false,
-				SMA200Up: false,
-
-				// Initialize distance calculations
-				PriceDistanceSMA10:  decimal.Zero,
-				PriceDistanceSMA50:  decimal.Zero,
-				PriceDistanceSMA200: decimal.Zero,
-
-				// Initialize relationship indicators
-				SMA50AboveSMA200: false,
-
-				// Initialize description fields
-				GoldenDeathCrossDesc:    "",
-				PriceSMA10CrossoverDesc: "",
-				PriceCrossoverDesc:      "",
-				RSIDesc:                 "",
-				StochasticDesc:          "",
-				CMFDesc:                 "",
-				MACDDesc:                "",
-				OBVDesc:                 "",
-				PSARDesc:                "",
-				ATRDesc:                 "",
-			},
-			// Initialize indicator fields with zero values
-			RSI9:         decimal.Zero,
-			RSI25:        decimal.Zero,
-			CMF20:        decimal.Zero,
-			OBVSMADiff:   decimal.Zero,
-			OBVRoC:       decimal.Zero,
-			PSAR1:        decimal.Zero,
-			RollingStd10: decimal.Zero,
-			RollingStd50: decimal.Zero,
-		}
+	date := slices.Index(header, "Date")
+	if date < 0 {
+		return nil, fmt.Errorf("%s has no Date column", filePath)
 	}
-
-	return stockData, nil
-}
-
-// parsePercentage converts percentage string like "2.15%" to decimal
-func ParsePercentage(percentStr string) decimal.Decimal {
-	if percentStr == "" {
-		return decimal.Zero
+	index := make(map[string]int)
+	for k, name := range header {
+		if slices.Contains(names, name) {
+			index[name] = k
+		}
 	}
 
-	// Remove % symbol
-	if len(percentStr) > 0 && percentStr[len(percentStr)-1] == '%' {
-		percentStr = percentStr[:len(percentStr)-1]
+	out := make(map[string]map[string]float64)
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[string]float64, len(index))
+		for name, k := range index {
+			values[name] = cellNumber(record[k])
+		}
+		out[strings.SplitN(record[date], "T", 2)[0]] = values
 	}
+}
 
-	// Parse as decimal
-	if val, err := decimal.NewFromString(percentStr); err == nil {
-		return val
-	}
+// descriptionColumns are the text columns written by the full calculation
+var descriptionColumns = []string{
+	"Golden_Death_Cross_Desc", "Price_SMA10_Crossover_Desc", "Price_Crossover_Desc", "RSI_Desc", "Stochastic_Desc",
+	"CMF_Desc", "MACD_Desc", "OBV_Desc", "PSAR_Desc", "ATR_Desc", "Bollinger_Desc", "Keltner_Desc", "Donchian_Desc",
+	"ADX_Desc", "Aroon_Desc", "Ichimoku_Desc", "MFI_Desc", "Chaikin_Desc", "VWAP_Desc",
+	"Candlestick_Desc", "Support_Resistance_Desc", "Divergence_Desc",
+}
 
-	return decimal.Zero
+// IndicatorsCalculator handles technical indicator calculations
+type IndicatorsCalculator struct {
+	logger        *common.Logger
+	fullRecompute bool
+	timeframe     Timeframe
 }
 
-// isDataUpToDate checks if the indicators file is up-to-date
-func (ic *IndicatorsCalculator) isDataUpToDate(indicatorsFilePath string, stockData []*StockDataWithIndicators) bool {
-	if _, err := os.Stat(indicatorsFilePath); os.IsNotExist(err) {
-		return false
+// NewIndicatorsCalculator creates a new IndicatorsCalculator instance
+func NewIndicatorsCalculator(logger *common.Logger) *IndicatorsCalculator {
+	return &IndicatorsCalculator{
+		logger:    logger,
+		timeframe: Daily,
 	}
+}
 
-	// Load existing indicators file
-	existingData, err := ic.loadExistingIndicators(indicatorsFilePath)
-	if err != nil || len(existingData) == 0 {
-		return false
-	}
+// SetFullRecompute ignores saved indicator state and recalculates the whole history
+func (ic *IndicatorsCalculator) SetFullRecompute(full bool) {
+	ic.fullRecompute = full
+}
 
-	// Compare last dates
-	lastExistingDate := existingData[len(existingData)-1].Date
-	lastNewDate := stockData[len(stockData)-1].Date
+// SetTimeframe calculates on weekly or monthly bars resampled from the daily raw file;
+// output files get the timeframe suffix, e.g. indicators_<TICKER>_W.csv
+func (ic *IndicatorsCalculator) SetTimeframe(tf Timeframe) {
+	ic.timeframe = tf
+}
 
-	return lastExistingDate.Equal(lastNewDate)
+// CalculateAll calculates the configured indicators for a ticker and saves them with descriptions
+func (ic *IndicatorsCalculator) CalculateAll(ticker string) error {
+	ic.logger.Info("Calculating %s indicators for ticker %s", ic.timeframe.Name(), ticker)
+	return ic.calculate(ticker, fmt.Sprintf("indicators_%s%s.csv", ticker, ic.timeframe.Suffix()), true)
 }
 
-// loadExistingIndicators loads existing indicators from CSV file
-func (ic *IndicatorsCalculator) loadExistingIndicators(filePath string) ([]*StockDataWithIndicators, error) {
-	file, err := os.Open(filePath)
+// calculate computes the indicator specs over raw_<TICKER>.csv and writes them to indicatorsFilePath.
+// When only new bars were appended to the raw file, the saved state is extended over those bars.
+func (ic *IndicatorsCalculator) calculate(ticker, indicatorsFilePath string, descriptions bool) error {
+	specs, err := ConfiguredSpecs()
 	if err != nil {
-		return nil, err
+		ic.logger.Error("Failed to load indicator specs: %v", err)
+		return err
 	}
-	defer file.Close()
 
-	var data []*StockDataWithIndicators
-	if err := gocsv.UnmarshalFile(file, &data); err != nil {
-		return nil, err
+	// Check if the raw data CSV file exists
+	rawFilePath := fmt.Sprintf("raw_%s.csv", ticker)
+	if _, err := os.Stat(rawFilePath); os.IsNotExist(err) {
+		ic.logger.Info("File %s does not exist.", rawFilePath)
+		return fmt.Errorf("raw data file does not exist: %s", rawFilePath)
 	}
 
-	return data, nil
-}
-
-// saveIndicatorsData saves the indicators data to CSV file
-func (ic *IndicatorsCalculator) saveIndicatorsData(stockData []*StockDataWithIndicators, filePath string) error {
-	file, err := os.Create(filePath)
+	// Read the raw data from CSV file
+	frame, err := readRawFrame(rawFilePath)
 	if err != nil {
+		ic.logger.Error("Failed to load stock data: %v", err)
 		return err
 	}
-	defer file.Close()
 
-	return gocsv.MarshalFile(stockData, file)
-}
-
-// calculateSMA calculates Simple Moving Averages and related indicators
-func (ic *IndicatorsCalculator) calculateSMA(stockData []*StockDataWithIndicators) error {
-	if len(stockData) == 0 {
-		return fmt.Errorf("no data provided for SMA calculation")
-	}
-
-	// Calculate SMA10, SMA50, SMA200
-	for i := range stockData {
-		// SMA10
-		if i >= 9 { // Need at least 10 data points
-			sum := decimal.Zero
-			for j := i - 9; j <= i; j++ {
-				sum = sum.Add(stockData[j].Close)
-			}
-			stockData[i].SMA10 = sum.Div(decimal.NewFromInt(10)).Round(2)
+	// Bars with a missing price are cleaned before any indicator sees them; the daily run reports each one
+	policy := common.AppConfig.Indicators.BarCleaning
+	frame, adjustments := CleanBars(frame, policy)
+	if ic.timeframe == Daily {
+		if len(adjustments) > 0 {
+			ic.logger.Info("Cleaned %d bars with missing prices (%s); see %s.", len(adjustments), policy, AdjustmentsFile(ticker))
 		}
-
-		// SMA50
-		if i >= 49 { // Need at least 50 data points
-			sum := decimal.Zero
-			for j := i - 49; j <= i; j++ {
-				sum = sum.Add(stockData[j].Close)
-			}
-			stockData[i].SMA50 = sum.Div(decimal.NewFromInt(50)).Round(2)
-		}
-
-		// SMA200
-		if i >= 199 { // Need at least 200 data points
-			sum := decimal.Zero
-			for j := i - 199; j <= i; j++ {
-				sum = sum.Add(stockData[j].Close)
-			}
-			stockData[i].SMA200 = sum.Div(decimal.NewFromInt(200)).Round(2)
+		if err := SaveAdjustments(adjustments, AdjustmentsFile(ticker)); err != nil {
+			ic.logger.Error("Failed to save bar adjustments: %v", err)
 		}
 	}
 
-	// Calculate crossover signals and other SMA-related indicators
-	for i := 1; i < len(stockData); i++ {
-		current := stockData[i]
-		previous := stockData[i-1]
-
-		// Golden Cross and Death Cross
-		if !current.SMA50.IsZero() && !current.SMA200.IsZero() &&
-			!previous.SMA50.IsZero() && !previous.SMA200.IsZero() {
-
-			// Golden Cross: SMA50 crosses above SMA200
-			if current.SMA50.GreaterThan(current.SMA200) &&
-				previous.SMA50.LessThanOrEqual(previous.SMA200) {
-				current.GoldenCross = true
-			}
-
-			// Death Cross: SMA50 crosses below SMA200
-			if current.SMA50.LessThan(current.SMA200) &&
-				previous.SMA50.GreaterThanOrEqual(previous.SMA200) {
-				current.DeathCross = true
-			}
+	if ic.timeframe != Daily {
+		daily := frame.Len()
+		var partial bool
+		frame, partial = Resample(frame, ic.timeframe, calendar.Default(), time.Now(), common.AppConfig.Indicators.PartialPeriods)
+		ic.logger.Info("Resampled %d daily bars into %d %s bars.", daily, frame.Len(), ic.timeframe.Name())
+		if partial {
+			ic.logger.Info("The last %s bar is a partial period.", ic.timeframe.Name())
 		}
+	}
 
-		// Price and SMA crossovers
-		if !current.SMA10.IsZero() && !previous.SMA10.IsZero() {
-			// Price crosses above SMA10
-			if current.Close.GreaterThan(current.SMA10) &&
-				previous.Close.LessThanOrEqual(previous.SMA10) {
-				current.PriceCrossSMA10Up = true
-			}
+	if frame.Len() == 0 {
+		ic.logger.Error("The DataFrame from raw data is empty.")
+		return fmt.Errorf("no stock data found")
+	}
 
-			// Price crosses below SMA10
-			if current.Close.LessThan(current.SMA10) &&
-				previous.Close.GreaterThanOrEqual(previous.SMA10) {
-				current.PriceCrossSMA10Down = true
-			}
+	// The descriptive run also lists every divergence for the API and the daily report
+	if descriptions {
+		cfg := common.AppConfig.Indicators
+		events := DetectDivergences(frame, cfg.DivergenceLookback, cfg.DivergenceWindow)
+		if err := SaveDivergences(events, DivergenceFile(ticker, ic.timeframe)); err != nil {
+			ic.logger.Error("Failed to save divergences: %v", err)
 		}
+	}
 
-		// Price and SMA50 crossovers
-		if !current.SMA50.IsZero() && !previous.SMA50.IsZero() {
-			// Price crosses above SMA50
-			if current.Close.GreaterThan(current.SMA50) &&
-				previous.Close.LessThanOrEqual(previous.SMA50) {
-				current.PriceCrossSMA50Up = true
-			}
+	header := Columns(specs, descriptions)
+	statePath := StatePath(indicatorsFilePath)
+	lang := ""
+	if descriptions {
+		lang = common.AppConfig.Language
+	}
 
-			// Price crosses below SMA50
-			if current.Close.LessThan(current.SMA50) &&
-				previous.Close.GreaterThanOrEqual(previous.SMA50) {
-				current.PriceCrossSMA50Down = true
-			}
-		}
+	if ic.fullRecompute {
+		ic.logger.Info("Recalculating the full history as requested.")
+	} else if state, reason := ic.resumeState(indicatorsFilePath, statePath, frame, header, specs, lang); state == nil {
+		ic.logger.Info("Recalculating the full history: %s.", reason)
+	} else if state.Rows == frame.Len() {
+		ic.logger.Info("The data is up to date.")
+		return nil
+	} else {
+		return ic.extend(frame, state, specs, header, lang, indicatorsFilePath, statePath)
+	}
 
-		// Calculate SMA slopes
-		if !current.SMA10.IsZero() && !previous.SMA10.IsZero() {
-			current.SMA10Up = current.SMA10.GreaterThan(previous.SMA10)
-		}
-		if !current.SMA50.IsZero() && !previous.SMA50.IsZero() {
-			current.SMA50Up = current.SMA50.GreaterThan(previous.SMA50)
-		}
-		if !current.SMA200.IsZero() && !previous.SMA200.IsZero() {
-			current.SMA200Up = current.SMA200.GreaterThan(previous.SMA200)
-		}
+	ic.logger.Info("Calculating %d technical indicators...", len(specs))
+	steppers, err := ApplySpecs(frame, specs)
+	if err != nil {
+		return fmt.Errorf("failed to calculate indicators: %w", err)
+	}
 
-		// Calculate distances between price and SMAs
-		if !current.SMA10.IsZero() {
-			current.PriceDistanceSMA10 = current.Close.Sub(current.SMA10).Round(2)
-		}
-		if !current.SMA50.IsZero() {
-			current.PriceDistanceSMA50 = current.Close.Sub(current.SMA50).Round(2)
-		}
-		if !current.SMA200.IsZero() {
-			current.PriceDistanceSMA200 = current.Close.Sub(current.SMA200).Round(2)
-		}
+	if descriptions {
+		addDescriptions(frame, specs, lang)
+	}
 
-		// SMA50 above SMA200
-		if !current.SMA50.IsZero() && !current.SMA200.IsZero() {
-			current.SMA50AboveSMA200 = current.SMA50.GreaterThan(current.SMA200)
-		}
+	// Save the updated data to CSV file
+	if err := frame.WriteCSV(indicatorsFilePath); err != nil {
+		return fmt.Errorf("failed to save indicators data: %w", err)
 	}
+	ic.saveState(frame, header, specs, steppers, lang, statePath)
 
+	ic.logger.Info("Data calculation completed and saved to %s.", indicatorsFilePath)
 	return nil
 }
 
-// calculateRSI calculates RSI indicators for periods 9, 14, and 25
-func (ic *IndicatorsCalculator) calculateRSI(stockData []*StockDataWithIndicators) error {
-	periods := []int{9, 14, 25}
+// extend calculates only the bars appended after the saved state and appends them to the indicator file.
+// lang is the language of the descriptions, or "" for a file without them.
+func (ic *IndicatorsCalculator) extend(frame *Frame, state *indicatorState, specs []Spec, header []string,
+	lang, indicatorsFilePath, statePath string) error {
+	newBars := frame.Slice(state.Rows)
+	ic.logger.Info("Extending indicators with %d new bars from saved state...", newBars.Len())
 
-	for _, period := range periods {
-		if len(stockData) < period+1 {
-			continue // Not enough data for this RSI period
-		}
+	steppers, err := ExtendSpecs(newBars, specs, state.Steppers)
+	if err != nil {
+		return fmt.Errorf("failed to calculate indicators: %w", err)
+	}
 
-		// Calculate RSI for this period
-		for i := period; i < len(stockData); i++ {
-			gains := decimal.Zero
-			losses := decimal.Zero
-
-			// Calculate average gains and losses over the period
-			for j := i - period + 1; j <= i; j++ {
-				if j > 0 {
-					change := stockData[j].Close.Sub(stockData[j-1].Close)
-					if change.GreaterThan(decimal.Zero) {
-						gains = gains.Add(change)
-					} else {
-						losses = losses.Add(change.Abs())
-					}
-				}
-			}
+	if lang != "" {
+		addDescriptions(newBars, specs, lang)
+	}
 
-			avgGain := gains.Div(decimal.NewFromInt(int64(period)))
-			avgLoss := losses.Div(decimal.NewFromInt(int64(period)))
-
-			if !avgLoss.IsZero() {
-				rs := avgGain.Div(avgLoss)
-				rsi := decimal.NewFromInt(100).Sub(decimal.NewFromInt(100).Div(decimal.NewFromInt(1).Add(rs)))
-
-				// Assign to appropriate field
-				switch period {
-				case 9:
-					stockData[i].RSI9 = rsi.Round(2)
-				case 14:
-					stockData[i].RSI14 = rsi.Round(2)
-				case 25:
-					stockData[i].RSI25 = rsi.Round(2)
-				}
-			}
-		}
+	if err := newBars.AppendCSV(indicatorsFilePath); err != nil {
+		return fmt.Errorf("failed to save indicators data: %w", err)
 	}
+	ic.saveState(frame, header, specs, steppers, lang, statePath)
 
+	ic.logger.Info("Data calculation completed and appended to %s.", indicatorsFilePath)
 	return nil
 }
 
-// calculateBasicIndicators calculates basic indicators without descriptions (for calculate_num mode)
-func (ic *IndicatorsCalculator) calculateBasicIndicators(stockData []*StockDataWithIndicators) error {
-	// Calculate SMA
-	if err := ic.calculateSMA(stockData); err != nil {
-		return err
+// resumeState returns the saved state when the indicator file can be extended, or why it cannot
+func (ic *IndicatorsCalculator) resumeState(indicatorsFilePath, statePath string, frame *Frame,
+	header []string, specs []Spec, lang string) (*indicatorState, string) {
+	existing, err := readCSVSummary(indicatorsFilePath)
+	if err != nil {
+		return nil, indicatorsFilePath + " is missing or unreadable"
 	}
 
-	// Calculate RSI
-	if err := ic.calculateRSI(stockData); err != nil {
-		return err
+	// A changed spec file changes the header
+	if !slices.Equal(existing.Header, header) {
+		return nil, "indicator columns changed"
 	}
 
-	// Calculate basic MACD
-	if err := ic.calculateMACD(stockData); err != nil {
-		return err
+	state, err := loadIndicatorState(statePath)
+	if err != nil || state.Version != stateVersion || !slices.Equal(state.Columns, header) {
+		return nil, "no saved indicator state"
+	}
+	if state.Rows != existing.Rows || !state.LastDate.Equal(existing.LastDate) {
+		return nil, "saved indicator state does not match " + indicatorsFilePath
 	}
 
-	// Calculate basic EMAs
-	if err := ic.calculateEMAs(stockData); err != nil {
-		return err
+	// Descriptions already written stay in their language, so a new one rewrites the file
+	if lang != "" && cmp.Or(state.Language, i18n.English) != lang {
+		return nil, "description language changed"
 	}
 
-	// Calculate other basic indicators
-	if err := ic.calculateStochasticOscillator(stockData); err != nil {
-		return err
+	// Any edit to rows already calculated invalidates every later value
+	if frame.Len() < state.Rows || fingerprint(frame, state.Rows) != state.Fingerprint {
+		return nil, "historic rows changed"
 	}
 
-	if err := ic.calculateCMF(stockData); err != nil {
-		return err
+	// An edited custom formula keeps its column but not its state, which is saved under the formula
+	for _, spec := range specs {
+		if _, ok := state.Steppers[spec.String()]; !ok {
+			return nil, fmt.Sprintf("no saved state for %s", spec)
+		}
+		if state.Rows < spec.MinBars() {
+			return nil, fmt.Sprintf("history shorter than the %d bars %s needs", spec.MinBars(), spec)
+		}
 	}
+	return state, ""
+}
 
-	if err := ic.calculateOBV(stockData); err != nil {
-		return err
+// saveState records the stepper states; without them the next run simply recalculates everything
+func (ic *IndicatorsCalculator) saveState(frame *Frame, header []string, specs []Spec, steppers []Stepper, lang, statePath string) {
+	state, err := newIndicatorState(frame, frame.Len(), header, specs, steppers)
+	if err == nil {
+		state.Language = lang
+		err = state.save(statePath)
 	}
+	if err != nil {
+		ic.logger.Warn("Failed to save indicator state %s: %v", statePath, err)
+		os.Remove(statePath)
+	}
+}
 
-	if err := ic.calculatePSAR(stockData); err != nil {
-		return err
+// parsePercentage converts percentage string like "2.15%" to decimal
+func ParsePercentage(percentStr string) decimal.Decimal {
+	if percentStr == "" {
+		return decimal.Zero
 	}
 
-	if err := ic.calculateATR(stockData); err != nil {
-		return err
+	// Remove % symbol
+	if len(percentStr) > 0 && percentStr[len(percentStr)-1] == '%' {
+		percentStr = percentStr[:len(percentStr)-1]
 	}
 
-	if err := ic.calculateRollingStd(stockData); err != nil {
-		return err
+	// Parse as decimal
+	if val, err := decimal.NewFromString(percentStr); err == nil {
+		return val
 	}
 
-	return nil
+	return decimal.Zero
 }
 
-// calculateEMAs calculates Exponential Moving Averages
-func (ic *IndicatorsCalculator) calculateEMAs(stockData []*StockDataWithIndicators) error {
-	if len(stockData) == 0 {
-		return nil
+// Describe rebuilds the descriptions of one row of an indicator file in lang, keyed by column.
+// Only the row itself is needed, so the API can answer in any language without recalculating.
+func Describe(header, record []string, specs []Spec, lang string) map[string]string {
+	f := NewFrame(1)
+	f.Dates = append(f.Dates, time.Time{})
+	for _, series := range []*[]float64{&f.Open, &f.High, &f.Low, &f.Close, &f.Change, &f.ChangePercent} {
+		*series = append(*series, 0)
 	}
+	f.Volume, f.Trades = append(f.Volume, 0), append(f.Trades, 0)
 
-	// Calculate EMA5, EMA10, EMA20, EMA50, EMA200
-	periods := []int{5, 10, 20, 50, 200}
-
-	for _, period := range periods {
-		if len(stockData) < period {
+	prices := map[string][]float64{"Open": f.Open, "High": f.High, "Low": f.Low, "Close": f.Close}
+	for k, name := range header[:min(len(header), len(record))] {
+		value := record[k]
+		if values, ok := prices[name]; ok {
+			values[0], _ = strconv.ParseFloat(value, 64)
+		}
+		if slices.Contains(baseColumns, name) || slices.Contains(descriptionColumns, name) {
 			continue
 		}
-
-		multiplier := decimal.NewFromFloat(2.0).Div(decimal.NewFromInt(int64(period + 1)))
-
-		// Initialize first EMA value with first close price
-		var emaValues []decimal.Decimal
-		emaValues = append(emaValues, stockData[0].Close)
-
-		for i := 1; i < len(stockData); i++ {
-			ema := stockData[i].Close.Mul(multiplier).Add(emaValues[i-1].Mul(decimal.NewFromInt(1).Sub(multiplier)))
-			emaValues = append(emaValues, ema)
-
-			// Assign to appropriate field
-			switch period {
-			case 5:
-				stockData[i].EMA5 = ema.Round(2)
-			case 10:
-				stockData[i].EMA10 = ema.Round(2)
-			case 20:
-				stockData[i].EMA20 = ema.Round(2)
-			case 50:
-				stockData[i].EMA50 = ema.Round(2)
-			case 200:
-				stockData[i].EMA200 = ema.Round(2)
-			}
+		if value == "true" || value == "false" {
+			f.AddFlag(name)[0] = value == "true"
+		} else if number, err := strconv.ParseFloat(value, 64); err == nil {
+			f.AddNumber(name)[0] = number
+		} else {
+			f.AddText(name)[0] = value
 		}
 	}
 
-	return nil
-}
-
-// calculateMACD calculates MACD indicator
-func (ic *IndicatorsCalculator) calculateMACD(stockData []*StockDataWithIndicators) error {
-	if len(stockData) < 26 {
-		return nil // Not enough data for MACD
-	}
-
-	// Calculate EMA12 and EMA26 for MACD
-	ema12 := make([]decimal.Decimal, len(stockData))
-	ema26 := make([]decimal.Decimal, len(stockData))
-
-	// Initialize first EMA values
-	ema12[0] = stockData[0].Close
-	ema26[0] = stockData[0].Close
-
-	multiplier12 := decimal.NewFromFloat(2.0).Div(decimal.NewFromInt(13)) // 2/(12+1)
-	multiplier26 := decimal.NewFromFloat(2.0).Div(decimal.NewFromInt(27)) // 2/(26+1)
-
-	for i := 1; i < len(stockData); i++ {
-		// EMA12
-		ema12[i] = stockData[i].Close.Mul(multiplier12).Add(ema12[i-1].Mul(decimal.NewFromInt(1).Sub(multiplier12)))
-
-		// EMA26
-		ema26[i] = stockData[i].Close.Mul(multiplier26).Add(ema26[i-1].Mul(decimal.NewFromInt(1).Sub(multiplier26)))
-
-		// MACD Line = EMA12 - EMA26
-		if i >= 25 { // Start calculating MACD after we have enough data for EMA26
-			stockData[i].MACD = ema12[i].Sub(ema26[i]).Round(4)
+	// Specs added since the file was calculated have no columns to describe
+	var present []Spec
+	for _, spec := range specs {
+		if !slices.ContainsFunc(spec.Columns(), func(c string) bool { return f.Column(c) == nil }) {
+			present = append(present, spec)
 		}
 	}
 
-	// Calculate MACD Signal Line (EMA9 of MACD)
-	if len(stockData) >= 34 { // Need at least 34 periods for signal line
-		macdSignal := make([]decimal.Decimal, len(stockData))
-		multiplier9 := decimal.NewFromFloat(2.0).Div(decimal.NewFromInt(10)) // 2/(9+1)
-
-		// Find first non-zero MACD value for initialization
-		firstMACDIndex := 25
-		macdSignal[firstMACDIndex] = stockData[firstMACDIndex].MACD
-
-		for i := firstMACDIndex + 1; i < len(stockData); i++ {
-			if !stockData[i].MACD.IsZero() {
-				macdSignal[i] = stockData[i].MACD.Mul(multiplier9).Add(macdSignal[i-1].Mul(decimal.NewFromInt(1).Sub(multiplier9)))
-				stockData[i].MACDSignal = macdSignal[i].Round(4)
-
-				// MACD Histogram = MACD - Signal
-				stockData[i].MACDHist = stockData[i].MACD.Sub(stockData[i].MACDSignal).Round(4)
-			}
+	addDescriptions(f, present, lang)
+	out := make(map[string]string, len(descriptionColumns))
+	for _, name := range descriptionColumns {
+		if c := f.Column(name); c != nil {
+			out[name] = c.Text[0]
 		}
 	}
-
-	return nil
+	return out
 }
 
-// Placeholder implementations for remaining indicators
-func (ic *IndicatorsCalculator) calculateStochasticOscillator(stockData []*StockDataWithIndicators) error {
-	// Basic Stochastic Oscillator implementation
-	period := 9
-	if len(stockData) < period {
+// addDescriptions adds descriptive text for indicators (for full mode) in lang.
+// Each description reads the first configured instance of its indicator and stays empty without one.
+func addDescriptions(f *Frame, specs []Spec, lang string) {
+	msg := func(key string, args ...interface{}) string { return i18n.T(lang, key, args...) }
+	also := func(names []string) string {
+		return msg("desc.also", strings.Join(names, msg("desc.list_separator")))
+	}
+
+	goldenDeathDesc := f.AddText("Golden_Death_Cross_Desc")
+	sma10Desc := f.AddText("Price_SMA10_Crossover_Desc")
+	crossoverDesc := f.AddText("Price_Crossover_Desc")
+	rsiDesc := f.AddText("RSI_Desc")
+	stochDesc := f.AddText("Stochastic_Desc")
+	cmfDesc := f.AddText("CMF_Desc")
+	macdDesc := f.AddText("MACD_Desc")
+	obvDesc := f.AddText("OBV_Desc")
+	psarDesc := f.AddText("PSAR_Desc")
+	atrDesc := f.AddText("ATR_Desc")
+	bollingerDesc := f.AddText("Bollinger_Desc")
+	keltnerDesc := f.AddText("Keltner_Desc")
+	donchianDesc := f.AddText("Donchian_Desc")
+	adxDesc := f.AddText("ADX_Desc")
+	aroonDesc := f.AddText("Aroon_Desc")
+	ichimokuDesc := f.AddText("Ichimoku_Desc")
+	mfiDesc := f.AddText("MFI_Desc")
+	chaikinDesc := f.AddText("Chaikin_Desc")
+	vwapDesc := f.AddText("VWAP_Desc")
+	candleDesc := f.AddText("Candlestick_Desc")
+	levelsDesc := f.AddText("Support_Resistance_Desc")
+	divergenceDesc := f.AddText("Divergence_Desc")
+
+	columns := func(name string) []string {
+		if spec, ok := firstSpec(specs, name); ok {
+			return spec.Columns()
+		}
 		return nil
 	}
 
-	for i := period - 1; i < len(stockData); i++ {
-		// Find highest high and lowest low in the period
-		highestHigh := stockData[i-period+1].High
-		lowestLow := stockData[i-period+1].Low
-
-		for j := i - period + 2; j <= i; j++ {
-			if stockData[j].High.GreaterThan(highestHigh) {
-				highestHigh = stockData[j].High
-			}
-			if stockData[j].Low.LessThan(lowestLow) {
-				lowestLow = stockData[j].Low
+	// Golden/Death Cross descriptions
+	if cols := columns("CROSS"); cols != nil {
+		golden, death := f.Flag(cols[0]), f.Flag(cols[1])
+		for i := range goldenDeathDesc {
+			if golden[i] {
+				goldenDeathDesc[i] = msg("desc.cross.golden")
+			} else if death[i] {
+				goldenDeathDesc[i] = msg("desc.cross.death")
+			} else {
+				goldenDeathDesc[i] = msg("desc.cross.none")
 			}
 		}
-
-		// Calculate %K
-		if !highestHigh.Equal(lowestLow) {
-			k := stockData[i].Close.Sub(lowestLow).Div(highestHigh.Sub(lowestLow)).Mul(decimal.NewFromInt(100))
-			stockData[i].StochK = k.Round(2)
-		}
 	}
 
-	// Calculate %D (3-period SMA of %K)
-	for i := period + 1; i < len(stockData); i++ {
-		if i >= period+2 { // Need at least 3 %K values
-			sum := stockData[i].StochK.Add(stockData[i-1].StochK).Add(stockData[i-2].StochK)
-			stockData[i].StochD = sum.Div(decimal.NewFromInt(3)).Round(2)
+	// Price SMA10 crossover descriptions
+	if up, down := f.Flag("Price_Cross_SMA10_Up"), f.Flag("Price_Cross_SMA10_Down"); up != nil && down != nil {
+		for i := range sma10Desc {
+			if up[i] {
+				sma10Desc[i] = msg("desc.sma10.up")
+			} else if down[i] {
+				sma10Desc[i] = msg("desc.sma10.down")
+			} else {
+				sma10Desc[i] = msg("desc.sma10.none")
+			}
 		}
 	}
 
-	return nil
-}
-
-func (ic *IndicatorsCalculator) calculateCMF(stockData []*StockDataWithIndicators) error {
-	// Chaikin Money Flow implementation
-	period := 20
-	if len(stockData) < period {
-		return nil
+	// Price crossover descriptions (SMA50 and SMA200)
+	var majorUp, majorDown [][]bool
+	for _, period := range []string{"50", "200"} {
+		if up := f.Flag("Price_Cross_SMA" + period + "_Up"); up != nil {
+			majorUp = append(majorUp, up)
+			majorDown = append(majorDown, f.Flag("Price_Cross_SMA"+period+"_Down"))
+		}
 	}
-
-	for i := period - 1; i < len(stockData); i++ {
-		var sumMFV, sumVolume decimal.Decimal
-
-		for j := i - period + 1; j <= i; j++ {
-			// Money Flow Multiplier = ((Close - Low) - (High - Close)) / (High - Low)
-			if !stockData[j].High.Equal(stockData[j].Low) {
-				mfm := stockData[j].Close.Sub(stockData[j].Low).Sub(stockData[j].High.Sub(stockData[j].Close)).Div(stockData[j].High.Sub(stockData[j].Low))
-				mfv := mfm.Mul(decimal.NewFromInt(stockData[j].Volume))
-				sumMFV = sumMFV.Add(mfv)
+	if len(majorUp) > 0 {
+		anyAt := func(flags [][]bool, i int) bool {
+			for _, values := range flags {
+				if values[i] {
+					return true
+				}
 			}
-			sumVolume = sumVolume.Add(decimal.NewFromInt(stockData[j].Volume))
+			return false
 		}
-
-		if !sumVolume.IsZero() {
-			stockData[i].CMF20 = sumMFV.Div(sumVolume).Round(4)
+		for i := range crossoverDesc {
+			if anyAt(majorUp, i) {
+				crossoverDesc[i] = msg("desc.major_sma.up")
+			} else if anyAt(majorDown, i) {
+				crossoverDesc[i] = msg("desc.major_sma.down")
+			} else {
+				crossoverDesc[i] = msg("desc.major_sma.none")
+			}
 		}
 	}
 
-	return nil
-}
-
-func (ic *IndicatorsCalculator) calculateOBV(stockData []*StockDataWithIndicators) error {
-	// On-Balance Volume implementation
-	if len(stockData) == 0 {
-		return nil
-	}
-
-	stockData[0].OBV = decimal.NewFromInt(stockData[0].Volume)
-
-	for i := 1; i < len(stockData); i++ {
-		if stockData[i].Close.GreaterThan(stockData[i-1].Close) {
-			stockData[i].OBV = stockData[i-1].OBV.Add(decimal.NewFromInt(stockData[i].Volume))
-		} else if stockData[i].Close.LessThan(stockData[i-1].Close) {
-			stockData[i].OBV = stockData[i-1].OBV.Sub(decimal.NewFromInt(stockData[i].Volume))
-		} else {
-			stockData[i].OBV = stockData[i-1].OBV
+	// RSI descriptions
+	if cols := columns("RSI"); cols != nil {
+		for i, rsi := range f.Number(cols[0]) {
+			if rsi == 0 {
+				continue
+			}
+			if rsi > 70 {
+				rsiDesc[i] = msg("desc.rsi.overbought")
+			} else if rsi < 30 {
+				rsiDesc[i] = msg("desc.rsi.oversold")
+			} else if rsi > 50 {
+				rsiDesc[i] = msg("desc.rsi.above_mid")
+			} else {
+				rsiDesc[i] = msg("desc.rsi.below_mid")
+			}
 		}
 	}
 
-	// Calculate OBV Rate of Change
-	for i := 10; i < len(stockData); i++ {
-		if !stockData[i-10].OBV.IsZero() {
-			roc := stockData[i].OBV.Sub(stockData[i-10].OBV).Div(stockData[i-10].OBV.Abs()).Mul(decimal.NewFromInt(100))
-			stockData[i].OBVRoC = roc.Round(2)
+	// Stochastic descriptions
+	if cols := columns("STOCH"); cols != nil {
+		stochK, stochD := f.Number(cols[0]), f.Number(cols[1])
+		for i := range stochDesc {
+			if stochK[i] == 0 || stochD[i] == 0 {
+				continue
+			}
+			if stochK[i] > 80 && stochD[i] > 80 {
+				stochDesc[i] = msg("desc.stoch.overbought")
+			} else if stochK[i] < 20 && stochD[i] < 20 {
+				stochDesc[i] = msg("desc.stoch.oversold")
+			} else if stochK[i] > stochD[i] {
+				stochDesc[i] = msg("desc.stoch.bullish_cross")
+			} else {
+				stochDesc[i] = msg("desc.stoch.bearish_cross")
+			}
 		}
 	}
 
-	return nil
-}
-
-func (ic *IndicatorsCalculator) calculatePSAR(stockData []*StockDataWithIndicators) error {
-	// Parabolic SAR implementation (basic version)
-	if len(stockData) < 2 {
-		return nil
-	}
-
-	// PSAR with acceleration 0.02, maximum 0.2
-	acceleration := decimal.NewFromFloat(0.02)
-	maxAcceleration := decimal.NewFromFloat(0.2)
-
-	// Initialize
-	stockData[0].PSAR1 = stockData[0].Low
-	isUptrend := true
-	af := acceleration
-	ep := stockData[0].High // Extreme Point
-
-	for i := 1; i < len(stockData); i++ {
-		// Calculate PSAR
-		psar := stockData[i-1].PSAR1.Add(af.Mul(ep.Sub(stockData[i-1].PSAR1)))
-
-		if isUptrend {
-			if stockData[i].Low.LessThan(psar) {
-				// Trend reversal
-				isUptrend = false
-				psar = ep
-				ep = stockData[i].Low
-				af = acceleration
-			} else {
-				if stockData[i].High.GreaterThan(ep) {
-					ep = stockData[i].High
-					af = af.Add(acceleration)
-					if af.GreaterThan(maxAcceleration) {
-						af = maxAcceleration
-					}
-				}
+	// CMF descriptions
+	if cols := columns("CMF"); cols != nil {
+		for i, cmf := range f.Number(cols[0]) {
+			if cmf == 0 {
+				continue
 			}
-		} else {
-			if stockData[i].High.GreaterThan(psar) {
-				// Trend reversal
-				isUptrend = true
-				psar = ep
-				ep = stockData[i].High
-				af = acceleration
+			if cmf > 0.1 {
+				cmfDesc[i] = msg("desc.cmf.strong")
+			} else if cmf < -0.1 {
+				cmfDesc[i] = msg("desc.cmf.weak")
+			} else if cmf > 0 {
+				cmfDesc[i] = msg("desc.cmf.mild_accumulation")
 			} else {
-				if stockData[i].Low.LessThan(ep) {
-					ep = stockData[i].Low
-					af = af.Add(acceleration)
-					if af.GreaterThan(maxAcceleration) {
-						af = maxAcceleration
-					}
-				}
+				cmfDesc[i] = msg("desc.cmf.mild_distribution")
 			}
 		}
-
-		stockData[i].PSAR1 = psar.Round(4)
 	}
 
-	// Calculate PSAR2 with different parameters (0.01, 0.1)
-	acceleration2 := decimal.NewFromFloat(0.01)
-	maxAcceleration2 := decimal.NewFromFloat(0.1)
-
-	stockData[0].PSAR2 = stockData[0].Low
-	isUptrend2 := true
-	af2 := acceleration2
-	ep2 := stockData[0].High
-
-	for i := 1; i < len(stockData); i++ {
-		psar := stockData[i-1].PSAR2.Add(af2.Mul(ep2.Sub(stockData[i-1].PSAR2)))
-
-		if isUptrend2 {
-			if stockData[i].Low.LessThan(psar) {
-				isUptrend2 = false
-				psar = ep2
-				ep2 = stockData[i].Low
-				af2 = acceleration2
-			} else {
-				if stockData[i].High.GreaterThan(ep2) {
-					ep2 = stockData[i].High
-					af2 = af2.Add(acceleration2)
-					if af2.GreaterThan(maxAcceleration2) {
-						af2 = maxAcceleration2
-					}
-				}
+	// MACD descriptions
+	if cols := columns("MACD"); cols != nil {
+		macd, signal, histogram := f.Number(cols[0]), f.Number(cols[1]), f.Number(cols[2])
+		for i := range macdDesc {
+			if macd[i] == 0 || signal[i] == 0 {
+				continue
 			}
-		} else {
-			if stockData[i].High.GreaterThan(psar) {
-				isUptrend2 = true
-				psar = ep2
-				ep2 = stockData[i].High
-				af2 = acceleration2
+			if macd[i] > signal[i] && histogram[i] > 0 {
+				macdDesc[i] = msg("desc.macd.bullish")
+			} else if macd[i] < signal[i] && histogram[i] < 0 {
+				macdDesc[i] = msg("desc.macd.bearish")
+			} else if macd[i] > signal[i] {
+				macdDesc[i] = msg("desc.macd.above_signal")
 			} else {
-				if stockData[i].Low.LessThan(ep2) {
-					ep2 = stockData[i].Low
-					af2 = af2.Add(acceleration2)
-					if af2.GreaterThan(maxAcceleration2) {
-						af2 = maxAcceleration2
-					}
-				}
+				macdDesc[i] = msg("desc.macd.below_signal")
 			}
 		}
-
-		stockData[i].PSAR2 = psar.Round(4)
 	}
 
-	return nil
-}
-
-func (ic *IndicatorsCalculator) calculateATR(stockData []*StockDataWithIndicators) error {
-	// Average True Range implementation
-	period := 14
-	if len(stockData) < period {
-		return nil
-	}
-
-	// Calculate True Range for each period
-	trueRanges := make([]decimal.Decimal, len(stockData))
-
-	for i := 1; i < len(stockData); i++ {
-		// TR = max(High - Low, |High - PrevClose|, |Low - PrevClose|)
-		hl := stockData[i].High.Sub(stockData[i].Low)
-		hpc := stockData[i].High.Sub(stockData[i-1].Close).Abs()
-		lpc := stockData[i].Low.Sub(stockData[i-1].Close).Abs()
-
-		tr := hl
-		if hpc.GreaterThan(tr) {
-			tr = hpc
-		}
-		if lpc.GreaterThan(tr) {
-			tr = lpc
+	// OBV descriptions
+	if cols := columns("OBV"); cols != nil {
+		obv, obvRoc := f.Number(cols[0]), f.Number(cols[1])
+		for i := range obvDesc {
+			if obv[i] == 0 {
+				continue
+			}
+			if obvRoc[i] > 10 {
+				obvDesc[i] = msg("desc.obv.accumulation")
+			} else if obvRoc[i] < -10 {
+				obvDesc[i] = msg("desc.obv.distribution")
+			} else if obvRoc[i] > 0 {
+				obvDesc[i] = msg("desc.obv.mild_accumulation")
+			} else {
+				obvDesc[i] = msg("desc.obv.mild_distribution")
+			}
 		}
-
-		trueRanges[i] = tr
 	}
 
-	// Calculate ATR (Simple Moving Average of True Range)
-	for i := period; i < len(stockData); i++ {
-		sum := decimal.Zero
-		for j := i - period + 1; j <= i; j++ {
-			sum = sum.Add(trueRanges[j])
+	// PSAR descriptions
+	if cols := columns("PSAR"); cols != nil {
+		for i, psar := range f.Number(cols[0]) {
+			if psar == 0 {
+				continue
+			}
+			if f.Close[i] > psar {
+				psarDesc[i] = msg("desc.psar.bullish")
+			} else {
+				psarDesc[i] = msg("desc.psar.bearish")
+			}
 		}
-		stockData[i].ATR = sum.Div(decimal.NewFromInt(int64(period))).Round(4)
 	}
 
-	return nil
-}
-
-func (ic *IndicatorsCalculator) calculateRollingStd(stockData []*StockDataWithIndicators) error {
-	// Rolling Standard Deviation implementation
-	periods := []int{10, 50}
-
-	for _, period := range periods {
-		if len(stockData) < period {
-			continue
-		}
-
-		for i := period - 1; i < len(stockData); i++ {
-			// Calculate mean
-			sum := decimal.Zero
-			for j := i - period + 1; j <= i; j++ {
-				sum = sum.Add(stockData[j].Close)
+	// ATR descriptions; ATR is used for volatility assessment rather than directional signals
+	if cols := columns("ATR"); cols != nil {
+		for i, atr := range f.Number(cols[0]) {
+			if atr == 0 || f.Close[i] == 0 {
+				continue
 			}
-			mean := sum.Div(decimal.NewFromInt(int64(period)))
-
-			// Calculate variance
-			variance := decimal.Zero
-			for j := i - period + 1; j <= i; j++ {
-				diff := stockData[j].Close.Sub(mean)
-				variance = variance.Add(diff.Mul(diff))
-			}
-			variance = variance.Div(decimal.NewFromInt(int64(period)))
-
-			// Standard deviation is square root of variance
-			// Using approximation for decimal square root
-			std := ic.sqrt(variance)
-
-			switch period {
-			case 10:
-				stockData[i].RollingStd10 = std.Round(4)
-			case 50:
-				stockData[i].RollingStd50 = std.Round(4)
+			pricePercent := numeric.Round(atr/f.Close[i]*100, 8)
+			if pricePercent > 5 {
+				atrDesc[i] = msg("desc.atr.high")
+			} else if pricePercent > 2 {
+				atrDesc[i] = msg("desc.atr.moderate")
+			} else {
+				atrDesc[i] = msg("desc.atr.low")
 			}
 		}
 	}
 
-	return nil
-}
-
-// sqrt calculates square root using Newton's method for decimal
-func (ic *IndicatorsCalculator) sqrt(x decimal.Decimal) decimal.Decimal {
-	if x.LessThanOrEqual(decimal.Zero) {
-		return decimal.Zero
-	}
-
-	// Newton's method for square root
-	guess := x.Div(decimal.NewFromInt(2))
-	for i := 0; i < 10; i++ { // 10 iterations should be enough for precision
-		newGuess := guess.Add(x.Div(guess)).Div(decimal.NewFromInt(2))
-		if newGuess.Sub(guess).Abs().LessThan(decimal.NewFromFloat(0.0001)) {
-			break
+	// Bollinger descriptions from %B
+	var bbLower, bbMiddle, bbUpper []float64
+	if cols := columns("BBANDS"); cols != nil {
+		bbLower, bbMiddle, bbUpper = f.Number(cols[0]), f.Number(cols[1]), f.Number(cols[2])
+		for i, percent := range f.Number(cols[4]) {
+			if bbMiddle[i] == 0 {
+				continue
+			}
+			if percent > 1 {
+				bollingerDesc[i] = msg("desc.bollinger.above")
+			} else if percent < 0 {
+				bollingerDesc[i] = msg("desc.bollinger.below")
+			} else if percent > 0.5 {
+				bollingerDesc[i] = msg("desc.bollinger.upper_half")
+			} else {
+				bollingerDesc[i] = msg("desc.bollinger.lower_half")
+			}
 		}
-		guess = newGuess
 	}
 
-	return guess
-}
-
-// addDescriptions adds descriptive text for indicators (for full mode)
-func (ic *IndicatorsCalculator) addDescriptions(stockData []*StockDataWithIndicators) {
-	for _, data := range stockData {
-		// Golden/Death Cross descriptions
-		if data.GoldenCross {
-			data.GoldenDeathCrossDesc = "Buy - Golden Cross Detected: The shorter-term SMA50 has crossed above the longer-term SMA200; a bullish signal. Historically this pattern indicates the early stages of a prolonged bull market. Interpretation: The asset's price might rise; suggesting a favorable time to buy or add to your position."
-		} else if data.DeathCross {
-			data.GoldenDeathCrossDesc = "Sell - Death Cross Detected: The shorter-term SMA50 has crossed below the longer-term SMA200; a bearish signal. This pattern often precedes a forthcoming bear market or a prolonged period of selling. Interpretation: Exercise caution; consider reducing exposure or hedge against losses."
-		} else {
-			data.GoldenDeathCrossDesc = "Neutral - No Significant Cross Detected: The market shows no clear bullish or bearish signals at the moment. This can indicate a period of consolidation or sideways movement. Interpretation: Monitor other indicators; stay updated with market news and maintain a diversified strategy."
+	// Keltner descriptions; Bollinger bands inside the channel mark a squeeze
+	if cols := columns("KC"); cols != nil {
+		lower, upper := f.Number(cols[0]), f.Number(cols[2])
+		for i := range keltnerDesc {
+			if lower[i] == 0 || upper[i] == 0 {
+				continue
+			}
+			if bbMiddle != nil && bbMiddle[i] != 0 && bbLower[i] > lower[i] && bbUpper[i] < upper[i] {
+				keltnerDesc[i] = msg("desc.keltner.squeeze")
+			} else if f.Close[i] > upper[i] {
+				keltnerDesc[i] = msg("desc.keltner.above")
+			} else if f.Close[i] < lower[i] {
+				keltnerDesc[i] = msg("desc.keltner.below")
+			} else {
+				keltnerDesc[i] = msg("desc.keltner.inside")
+			}
 		}
+	}
 
-		// Price SMA10 crossover descriptions
-		if data.PriceCrossSMA10Up {
-			data.PriceSMA10CrossoverDesc = "Buy - Price Crossed Above SMA10: The asset's price has surged above its 10-period average. This upward crossover is historically a sign of short-term bullish momentum. Interpretation: It might be an opportunity to capitalize on the momentum; but also consider other indicators for confirmation."
-		} else if data.PriceCrossSMA10Down {
-			data.PriceSMA10CrossoverDesc = "Sell - Price Crossed Below SMA10: The asset's price is dipping below its recent 10-period average. This can hint at a short-term decline or a potential pullback. Interpretation: It might be wise to exercise caution; adjust strategies or set stop losses."
-		} else {
-			data.PriceSMA10CrossoverDesc = "Neutral - Price Oscillating Around SMA10: The asset's price is weaving around its 10-period average; indicating market indecision. This pattern could be a sign of consolidation. Interpretation: Stay alert; monitor other indicators and be ready for a potential breakout."
+	// Donchian descriptions; a bar touching a channel edge set a new high or low for the period
+	if cols := columns("DONCHIAN"); cols != nil {
+		lower, middle, upper := f.Number(cols[0]), f.Number(cols[1]), f.Number(cols[2])
+		for i := range donchianDesc {
+			if upper[i] == 0 {
+				continue
+			}
+			if f.High[i] >= upper[i] && f.Close[i] > middle[i] {
+				donchianDesc[i] = msg("desc.donchian.breakout")
+			} else if f.Low[i] <= lower[i] && f.Close[i] < middle[i] {
+				donchianDesc[i] = msg("desc.donchian.breakdown")
+			} else if f.Close[i] > middle[i] {
+				donchianDesc[i] = msg("desc.donchian.upper")
+			} else {
+				donchianDesc[i] = msg("desc.donchian.lower")
+			}
 		}
+	}
 
-		// Price crossover descriptions (SMA50 and SMA200)
-		if data.PriceCrossSMA50Up || data.PriceCrossSMA200Up {
-			data.PriceCrossoverDesc = "Buy; Price Crossed Above Major SMA: The asset's price has crossed above a significant moving average - indicating strong bullish momentum. This suggests institutional interest and potential trend continuation. Interpretation: Consider entering long positions with proper risk management."
-		} else if data.PriceCrossSMA50Down || data.PriceCrossSMA200Down {
-			data.PriceCrossoverDesc = "Sell; Price Crossed Below Major SMA: The asset's price has fallen below a key moving average - signaling potential weakness. This could indicate the start of a downtrend. Interpretation: Consider reducing positions or implementing protective stops."
-		} else {
-			data.PriceCrossoverDesc = "Neutral; Price Respecting SMA Levels: The asset's price is trading in line with major moving averages - showing balanced market conditions. Interpretation: Wait for clearer directional signals before making significant position changes."
+	// ADX descriptions; ADX measures trend strength and the DI lines its direction
+	if cols := columns("ADX"); cols != nil {
+		plusDI, minusDI := f.Number(cols[1]), f.Number(cols[2])
+		for i, adx := range f.Number(cols[0]) {
+			if adx == 0 {
+				continue
+			}
+			if adx > 25 && plusDI[i] > minusDI[i] {
+				adxDesc[i] = msg("desc.adx.uptrend")
+			} else if adx > 25 {
+				adxDesc[i] = msg("desc.adx.downtrend")
+			} else if adx < 20 {
+				adxDesc[i] = msg("desc.adx.no_trend")
+			} else if plusDI[i] > minusDI[i] {
+				adxDesc[i] = msg("desc.adx.developing_up")
+			} else {
+				adxDesc[i] = msg("desc.adx.developing_down")
+			}
 		}
+	}
 
-		// RSI descriptions
-		if !data.RSI14.IsZero() {
-			rsi := data.RSI14
-			if rsi.GreaterThan(decimal.NewFromInt(70)) {
-				data.RSIDesc = "Sell - RSI Overbought: RSI is above 70; indicating the asset may be overbought. This suggests selling pressure could emerge soon. Interpretation: Consider taking profits or reducing positions; but watch for trend continuation in strong markets."
-			} else if rsi.LessThan(decimal.NewFromInt(30)) {
-				data.RSIDesc = "Buy - RSI Oversold: RSI is below 30; indicating the asset may be oversold. This suggests a potential bounce or reversal. Interpretation: Look for buying opportunities; but confirm with other indicators and price action."
-			} else if rsi.GreaterThan(decimal.NewFromInt(50)) {
-				data.RSIDesc = "Neutral-Bullish - RSI Above Midline: RSI is above 50; showing bullish momentum but not extreme. The trend appears healthy with room for further upside. Interpretation: Maintain bullish bias but monitor for overbought conditions."
+	// Aroon descriptions
+	if cols := columns("AROON"); cols != nil {
+		down, up, osc := f.Number(cols[0]), f.Number(cols[1]), f.Number(cols[2])
+		for i := range aroonDesc {
+			if up[i] == 0 && down[i] == 0 {
+				continue
+			}
+			if up[i] > 70 && down[i] < 30 {
+				aroonDesc[i] = msg("desc.aroon.uptrend")
+			} else if down[i] > 70 && up[i] < 30 {
+				aroonDesc[i] = msg("desc.aroon.downtrend")
+			} else if osc[i] > 0 {
+				aroonDesc[i] = msg("desc.aroon.positive")
 			} else {
-				data.RSIDesc = "Neutral-Bearish - RSI Below Midline: RSI is below 50; indicating bearish momentum but not extreme oversold. The trend shows weakness with potential for further decline. Interpretation: Exercise caution and look for confirmation before buying."
+				aroonDesc[i] = msg("desc.aroon.not_positive")
 			}
 		}
+	}
 
-		// Stochastic descriptions
-		if !data.StochK.IsZero() && !data.StochD.IsZero() {
-			stochK := data.StochK
-			stochD := data.StochD
-			if stochK.GreaterThan(decimal.NewFromInt(80)) && stochD.GreaterThan(decimal.NewFromInt(80)) {
-				data.StochasticDesc = "Sell; Stochastic Overbought: Both %K and %D are above 80 - indicating overbought conditions. Momentum may be slowing. Interpretation: Consider profit-taking or tightening stops as a pullback may be imminent."
-			} else if stochK.LessThan(decimal.NewFromInt(20)) && stochD.LessThan(decimal.NewFromInt(20)) {
-				data.StochasticDesc = "Buy; Stochastic Oversold: Both %K and %D are below 20 - indicating oversold conditions. A bounce may be developing. Interpretation: Look for buying opportunities on confirmation of upward momentum."
-			} else if stochK.GreaterThan(stochD) {
-				data.StochasticDesc = "Neutral-Bullish; Stochastic Bullish Crossover: %K is above %D - indicating bullish momentum. The trend appears to be strengthening. Interpretation: Monitor for continuation of upward movement."
+	// Ichimoku descriptions from the price against the cloud, the Tenkan/Kijun cross and the Chikou span
+	if cols := columns("ICHIMOKU"); cols != nil {
+		tenkan, kijun, spanA, spanB := f.Number(cols[0]), f.Number(cols[1]), f.Number(cols[2]), f.Number(cols[3])
+		chikou := f.Number(cols[4])
+		for i := range ichimokuDesc {
+			if spanA[i] == 0 || spanB[i] == 0 {
+				continue
+			}
+			top, bottom := max(spanA[i], spanB[i]), min(spanA[i], spanB[i])
+			if f.Close[i] > top && tenkan[i] > kijun[i] && chikou[i] > 0 {
+				ichimokuDesc[i] = msg("desc.ichimoku.bullish")
+			} else if f.Close[i] < bottom && tenkan[i] < kijun[i] && chikou[i] < 0 {
+				ichimokuDesc[i] = msg("desc.ichimoku.bearish")
+			} else if f.Close[i] > top {
+				ichimokuDesc[i] = msg("desc.ichimoku.above")
+			} else if f.Close[i] < bottom {
+				ichimokuDesc[i] = msg("desc.ichimoku.below")
 			} else {
-				data.StochasticDesc = "Neutral-Bearish; Stochastic Bearish Crossover: %K is below %D - indicating bearish momentum. Weakness may be developing. Interpretation: Exercise caution and consider defensive positioning."
+				ichimokuDesc[i] = msg("desc.ichimoku.inside")
 			}
 		}
+	}
 
-		// CMF descriptions
-		if !data.CMF.IsZero() {
-			cmf := data.CMF
-			if cmf.GreaterThan(decimal.NewFromFloat(0.1)) {
-				data.CMFDesc = "Buy; Strong Money Flow: CMF is positive and strong - indicating accumulation by institutional investors. Money is flowing into the asset. Interpretation: This supports bullish price action and suggests buying interest."
-			} else if cmf.LessThan(decimal.NewFromFloat(-0.1)) {
-				data.CMFDesc = "Sell; Weak Money Flow: CMF is negative and weak - indicating distribution by institutional investors. Money is flowing out of the asset. Interpretation: This supports bearish price action and suggests selling pressure."
-			} else if cmf.GreaterThan(decimal.Zero) {
-				data.CMFDesc = "Neutral-Bullish, Mild Accumulation: CMF is slightly positive; showing mild buying interest. The trend is supported but not strongly. Interpretation: Cautiously bullish - but look for stronger confirmation."
+	// MFI descriptions; MFI is a volume weighted RSI
+	if cols := columns("MFI"); cols != nil {
+		for i, mfi := range f.Number(cols[0]) {
+			if mfi == 0 {
+				continue
+			}
+			if mfi > 80 {
+				mfiDesc[i] = msg("desc.mfi.overbought")
+			} else if mfi < 20 {
+				mfiDesc[i] = msg("desc.mfi.oversold")
+			} else if mfi > 50 {
+				mfiDesc[i] = msg("desc.mfi.above_mid")
 			} else {
-				data.CMFDesc = "Neutral-Bearish; Mild Distribution: CMF is slightly negative - showing mild selling pressure. The trend shows some weakness. Interpretation: Exercise caution and monitor for trend deterioration."
+				mfiDesc[i] = msg("desc.mfi.below_mid")
 			}
 		}
+	}
 
-		// MACD descriptions
-		if !data.MACD.IsZero() && !data.MACDSignal.IsZero() {
-			macd := data.MACD
-			signal := data.MACDSignal
-			histogram := data.MACDHist
-
-			if macd.GreaterThan(signal) && histogram.GreaterThan(decimal.Zero) {
-				data.MACDDesc = "Buy; MACD Bullish: MACD line is above signal line with positive histogram - indicating strong bullish momentum. The trend is accelerating upward. Interpretation: Consider entering long positions or adding to existing bullish positions."
-			} else if macd.LessThan(signal) && histogram.LessThan(decimal.Zero) {
-				data.MACDDesc = "Sell; MACD Bearish: MACD line is below signal line with negative histogram - indicating strong bearish momentum. The trend is accelerating downward. Interpretation: Consider reducing positions or entering short positions."
-			} else if macd.GreaterThan(signal) {
-				data.MACDDesc = "Neutral-Bullish - MACD Above Signal: MACD is above signal line but momentum is weakening. Bullish trend may be losing steam. Interpretation: Maintain bullish bias but watch for potential reversal signals."
+	// Chaikin oscillator descriptions; the oscillator is the momentum of the A/D line
+	if cols := columns("ADOSC"); cols != nil {
+		for i, adosc := range f.Number(cols[0]) {
+			if adosc == 0 {
+				continue
+			}
+			if adosc > 0 {
+				chaikinDesc[i] = msg("desc.chaikin.positive")
 			} else {
-				data.MACDDesc = "Neutral-Bearish - MACD Below Signal: MACD is below signal line but momentum is weakening. Bearish trend may be losing steam. Interpretation: Maintain bearish bias but watch for potential reversal signals."
+				chaikinDesc[i] = msg("desc.chaikin.negative")
 			}
 		}
+	}
 
-		// OBV descriptions
-		if !data.OBV.IsZero() {
-			obvRoc := data.OBVRoC
-			if obvRoc.GreaterThan(decimal.NewFromInt(10)) {
-				data.OBVDesc = "Buy; Strong Volume Accumulation: OBV is rising strongly - indicating heavy accumulation. Smart money is buying aggressively. Interpretation: This supports bullish price action and suggests strong institutional interest."
-			} else if obvRoc.LessThan(decimal.NewFromInt(-10)) {
-				data.OBVDesc = "Sell; Strong Volume Distribution: OBV is falling strongly - indicating heavy distribution. Smart money is selling aggressively. Interpretation: This supports bearish price action and suggests institutional selling."
-			} else if obvRoc.GreaterThan(decimal.Zero) {
-				data.OBVDesc = "Neutral-Bullish; Mild Volume Accumulation: OBV is rising moderately - showing steady accumulation. Buying interest is present but not overwhelming. Interpretation: Cautiously bullish volume pattern."
+	// VWAP descriptions from the close against the rolling VWAP
+	if cols := columns("VWAP"); cols != nil {
+		for i, vwap := range f.Number(cols[0]) {
+			if vwap == 0 {
+				continue
+			}
+			if f.Close[i] > vwap {
+				vwapDesc[i] = msg("desc.vwap.above")
+			} else if f.Close[i] < vwap {
+				vwapDesc[i] = msg("desc.vwap.below")
 			} else {
-				data.OBVDesc = "Neutral-Bearish; Mild Volume Distribution: OBV is declining moderately - showing steady distribution. Selling pressure is present but not overwhelming. Interpretation: Cautiously bearish volume pattern."
+				vwapDesc[i] = msg("desc.vwap.at")
 			}
 		}
+	}
 
-		// PSAR descriptions
-		if !data.PSAR.IsZero() {
-			if data.Close.GreaterThan(data.PSAR) {
-				data.PSARDesc = "Buy; PSAR Bullish: Price is above PSAR - indicating an uptrend. The parabolic SAR suggests continued bullish momentum. Interpretation: Trend following systems suggest maintaining long positions with PSAR as trailing stop."
-			} else {
-				data.PSARDesc = "Sell; PSAR Bearish: Price is below PSAR - indicating a downtrend. The parabolic SAR suggests continued bearish momentum. Interpretation: Trend following systems suggest maintaining short positions or avoiding long positions."
+	// Candlestick descriptions explain the strongest pattern on the bar and list the others
+	if cols := columns("CDL"); cols != nil {
+		strength := f.Number(cols[2])
+		for i, names := range f.Column(cols[0]).Text {
+			if names == "" {
+				continue
+			}
+			found := strings.Split(names, "; ")
+			pattern := patternByName(found[0])
+			for k, name := range found {
+				found[k] = i18n.Or(lang, "pattern."+name, name)
+			}
+			action := "Neutral"
+			switch {
+			case pattern.Bias > 0 && strength[i] >= 50:
+				action = "Buy"
+			case pattern.Bias > 0:
+				action = "Neutral-Bullish"
+			case pattern.Bias < 0 && strength[i] >= 50:
+				action = "Sell"
+			case pattern.Bias < 0:
+				action = "Neutral-Bearish"
+			}
+			meaning := i18n.Or(lang, "pattern."+pattern.Name+".meaning", pattern.Meaning)
+			candleDesc[i] = msg("desc.candlestick", msg("action."+action), found[0], strength[i], meaning)
+			if len(found) > 1 {
+				candleDesc[i] += also(found[1:])
 			}
 		}
+	}
 
-		// ATR descriptions
-		if !data.ATR.IsZero() && !data.Close.IsZero() {
-			// ATR is used for volatility assessment rather than directional signals
-			atr := data.ATR
-			pricePercent := atr.Div(data.Close).Mul(decimal.NewFromInt(100))
+	// Support/resistance descriptions from the distance to the nearest zones
+	if cols := columns("SR"); cols != nil {
+		support, resistance := f.Number(cols[0]), f.Number(cols[1])
+		supDist, resDist := f.Number(cols[2]), f.Number(cols[3])
+		for i := range levelsDesc {
+			nearSupport := support[i] > 0 && supDist[i] <= 1
+			nearResistance := resistance[i] > 0 && resDist[i] <= 1
+			if nearSupport && nearResistance {
+				levelsDesc[i] = msg("desc.levels.squeezed", support[i], resistance[i])
+			} else if nearSupport {
+				levelsDesc[i] = msg("desc.levels.near_support", supDist[i], support[i])
+			} else if nearResistance {
+				levelsDesc[i] = msg("desc.levels.near_resistance", resDist[i], resistance[i])
+			} else if support[i] == 0 && resistance[i] > 0 {
+				levelsDesc[i] = msg("desc.levels.below_all", resistance[i])
+			} else if resistance[i] == 0 && support[i] > 0 {
+				levelsDesc[i] = msg("desc.levels.above_all", support[i])
+			} else if support[i] > 0 {
+				levelsDesc[i] = msg("desc.levels.between", support[i], supDist[i], resistance[i], resDist[i])
+			}
+		}
+	}
 
-			if pricePercent.GreaterThan(decimal.NewFromInt(5)) {
-				data.ATRDesc = "High Volatility Warning: ATR indicates high volatility (>5% of price). Market conditions are unstable with large price swings. Interpretation: Use wider stops; reduce position sizes - and expect increased risk."
-			} else if pricePercent.GreaterThan(decimal.NewFromInt(2)) {
-				data.ATRDesc = "Moderate Volatility: ATR shows moderate volatility (2-5% of price). Normal market conditions with reasonable price movement. Interpretation: Standard risk management applies - monitor for volatility changes."
-			} else {
-				data.ATRDesc = "Low Volatility: ATR indicates low volatility (<2% of price). Market is relatively calm with small price movements. Interpretation: Consider tighter stops - but watch for potential volatility breakouts."
+	// Divergence descriptions from the strongest divergence confirmed on the bar
+	if cols := columns("DIV"); cols != nil {
+		strength := f.Number(cols[2])
+		for i, types := range f.Column(cols[0]).Text {
+			if types == "" {
+				continue
+			}
+			found := strings.Split(types, "; ")
+			bullish := f.Number(cols[1])[i] > 0
+			regular := strings.HasPrefix(found[0], "Regular")
+			indicator := found[0][strings.LastIndex(found[0], " ")+1:]
+			var action, meaning string
+			switch {
+			case bullish && regular:
+				action, meaning = "Buy", "desc.divergence.regular_bullish"
+			case bullish:
+				action, meaning = "Buy", "desc.divergence.hidden_bullish"
+			case regular:
+				action, meaning = "Sell", "desc.divergence.regular_bearish"
+			default:
+				action, meaning = "Sell", "desc.divergence.hidden_bearish"
+			}
+			if strength[i] < 50 {
+				action = "Neutral-Bullish"
+				if !bullish {
+					action = "Neutral-Bearish"
+				}
+			}
+			for k, label := range found {
+				found[k] = divergenceLabel(lang, label)
+			}
+			divergenceDesc[i] = msg("desc.divergence", msg("action."+action), found[0], strength[i], msg(meaning, indicator))
+			if len(found) > 1 {
+				divergenceDesc[i] += also(found[1:])
 			}
 		}
 	}
 }
+
+// divergenceLabel translates a label such as "Regular Bullish RSI"; the indicator name is kept
+func divergenceLabel(lang, label string) string {
+	parts := strings.SplitN(label, " ", 3)
+	if len(parts) != 3 {
+		return label
+	}
+	return i18n.T(lang, "divergence.label", i18n.T(lang, "divergence."+parts[0]), i18n.T(lang, "divergence."+parts[1]), parts[2])
+}
@@ -0,0 +1,364 @@
+package indicators
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"isx-auto-scrapper/internal/numeric"
+)
+
+// minZoneTouches is the number of swings a price band needs before it counts as a support/resistance zone
+const minZoneTouches = 2
+
+// fibRatios are the Fibonacci retracement ratios of a swing
+var fibRatios = []float64{0, 0.236, 0.382, 0.5, 0.618, 0.786, 1}
+
+// LevelOptions configures NewLevels
+type LevelOptions struct {
+	Lookback  int     // Bars on each side a swing high or low must exceed
+	Tolerance float64 // Percent within which swings join the same zone
+	Bars      int     // Bars whose swings form zones
+	FibBars   int     // Bars searched for the latest major swing
+}
+
+// Pivots are the pivot points for the session after the bar they were computed from
+type Pivots struct {
+	Method string  `json:"method"` // classic, fibonacci or camarilla
+	P      float64 `json:"p"`
+	R1     float64 `json:"r1"`
+	R2     float64 `json:"r2"`
+	R3     float64 `json:"r3"`
+	R4     float64 `json:"r4,omitempty"` // Camarilla only
+	S1     float64 `json:"s1"`
+	S2     float64 `json:"s2"`
+	S3     float64 `json:"s3"`
+	S4     float64 `json:"s4,omitempty"` // Camarilla only
+}
+
+// Swing is a confirmed swing high or low
+type Swing struct {
+	Date  string  `json:"date"`
+	Kind  string  `json:"kind"` // high or low
+	Price float64 `json:"price"`
+}
+
+// Zone is a horizontal price band where several swings turned
+type Zone struct {
+	Low       float64 `json:"low"`
+	High      float64 `json:"high"`
+	Price     float64 `json:"price"` // Average of the swings in the zone
+	Touches   int     `json:"touches"`
+	Kind      string  `json:"kind"` // support below the close, resistance above it
+	LastTouch string  `json:"last_touch"`
+
+	lastBar int
+}
+
+// FibLevel is one retracement level
+type FibLevel struct {
+	Ratio float64 `json:"ratio"`
+	Price float64 `json:"price"`
+}
+
+// Fibonacci holds the retracement levels of the latest major swing, measured back from its end
+type Fibonacci struct {
+	Direction string     `json:"direction"` // up when the low came first, down otherwise
+	From      string     `json:"from"`
+	To        string     `json:"to"`
+	Start     float64    `json:"start"`
+	End       float64    `json:"end"`
+	Levels    []FibLevel `json:"levels"`
+}
+
+// NearestLevel is the closest level on one side of the close
+type NearestLevel struct {
+	Price    float64 `json:"price"`
+	Source   string  `json:"source"`   // e.g. "zone", "classic S1" or "fib 61.8%"
+	Distance float64 `json:"distance"` // Percent of the close
+}
+
+// Levels are the support and resistance levels of a price history as of its last bar
+type Levels struct {
+	Date       string        `json:"date"`
+	Close      float64       `json:"close"`
+	Pivots     []Pivots      `json:"pivots"`
+	Swings     []Swing       `json:"swings"`
+	Zones      []Zone        `json:"zones"`
+	Fibonacci  *Fibonacci    `json:"fibonacci"`
+	Support    *NearestLevel `json:"support"`
+	Resistance *NearestLevel `json:"resistance"`
+}
+
+// NewLevels computes pivots, swings, zones, Fibonacci retracements and the nearest levels around the last close.
+// Bars without prices are ignored.
+func NewLevels(f *Frame, o LevelOptions) *Levels {
+	levels := &Levels{Pivots: []Pivots{}, Swings: []Swing{}, Zones: []Zone{}}
+	last := -1
+	tracker := &swingTracker{lookback: o.Lookback, keep: o.Bars}
+	for i := 0; i < f.Len(); i++ {
+		tracker.step(f.High[i], f.Low[i])
+		if f.Low[i] > 0 {
+			last = i
+		}
+	}
+	if last < 0 {
+		return levels
+	}
+	levels.Date = f.Dates[last].Format("2006-01-02")
+	levels.Close = f.Close[last]
+	levels.Pivots = pivotPoints(f.High[last], f.Low[last], f.Close[last])
+
+	for _, s := range tracker.Swings {
+		kind := "low"
+		if s.High {
+			kind = "high"
+		}
+		levels.Swings = append(levels.Swings, Swing{Date: f.Dates[s.Bar].Format("2006-01-02"), Kind: kind, Price: s.Price})
+	}
+	for _, z := range clusterZones(tracker.Swings, o.Tolerance, levels.Close) {
+		z.LastTouch = f.Dates[z.lastBar].Format("2006-01-02")
+		levels.Zones = append(levels.Zones, z)
+	}
+	levels.Fibonacci = fibonacci(f, max(last-o.FibBars+1, 0), last)
+	levels.nearest()
+	levels.round()
+	return levels
+}
+
+// pivotPoints returns classic, Fibonacci and Camarilla pivots from one bar
+func pivotPoints(high, low, closePrice float64) []Pivots {
+	p := (high + low + closePrice) / 3
+	r := high - low
+	return []Pivots{
+		{Method: "classic", P: p,
+			R1: 2*p - low, R2: p + r, R3: high + 2*(p-low),
+			S1: 2*p - high, S2: p - r, S3: low - 2*(high-p)},
+		{Method: "fibonacci", P: p,
+			R1: p + 0.382*r, R2: p + 0.618*r, R3: p + r,
+			S1: p - 0.382*r, S2: p - 0.618*r, S3: p - r},
+		{Method: "camarilla", P: p,
+			R1: closePrice + r*1.1/12, R2: closePrice + r*1.1/6, R3: closePrice + r*1.1/4, R4: closePrice + r*1.1/2,
+			S1: closePrice - r*1.1/12, S2: closePrice - r*1.1/6, S3: closePrice - r*1.1/4, S4: closePrice - r*1.1/2},
+	}
+}
+
+// fibonacci measures the retracements of the swing between the highest high and lowest low of bars from..to
+func fibonacci(f *Frame, from, to int) *Fibonacci {
+	hi, lo := -1, -1
+	for i := from; i <= to; i++ {
+		if f.Low[i] <= 0 {
+			continue
+		}
+		if hi < 0 || f.High[i] > f.High[hi] {
+			hi = i
+		}
+		if lo < 0 || f.Low[i] < f.Low[lo] {
+			lo = i
+		}
+	}
+	if hi < 0 || f.High[hi] == f.Low[lo] {
+		return nil
+	}
+
+	fib := &Fibonacci{Direction: "up", From: f.Dates[lo].Format("2006-01-02"), To: f.Dates[hi].Format("2006-01-02"),
+		Start: f.Low[lo], End: f.High[hi]}
+	if hi < lo {
+		fib.Direction, fib.From, fib.To = "down", fib.To, fib.From
+		fib.Start, fib.End = f.High[hi], f.Low[lo]
+	}
+	for _, ratio := range fibRatios {
+		fib.Levels = append(fib.Levels, FibLevel{Ratio: ratio, Price: fib.End - ratio*(fib.End-fib.Start)})
+	}
+	return fib
+}
+
+// nearest picks the closest zone, classic pivot or Fibonacci level below and above the close
+func (l *Levels) nearest() {
+	consider := func(price float64, source string) {
+		if price <= 0 || price == l.Close {
+			return
+		}
+		level := &NearestLevel{Price: price, Source: source, Distance: math.Abs(price-l.Close) / l.Close * 100}
+		if price < l.Close && (l.Support == nil || price > l.Support.Price) {
+			l.Support = level
+		} else if price > l.Close && (l.Resistance == nil || price < l.Resistance.Price) {
+			l.Resistance = level
+		}
+	}
+	for _, z := range l.Zones {
+		consider(z.Price, "zone")
+	}
+	if len(l.Pivots) > 0 {
+		c := l.Pivots[0]
+		for _, level := range []struct {
+			name  string
+			price float64
+		}{{"P", c.P}, {"R1", c.R1}, {"R2", c.R2}, {"R3", c.R3}, {"S1", c.S1}, {"S2", c.S2}, {"S3", c.S3}} {
+			consider(level.price, "classic "+level.name)
+		}
+	}
+	if l.Fibonacci != nil {
+		for _, level := range l.Fibonacci.Levels {
+			consider(level.Price, "fib "+strconv.FormatFloat(numeric.Round(level.Ratio*100, 1), 'f', -1, 64)+"%")
+		}
+	}
+}
+
+// round rounds prices to 4 decimals and distances to 2 for output
+func (l *Levels) round() {
+	for k := range l.Pivots {
+		p := &l.Pivots[k]
+		for _, v := range []*float64{&p.P, &p.R1, &p.R2, &p.R3, &p.R4, &p.S1, &p.S2, &p.S3, &p.S4} {
+			*v = numeric.Round(*v, 4)
+		}
+	}
+	for k := range l.Zones {
+		z := &l.Zones[k]
+		z.Low, z.High, z.Price = numeric.Round(z.Low, 4), numeric.Round(z.High, 4), numeric.Round(z.Price, 4)
+	}
+	if l.Fibonacci != nil {
+		for k := range l.Fibonacci.Levels {
+			l.Fibonacci.Levels[k].Price = numeric.Round(l.Fibonacci.Levels[k].Price, 4)
+		}
+	}
+	for _, level := range []*NearestLevel{l.Support, l.Resistance} {
+		if level != nil {
+			level.Price, level.Distance = numeric.Round(level.Price, 4), numeric.Round(level.Distance, 2)
+		}
+	}
+}
+
+// swingPoint is a swing high or low found by swingTracker
+type swingPoint struct {
+	Bar   int     `json:"bar"`
+	Price float64 `json:"price"`
+	High  bool    `json:"high"`
+}
+
+// swingTracker finds swing highs and lows one bar at a time and keeps those of the last keep bars.
+// A bar is a swing high when its high is above the lookback bars before it and not below the lookback bars after it,
+// so the first bar of a flat top counts. Swings are confirmed lookback bars late.
+// Its exported fields are saved with the SR indicator state.
+type swingTracker struct {
+	Bars   int          `json:"bars"` // Bars seen, including those without prices
+	Highs  []float64    `json:"highs"`
+	Lows   []float64    `json:"lows"`
+	Index  []int        `json:"index"` // Bar of each held high and low
+	Swings []swingPoint `json:"swings"`
+
+	lookback, keep int
+}
+
+// step adds the next bar
+func (t *swingTracker) step(high, low float64) {
+	bar := t.Bars
+	t.Bars++
+	if low > 0 {
+		size := 2*t.lookback + 1
+		t.Highs = append(t.Highs, high)
+		t.Lows = append(t.Lows, low)
+		t.Index = append(t.Index, bar)
+		if len(t.Highs) > size {
+			t.Highs, t.Lows, t.Index = t.Highs[1:], t.Lows[1:], t.Index[1:]
+		}
+		if len(t.Highs) == size {
+			c := t.lookback
+			if isSwing(t.Highs, c, func(a, b float64) bool { return a > b }) {
+				t.Swings = append(t.Swings, swingPoint{Bar: t.Index[c], Price: t.Highs[c], High: true})
+			}
+			if isSwing(t.Lows, c, func(a, b float64) bool { return a < b }) {
+				t.Swings = append(t.Swings, swingPoint{Bar: t.Index[c], Price: t.Lows[c]})
+			}
+		}
+	}
+
+	// Drop swings older than the window
+	old := 0
+	for old < len(t.Swings) && t.Swings[old].Bar <= bar-t.keep {
+		old++
+	}
+	t.Swings = t.Swings[old:]
+}
+
+// isSwing reports whether values[c] beats every value before it and is beaten by none after it
+func isSwing(values []float64, c int, beats func(a, b float64) bool) bool {
+	for k, v := range values {
+		if (k < c && !beats(values[c], v)) || (k > c && beats(v, values[c])) {
+			return false
+		}
+	}
+	return true
+}
+
+// clusterZones groups swings whose prices lie within tolerance percent of the zone average,
+// keeping groups of at least minZoneTouches swings, lowest first
+func clusterZones(swings []swingPoint, tolerance, closePrice float64) []Zone {
+	sorted := append([]swingPoint{}, swings...)
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a].Price < sorted[b].Price })
+
+	var zones []Zone
+	var current *Zone
+	sum := 0.0
+	flush := func() {
+		if current != nil && current.Touches >= minZoneTouches {
+			current.Kind = "support"
+			if current.Price > closePrice {
+				current.Kind = "resistance"
+			}
+			zones = append(zones, *current)
+		}
+	}
+	for _, s := range sorted {
+		if current != nil && (s.Price-current.Price)/current.Price*100 <= tolerance {
+			current.Touches++
+			sum += s.Price
+			current.Price = sum / float64(current.Touches)
+			current.High = s.Price
+			current.lastBar = max(current.lastBar, s.Bar)
+			continue
+		}
+		flush()
+		current = &Zone{Low: s.Price, High: s.Price, Price: s.Price, Touches: 1, lastBar: s.Bar}
+		sum = s.Price
+	}
+	flush()
+	return zones
+}
+
+// srStepper feeds SR(lookback,tolerance,bars): the nearest support and resistance zones and their distance from the close
+type srStepper struct {
+	swingTracker
+
+	tolerance                             float64
+	support, resistance, supDist, resDist []float64
+}
+
+func newSR(f *Frame, p Params, total int) Stepper {
+	cols := registry["SR"].Outputs(p)
+	return &srStepper{
+		swingTracker: swingTracker{lookback: p.Int(0), keep: p.Int(2)},
+		tolerance:    p.Float(1),
+		support:      f.AddNumber(cols[0]),
+		resistance:   f.AddNumber(cols[1]),
+		supDist:      f.AddNumber(cols[2]),
+		resDist:      f.AddNumber(cols[3]),
+	}
+}
+
+func (s *srStepper) Step(f *Frame, i int) {
+	s.step(f.High[i], f.Low[i])
+	price := f.Close[i]
+	if price <= 0 {
+		return
+	}
+	for _, z := range clusterZones(s.Swings, s.tolerance, price) {
+		if z.Price < price {
+			s.support[i] = numeric.Round(z.Price, 4)
+			s.supDist[i] = numeric.Round((price-z.Price)/price*100, 2)
+		} else if z.Price > price && s.resistance[i] == 0 {
+			s.resistance[i] = numeric.Round(z.Price, 4)
+			s.resDist[i] = numeric.Round((z.Price-price)/price*100, 2)
+		}
+	}
+}
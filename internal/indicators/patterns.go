@@ -0,0 +1,313 @@
+package indicators
+
+import (
+	"sort"
+	"strings"
+
+	"isx-auto-scrapper/internal/numeric"
+)
+
+// DefaultPatternTrend is the default number of bars that set the trend and average size before a pattern
+const DefaultPatternTrend = 10
+
+// candle is one bar as seen by the pattern detector, with the context before it
+type candle struct {
+	Open    float64 `json:"open"`
+	High    float64 `json:"high"`
+	Low     float64 `json:"low"`
+	Close   float64 `json:"close"`
+	Trend   int     `json:"trend"`    // 1 when the previous close was above its average, -1 below, 0 unknown
+	AvgBody float64 `json:"avg_body"` // Average body of the bars before, 0 until known
+}
+
+func (c candle) body() float64     { return abs(c.Close - c.Open) }
+func (c candle) span() float64     { return c.High - c.Low }
+func (c candle) upper() float64    { return c.High - max(c.Open, c.Close) }
+func (c candle) lower() float64    { return min(c.Open, c.Close) - c.Low }
+func (c candle) bullish() bool     { return c.Close > c.Open }
+func (c candle) bearish() bool     { return c.Close < c.Open }
+func (c candle) midpoint() float64 { return (c.Open + c.Close) / 2 }
+func (c candle) long() bool        { return c.AvgBody > 0 && c.body() > c.AvgBody }
+func (c candle) doji() bool        { return c.span() > 0 && c.body() <= 0.1*c.span() }
+
+// abs returns |v|
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// candlePattern is one classic candlestick pattern
+type candlePattern struct {
+	Name     string
+	Bars     int
+	Bias     int                   // 1 bullish, -1 bearish, 0 neutral
+	Strength float64               // Score from 0 to 100 before volume confirmation
+	Meaning  string                // Plain language explanation used by Candlestick_Desc
+	match    func(c []candle) bool // c holds Bars candles, the current one last
+}
+
+// candlePatterns lists the detected patterns; reversal patterns require the trend they reverse
+var candlePatterns = []candlePattern{
+	{"Doji", 1, 0, 30,
+		"Open and close are almost equal - buyers and sellers are balanced. Interpretation: Indecision; the next bar often decides the direction.",
+		func(c []candle) bool {
+			x := c[0]
+			return x.doji() && x.upper() > 0.1*x.span() && x.lower() > 0.1*x.span()
+		}},
+	{"Dragonfly Doji", 1, 1, 40,
+		"A doji with a long lower shadow - sellers pushed the price down but buyers brought it back to the open. Interpretation: Rejection of lower prices; bullish after a decline.",
+		func(c []candle) bool {
+			x := c[0]
+			return x.doji() && x.upper() <= 0.1*x.span() && x.lower() >= 0.6*x.span()
+		}},
+	{"Gravestone Doji", 1, -1, 40,
+		"A doji with a long upper shadow - buyers pushed the price up but sellers brought it back to the open. Interpretation: Rejection of higher prices; bearish after a rise.",
+		func(c []candle) bool {
+			x := c[0]
+			return x.doji() && x.lower() <= 0.1*x.span() && x.upper() >= 0.6*x.span()
+		}},
+	{"Hammer", 1, 1, 50,
+		"After a decline the bar has a small body near the high and a lower shadow at least twice the body. Interpretation: Sellers lost control during the session; a possible bottom.",
+		func(c []candle) bool { return c[0].Trend < 0 && hammerShape(c[0]) }},
+	{"Hanging Man", 1, -1, 40,
+		"After a rise the bar has a small body near the high and a long lower shadow. Interpretation: Selling appeared inside the session; a possible top if the next bar confirms.",
+		func(c []candle) bool { return c[0].Trend > 0 && hammerShape(c[0]) }},
+	{"Inverted Hammer", 1, 1, 40,
+		"After a decline the bar has a small body near the low and an upper shadow at least twice the body. Interpretation: Buyers tested higher prices; a possible bottom if the next bar confirms.",
+		func(c []candle) bool { return c[0].Trend < 0 && starShape(c[0]) }},
+	{"Shooting Star", 1, -1, 50,
+		"After a rise the bar has a small body near the low and an upper shadow at least twice the body. Interpretation: Higher prices were rejected; a possible top.",
+		func(c []candle) bool { return c[0].Trend > 0 && starShape(c[0]) }},
+	{"Bullish Marubozu", 1, 1, 40,
+		"A long bullish body with almost no shadows - buyers controlled the whole session. Interpretation: Strong buying pressure; often continues.",
+		func(c []candle) bool { return c[0].bullish() && c[0].long() && c[0].body() >= 0.9*c[0].span() }},
+	{"Bearish Marubozu", 1, -1, 40,
+		"A long bearish body with almost no shadows - sellers controlled the whole session. Interpretation: Strong selling pressure; often continues.",
+		func(c []candle) bool { return c[0].bearish() && c[0].long() && c[0].body() >= 0.9*c[0].span() }},
+	{"Bullish Engulfing", 2, 1, 70,
+		"After a decline a bullish body fully covers the previous bearish body. Interpretation: Buyers overwhelmed sellers; a strong reversal signal.",
+		func(c []candle) bool {
+			a, b := c[0], c[1]
+			return a.Trend < 0 && a.bearish() && b.bullish() && b.Open <= a.Close && b.Close >= a.Open && b.body() > a.body()
+		}},
+	{"Bearish Engulfing", 2, -1, 70,
+		"After a rise a bearish body fully covers the previous bullish body. Interpretation: Sellers overwhelmed buyers; a strong reversal signal.",
+		func(c []candle) bool {
+			a, b := c[0], c[1]
+			return a.Trend > 0 && a.bullish() && b.bearish() && b.Open >= a.Close && b.Close <= a.Open && b.body() > a.body()
+		}},
+	{"Bullish Harami", 2, 1, 40,
+		"After a decline a small bullish body sits inside the previous long bearish body. Interpretation: Selling is losing momentum; wait for confirmation.",
+		func(c []candle) bool {
+			a, b := c[0], c[1]
+			return a.Trend < 0 && a.bearish() && a.long() && b.bullish() && b.Close <= a.Open && b.Open >= a.Close && b.body() < a.body()
+		}},
+	{"Bearish Harami", 2, -1, 40,
+		"After a rise a small bearish body sits inside the previous long bullish body. Interpretation: Buying is losing momentum; wait for confirmation.",
+		func(c []candle) bool {
+			a, b := c[0], c[1]
+			return a.Trend > 0 && a.bullish() && a.long() && b.bearish() && b.Open <= a.Close && b.Close >= a.Open && b.body() < a.body()
+		}},
+	{"Piercing Line", 2, 1, 60,
+		"After a long bearish bar the price opens lower but closes above the middle of that bar. Interpretation: Buyers stepped in strongly; a bullish reversal signal.",
+		func(c []candle) bool {
+			a, b := c[0], c[1]
+			return a.Trend < 0 && a.bearish() && a.long() && b.bullish() && b.Open < a.Close && b.Close > a.midpoint() && b.Close < a.Open
+		}},
+	{"Dark Cloud Cover", 2, -1, 60,
+		"After a long bullish bar the price opens higher but closes below the middle of that bar. Interpretation: Sellers stepped in strongly; a bearish reversal signal.",
+		func(c []candle) bool {
+			a, b := c[0], c[1]
+			return a.Trend > 0 && a.bullish() && a.long() && b.bearish() && b.Open > a.Close && b.Close < a.midpoint() && b.Close > a.Open
+		}},
+	{"Morning Star", 3, 1, 80,
+		"A long bearish bar, a small bar below its close, then a bullish bar closing above the middle of the first. Interpretation: A three-bar bottom; one of the most reliable bullish reversals.",
+		func(c []candle) bool {
+			a, b, x := c[0], c[1], c[2]
+			return a.Trend < 0 && a.bearish() && a.long() && b.body() <= 0.3*a.body() && max(b.Open, b.Close) <= a.Close &&
+				x.bullish() && x.Close > a.midpoint()
+		}},
+	{"Evening Star", 3, -1, 80,
+		"A long bullish bar, a small bar above its close, then a bearish bar closing below the middle of the first. Interpretation: A three-bar top; one of the most reliable bearish reversals.",
+		func(c []candle) bool {
+			a, b, x := c[0], c[1], c[2]
+			return a.Trend > 0 && a.bullish() && a.long() && b.body() <= 0.3*a.body() && min(b.Open, b.Close) >= a.Close &&
+				x.bearish() && x.Close < a.midpoint()
+		}},
+	{"Three White Soldiers", 3, 1, 80,
+		"Three bullish bars in a row, each opening inside the previous body and closing near its high. Interpretation: Steady buying; a strong bullish signal.",
+		func(c []candle) bool {
+			for k, x := range c {
+				if !x.bullish() || x.AvgBody == 0 || x.body() < 0.5*x.AvgBody || x.upper() > 0.3*x.span() {
+					return false
+				}
+				if k > 0 && (x.Close <= c[k-1].Close || x.Open < c[k-1].Open || x.Open > c[k-1].Close) {
+					return false
+				}
+			}
+			return true
+		}},
+	{"Three Black Crows", 3, -1, 80,
+		"Three bearish bars in a row, each opening inside the previous body and closing near its low. Interpretation: Steady selling; a strong bearish signal.",
+		func(c []candle) bool {
+			for k, x := range c {
+				if !x.bearish() || x.AvgBody == 0 || x.body() < 0.5*x.AvgBody || x.lower() > 0.3*x.span() {
+					return false
+				}
+				if k > 0 && (x.Close >= c[k-1].Close || x.Open > c[k-1].Open || x.Open < c[k-1].Close) {
+					return false
+				}
+			}
+			return true
+		}},
+}
+
+// hammerShape is a small body at the top of the range with a lower shadow at least twice the body
+func hammerShape(x candle) bool {
+	return x.span() > 0 && !x.doji() && x.lower() >= 2*x.body() && x.upper() <= 0.1*x.span()
+}
+
+// starShape is a small body at the bottom of the range with an upper shadow at least twice the body
+func starShape(x candle) bool {
+	return x.span() > 0 && !x.doji() && x.upper() >= 2*x.body() && x.lower() <= 0.1*x.span()
+}
+
+// patternMatch is a pattern found on a bar with its volume confirmed strength
+type patternMatch struct {
+	Pattern  *candlePattern
+	Strength float64
+}
+
+// patternDetector finds candlestick patterns one bar at a time.
+// Its exported fields are saved with the CDL indicator state.
+type patternDetector struct {
+	Recent  []candle       `json:"recent"` // Up to the last two bars
+	Closes  numeric.Window `json:"closes"`
+	Bodies  numeric.Window `json:"bodies"`
+	Volumes numeric.Window `json:"volumes"`
+
+	trend int
+}
+
+// step adds bar i and returns the patterns ending on it, strongest first.
+// Bars without a range (no trades or a single price) never match.
+func (d *patternDetector) step(f *Frame, i int) []patternMatch {
+	c := candle{Open: f.Open[i], High: f.High[i], Low: f.Low[i], Close: f.Close[i]}
+	if len(d.Closes.Values) == d.trend {
+		switch prev := d.Closes.Values[d.trend-1]; {
+		case prev > d.Closes.Mean():
+			c.Trend = 1
+		case prev < d.Closes.Mean():
+			c.Trend = -1
+		}
+		c.AvgBody = d.Bodies.Mean()
+	}
+	volume := float64(f.Volume[i])
+	busy := len(d.Volumes.Values) == d.trend && volume > d.Volumes.Mean()
+
+	var matches []patternMatch
+	window := append(append([]candle{}, d.Recent...), c)
+	if c.span() > 0 && c.Open > 0 {
+		for k := range candlePatterns {
+			p := &candlePatterns[k]
+			if len(window) < p.Bars || !p.match(window[len(window)-p.Bars:]) {
+				continue
+			}
+			strength := p.Strength
+			if busy {
+				strength = min(strength+10, 100) // Volume above average confirms the pattern
+			}
+			matches = append(matches, patternMatch{p, strength})
+		}
+	}
+	sort.SliceStable(matches, func(a, b int) bool { return matches[a].Strength > matches[b].Strength })
+
+	if len(window) > 2 {
+		window = window[len(window)-2:]
+	}
+	d.Recent = window
+	d.Closes.Push(c.Close, d.trend)
+	d.Bodies.Push(c.body(), d.trend)
+	d.Volumes.Push(volume, d.trend)
+	return matches
+}
+
+// PatternEvent is a candlestick pattern found on one bar, used for chart markers
+type PatternEvent struct {
+	Date     string  `json:"date"`
+	Pattern  string  `json:"pattern"`
+	Bias     string  `json:"bias"` // bullish, bearish or neutral
+	Strength float64 `json:"strength"`
+}
+
+// DetectPatterns returns every candlestick pattern in the frame, oldest first
+func DetectPatterns(f *Frame, trend int) []PatternEvent {
+	d := &patternDetector{trend: trend}
+	events := make([]PatternEvent, 0)
+	for i := 0; i < f.Len(); i++ {
+		for _, m := range d.step(f, i) {
+			events = append(events, PatternEvent{
+				Date:     f.Dates[i].Format("2006-01-02"),
+				Pattern:  m.Pattern.Name,
+				Bias:     biasName(m.Pattern.Bias),
+				Strength: m.Strength,
+			})
+		}
+	}
+	return events
+}
+
+// biasName returns the bias as a word
+func biasName(bias int) string {
+	switch {
+	case bias > 0:
+		return "bullish"
+	case bias < 0:
+		return "bearish"
+	}
+	return "neutral"
+}
+
+// cdlStepper feeds CDL(trend): the patterns on each bar, the bias and strength of the strongest
+type cdlStepper struct {
+	patternDetector
+
+	names          []string
+	bias, strength []float64
+}
+
+func newCDL(f *Frame, p Params, total int) Stepper {
+	cols := registry["CDL"].Outputs(p)
+	return &cdlStepper{
+		patternDetector: patternDetector{trend: p.Int(0)},
+		names:           f.AddText(cols[0]),
+		bias:            f.AddNumber(cols[1]),
+		strength:        f.AddNumber(cols[2]),
+	}
+}
+
+func (s *cdlStepper) Step(f *Frame, i int) {
+	matches := s.step(f, i)
+	if len(matches) == 0 {
+		return
+	}
+	names := make([]string, len(matches))
+	for k, m := range matches {
+		names[k] = m.Pattern.Name
+	}
+	s.names[i] = strings.Join(names, "; ")
+	s.bias[i] = float64(matches[0].Pattern.Bias)
+	s.strength[i] = matches[0].Strength
+}
+
+// patternByName returns the pattern called name
+func patternByName(name string) *candlePattern {
+	for k := range candlePatterns {
+		if candlePatterns[k].Name == name {
+			return &candlePatterns[k]
+		}
+	}
+	return nil
+}
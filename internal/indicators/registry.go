@@ -0,0 +1,322 @@
+package indicators
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"isx-auto-scrapper/internal/common"
+)
+
+// Param declares an indicator parameter
+type Param struct {
+	Name    string  `json:"name"`
+	Default float64 `json:"default"`
+	Integer bool    `json:"integer"` // Whole number of bars, at least 1
+}
+
+// Definition declares an indicator: its parameters, the bar fields it reads and the columns it writes.
+// New must add exactly the columns named by Outputs for the same parameters.
+type Definition struct {
+	Name        string
+	Description string
+	Params      []Param
+	Inputs      []string // Bar fields read: open, high, low, close, volume
+	Outputs     func(p Params) []string
+	// MinBars is the history length below which some outputs stay empty for the whole file;
+	// saved state is only extended once the history reached it. Nil means no minimum.
+	MinBars func(p Params) int
+	// Validate checks parameter values beyond their type; nil accepts any
+	Validate func(p Params) error
+	// New binds a stepper to its output columns in f; total is the length of the full history
+	New func(f *Frame, p Params, total int) Stepper
+}
+
+// Stepper computes an indicator one bar at a time.
+// Its exported fields are the state saved after a run and restored to extend the series with new bars.
+type Stepper interface {
+	Step(f *Frame, i int)
+}
+
+// Params are the parameter values of one indicator instance, in declaration order
+type Params []float64
+
+// Int returns parameter i as a bar count
+func (p Params) Int(i int) int {
+	return int(p[i])
+}
+
+// Float returns parameter i
+func (p Params) Float(i int) float64 {
+	return p[i]
+}
+
+// Format returns parameter i as written in specs and column names, e.g. 20 or 0.02
+func (p Params) Format(i int) string {
+	return strconv.FormatFloat(p[i], 'f', -1, 64)
+}
+
+var registry = make(map[string]*Definition)
+
+// Register adds an indicator definition; names are case-insensitive and must be unique
+func Register(def *Definition) {
+	name := strings.ToUpper(def.Name)
+	if _, exists := registry[name]; exists {
+		panic("indicator registered twice: " + name)
+	}
+	registry[name] = def
+}
+
+// Lookup returns the definition registered under name
+func Lookup(name string) (*Definition, bool) {
+	def, ok := registry[strings.ToUpper(strings.TrimSpace(name))]
+	return def, ok
+}
+
+// Definitions returns every registered indicator sorted by name
+func Definitions() []*Definition {
+	defs := make([]*Definition, 0, len(registry))
+	for _, def := range registry {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs
+}
+
+// Spec is one configured indicator instance, e.g. MACD(12,26,9)
+type Spec struct {
+	Def    *Definition
+	Params Params
+}
+
+// String returns the canonical spec text; indicators without parameters are written without parentheses
+func (s Spec) String() string {
+	if len(s.Params) == 0 {
+		return s.Def.Name
+	}
+	args := make([]string, len(s.Params))
+	for i := range s.Params {
+		args[i] = s.Params.Format(i)
+	}
+	return fmt.Sprintf("%s(%s)", s.Def.Name, strings.Join(args, ","))
+}
+
+// Columns returns the output columns of this instance
+func (s Spec) Columns() []string {
+	return s.Def.Outputs(s.Params)
+}
+
+// MarshalJSON writes the spec in its text form
+func (s Spec) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// ParseSpec parses text such as "SMA(20)", "OBV" or "PSAR(0.02, 0.2)".
+// Omitted trailing parameters take their defaults.
+func ParseSpec(text string) (Spec, error) {
+	text = strings.TrimSpace(text)
+	name, args := text, ""
+	if open := strings.Index(text, "("); open >= 0 {
+		if !strings.HasSuffix(text, ")") {
+			return Spec{}, fmt.Errorf("invalid indicator spec %q: missing ')'", text)
+		}
+		name, args = text[:open], text[open+1:len(text)-1]
+	}
+
+	def, ok := Lookup(name)
+	if !ok {
+		return Spec{}, fmt.Errorf("unknown indicator %q in %q", strings.TrimSpace(name), text)
+	}
+
+	var values []string
+	if strings.TrimSpace(args) != "" {
+		values = strings.Split(args, ",")
+	}
+	if len(values) > len(def.Params) {
+		return Spec{}, fmt.Errorf("%s takes at most %d parameters, got %d in %q", def.Name, len(def.Params), len(values), text)
+	}
+
+	params := make(Params, len(def.Params))
+	for i, param := range def.Params {
+		params[i] = param.Default
+		if i >= len(values) {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(values[i]), 64)
+		if err != nil {
+			return Spec{}, fmt.Errorf("invalid %s %s %q in %q", def.Name, param.Name, strings.TrimSpace(values[i]), text)
+		}
+		if param.Integer && (v < 1 || v != math.Trunc(v)) {
+			return Spec{}, fmt.Errorf("%s %s must be a whole number of at least 1 in %q", def.Name, param.Name, text)
+		}
+		if !param.Integer && v <= 0 {
+			return Spec{}, fmt.Errorf("%s %s must be positive in %q", def.Name, param.Name, text)
+		}
+		params[i] = v
+	}
+	if def.Validate != nil {
+		if err := def.Validate(params); err != nil {
+			return Spec{}, fmt.Errorf("invalid %s in %q: %w", def.Name, text, err)
+		}
+	}
+
+	return Spec{Def: def, Params: params}, nil
+}
+
+// ParseSpecs parses a list of specs and checks that no two write the same column
+func ParseSpecs(texts []string) ([]Spec, error) {
+	specs := make([]Spec, 0, len(texts))
+	owner := make(map[string]string)
+	for _, column := range append(append([]string{}, baseColumns...), descriptionColumns...) {
+		owner[column] = "the base columns"
+	}
+
+	for _, text := range texts {
+		spec, err := ParseSpec(text)
+		if err != nil {
+			return nil, err
+		}
+		for _, column := range spec.Columns() {
+			if other, taken := owner[column]; taken {
+				return nil, fmt.Errorf("%s and %s both write column %s", other, spec, column)
+			}
+			owner[column] = spec.String()
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// DefaultSpecTexts reproduces the long-standing layout of indicators_<TICKER>.csv.
+// The built-in strategies read the first instance of the indicators they use, and the first two SMA and STD.
+var DefaultSpecTexts = []string{
+	"SMA(10)", "SMA(50)", "SMA(200)", "CROSS(50,200)",
+	"EMA(5)", "EMA(10)", "EMA(20)", "EMA(50)", "EMA(200)",
+	"RSI(14)", "RSI(9)", "RSI(25)",
+	"STOCH(9,6,3)",
+	"MACD(12,26,9)",
+	"CMF(20)",
+	"OBV(10)",
+	"PSAR(0.02,0.2)", "PSAR(0.01,0.1)",
+	"ATR(14)",
+	"STD(10)", "STD(50)",
+	"BBANDS(20,2)", "KC(20,1.5,20)", "DONCHIAN(20)",
+	"ADX(14)", "AROON(25)", "ICHIMOKU(9,26,52)",
+	"MFI(14)", "AD", "ADOSC(3,10)", "VWAP(20)", "CDL(10)", "SR(5,1.5,250)",
+	"DIV(5,60)",
+}
+
+// specFile is the layout of the indicator spec file
+type specFile struct {
+	Indicators []string `json:"indicators"`
+}
+
+// LoadSpecs reads the indicator spec file; a missing file gives the default specs
+func LoadSpecs(path string) ([]Spec, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ParseSpecs(DefaultSpecTexts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file specFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	if len(file.Indicators) == 0 {
+		return nil, fmt.Errorf("%s lists no indicators", path)
+	}
+
+	specs, err := ParseSpecs(file.Indicators)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return specs, nil
+}
+
+// ConfiguredSpecs returns the specs of indicators.spec_file followed by the formulas of indicators.custom_file
+func ConfiguredSpecs() ([]Spec, error) {
+	cfg := common.AppConfig.Indicators
+	specs, err := LoadSpecs(cfg.SpecFile)
+	if err != nil {
+		return nil, err
+	}
+	custom, err := LoadCustomIndicators(cfg.CustomFile, specs)
+	if err != nil {
+		return nil, err
+	}
+	return append(specs, custom...), nil
+}
+
+// MinBars returns the history length from which saved state of the spec can be extended
+func (s Spec) MinBars() int {
+	if s.Def.MinBars == nil {
+		return 0
+	}
+	return s.Def.MinBars(s.Params)
+}
+
+// ApplySpecs computes every spec over the whole frame and returns the steppers holding the final state
+func ApplySpecs(f *Frame, specs []Spec) ([]Stepper, error) {
+	return runSpecs(f, specs, f.Len(), nil)
+}
+
+// ExtendSpecs computes every spec over a frame of new bars, continuing from the saved stepper states
+func ExtendSpecs(f *Frame, specs []Spec, saved map[string]json.RawMessage) ([]Stepper, error) {
+	return runSpecs(f, specs, 0, saved)
+}
+
+// runSpecs steps every spec over the frame, restoring saved state first when given
+func runSpecs(f *Frame, specs []Spec, total int, saved map[string]json.RawMessage) ([]Stepper, error) {
+	steppers := make([]Stepper, len(specs))
+	for k, spec := range specs {
+		stepper := spec.Def.New(f, spec.Params, total)
+		if saved != nil {
+			state, ok := saved[spec.String()]
+			if !ok {
+				return nil, fmt.Errorf("no saved state for %s", spec)
+			}
+			if err := json.Unmarshal(state, stepper); err != nil {
+				return nil, fmt.Errorf("invalid saved state for %s: %w", spec, err)
+			}
+		}
+		for i := 0; i < f.Len(); i++ {
+			stepper.Step(f, i)
+		}
+		for _, column := range spec.Columns() {
+			if f.Column(column) == nil {
+				return nil, fmt.Errorf("%s did not produce column %s", spec, column)
+			}
+		}
+		steppers[k] = stepper
+	}
+	return steppers, nil
+}
+
+// Columns returns the header of an indicator file for the given specs
+func Columns(specs []Spec, descriptions bool) []string {
+	header := append([]string{}, baseColumns...)
+	for _, spec := range specs {
+		header = append(header, spec.Columns()...)
+	}
+	if descriptions {
+		header = append(header, descriptionColumns...)
+	}
+	return header
+}
+
+// firstSpec returns the first configured instance of an indicator
+func firstSpec(specs []Spec, name string) (Spec, bool) {
+	for _, spec := range specs {
+		if strings.EqualFold(spec.Def.Name, name) {
+			return spec, true
+		}
+	}
+	return Spec{}, false
+}
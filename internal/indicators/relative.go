@@ -0,0 +1,370 @@
+package indicators
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/gocarina/gocsv"
+
+	"isx-auto-scrapper/internal/common"
+	"isx-auto-scrapper/internal/numeric"
+)
+
+// MarketIndexFile holds the market series every ticker is compared with
+const MarketIndexFile = "market_index.csv"
+
+// compositeBase is the level of the equal-weighted composite on its first session
+const compositeBase = 1000
+
+// MarketIndexRecord is one session of market_index.csv
+type MarketIndexRecord struct {
+	Date    string  `csv:"Date" json:"date"`
+	Level   float64 `csv:"Level" json:"level"`
+	Members int     `csv:"Members" json:"members"` // Tickers whose return moved the composite; 0 for a scraped index
+}
+
+// RelativeRecord is one session of relative_<TICKER>.csv. Values that cannot be computed yet are 0.
+type RelativeRecord struct {
+	Date        string  `csv:"Date" json:"date"`
+	Close       float64 `csv:"Close" json:"close"`
+	Market      float64 `csv:"Market" json:"market"`           // Market level
+	Sector      float64 `csv:"Sector" json:"sector"`           // Equal-weighted level of the sector peers
+	RSMarket    float64 `csv:"RS_Market" json:"rs_market"`     // Close over market, 100 on the first session
+	RSSector    float64 `csv:"RS_Sector" json:"rs_sector"`     // Close over the sector peers, 100 on the first session
+	RSRank      float64 `csv:"RS_Rank" json:"rs_rank"`         // 1 (weakest) to 99 (strongest) across all tickers
+	Beta        float64 `csv:"Beta" json:"beta"`               // Rolling beta of returns to the market
+	Correlation float64 `csv:"Correlation" json:"correlation"` // Rolling correlation of returns with the market
+}
+
+// RelativeFile returns the relative strength file of ticker, e.g. relative_BBOB.csv
+func RelativeFile(ticker string) string {
+	return "relative_" + ticker + ".csv"
+}
+
+// priceSeries is the sessions of one ticker with a close, as indexes into the market sessions
+type priceSeries struct {
+	ticker string
+	sector string
+	days   []int
+	closes []float64
+}
+
+// RelativeStrengthCalc compares every ticker with the market and its sector peers
+type RelativeStrengthCalc struct {
+	logger *common.Logger
+	config common.IndicatorsConfig
+}
+
+// NewRelativeStrengthCalc creates a new RelativeStrengthCalc instance
+func NewRelativeStrengthCalc(logger *common.Logger) *RelativeStrengthCalc {
+	return &RelativeStrengthCalc{
+		logger: logger,
+		config: common.AppConfig.Indicators,
+	}
+}
+
+// CalculateAll writes market_index.csv and relative_<TICKER>.csv for every ticker in TICKERS.csv.
+// It always covers the whole universe, since the composite and the ranks depend on every ticker.
+func (rc *RelativeStrengthCalc) CalculateAll() error {
+	tickers, err := common.LoadTickersWithInfo("TICKERS.csv")
+	if err != nil {
+		return fmt.Errorf("failed to load tickers: %w", err)
+	}
+
+	// Read every close; the market sessions are the dates on which any ticker traded
+	closes := make(map[string]map[time.Time]float64)
+	dateSet := make(map[time.Time]bool)
+	load := func(ticker string) {
+		if _, done := closes[ticker]; done {
+			return
+		}
+		f, err := LoadRawFrame(fmt.Sprintf("raw_%s.csv", ticker))
+		if err != nil {
+			return
+		}
+		byDate := make(map[time.Time]float64)
+		for i, c := range f.Close {
+			if c > 0 {
+				byDate[f.Dates[i]] = c
+				dateSet[f.Dates[i]] = true
+			}
+		}
+		closes[ticker] = byDate
+	}
+	for _, t := range tickers {
+		load(t.Symbol)
+	}
+	if rc.config.MarketIndex != "" {
+		load(rc.config.MarketIndex)
+		if _, ok := closes[rc.config.MarketIndex]; !ok {
+			return fmt.Errorf("market index raw_%s.csv not found", rc.config.MarketIndex)
+		}
+	}
+	if len(dateSet) == 0 {
+		return fmt.Errorf("no price data")
+	}
+
+	dates := make([]time.Time, 0, len(dateSet))
+	for d := range dateSet {
+		dates = append(dates, d)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	seriesOf := func(ticker, sector string) *priceSeries {
+		s := &priceSeries{ticker: ticker, sector: sector}
+		for d, date := range dates {
+			if c, ok := closes[ticker][date]; ok {
+				s.days = append(s.days, d)
+				s.closes = append(s.closes, c)
+			}
+		}
+		return s
+	}
+
+	var universe []*priceSeries
+	for _, t := range tickers {
+		if _, ok := closes[t.Symbol]; ok {
+			universe = append(universe, seriesOf(t.Symbol, t.Sector))
+		}
+	}
+
+	// The market is the configured index, carried over sessions it missed, or the composite of all tickers
+	var market []float64
+	var members []int
+	if rc.config.MarketIndex != "" {
+		market = carryForward(seriesOf(rc.config.MarketIndex, ""), len(dates))
+		members = make([]int, len(dates))
+	} else {
+		market, members = composite(universe, len(dates))
+	}
+	if err := saveMarketIndex(dates, market, members); err != nil {
+		return fmt.Errorf("failed to save market index: %w", err)
+	}
+
+	ranks := rsRanks(universe, len(dates), rc.config.RSPeriod)
+	for _, s := range universe {
+		// Peers are the other tickers of the sector
+		var peers []*priceSeries
+		for _, other := range universe {
+			if other.sector == s.sector && other != s {
+				peers = append(peers, other)
+			}
+		}
+		var sector []float64
+		if len(peers) > 0 {
+			sector, _ = composite(peers, len(dates))
+		}
+
+		records := rc.relativeRecords(s, dates, market, sector, ranks[s.ticker])
+		if err := saveRelative(records, RelativeFile(s.ticker)); err != nil {
+			rc.logger.Error("Failed to save relative strength for %s: %v", s.ticker, err)
+		}
+	}
+
+	rc.logger.Info("Relative strength calculated for %d tickers over %d sessions", len(universe), len(dates))
+	return nil
+}
+
+// relativeRecords builds the relative strength rows of one ticker; sector is nil without peers.
+// The RS lines are 100 on the first session with a benchmark level.
+func (rc *RelativeStrengthCalc) relativeRecords(s *priceSeries, dates []time.Time, market, sector, ranks []float64) []*RelativeRecord {
+	window := rc.config.BetaWindow
+	records := make([]*RelativeRecord, len(s.days))
+	var tickerReturns, marketReturns []float64
+	var marketBase, sectorBase float64 // Close over the benchmark on the first session both have
+	for k, d := range s.days {
+		r := &RelativeRecord{
+			Date:   dates[d].Format("2006-01-02"),
+			Close:  s.closes[k],
+			Market: numeric.Round(market[d], 4),
+			RSRank: ranks[d],
+		}
+		if market[d] > 0 {
+			if marketBase == 0 {
+				marketBase = s.closes[k] / market[d]
+			}
+			r.RSMarket = numeric.Round(s.closes[k]/market[d]/marketBase*100, 4)
+		}
+		if sector != nil && sector[d] > 0 {
+			if sectorBase == 0 {
+				sectorBase = s.closes[k] / sector[d]
+			}
+			r.Sector = numeric.Round(sector[d], 4)
+			r.RSSector = numeric.Round(s.closes[k]/sector[d]/sectorBase*100, 4)
+		}
+
+		// Both returns span the same sessions, so days without a trade are measured with the next one
+		if k > 0 && market[s.days[k-1]] > 0 && market[d] > 0 {
+			tickerReturns = append(tickerReturns, s.closes[k]/s.closes[k-1]-1)
+			marketReturns = append(marketReturns, market[d]/market[s.days[k-1]]-1)
+			if len(tickerReturns) > window {
+				tickerReturns, marketReturns = tickerReturns[1:], marketReturns[1:]
+			}
+			if len(tickerReturns) == window {
+				r.Beta, r.Correlation = betaCorrelation(tickerReturns, marketReturns)
+			}
+		}
+		records[k] = r
+	}
+	return records
+}
+
+// composite chains the average return of the series trading on each session into an index that
+// starts at compositeBase on the first close of any series (0 before it), and counts the series
+// behind each session's move
+func composite(series []*priceSeries, sessions int) ([]float64, []int) {
+	first := sessions
+	sums := make([]float64, sessions)
+	counts := make([]int, sessions)
+	for _, s := range series {
+		if len(s.days) > 0 {
+			first = min(first, s.days[0])
+		}
+		for k := 1; k < len(s.days); k++ {
+			sums[s.days[k]] += s.closes[k]/s.closes[k-1] - 1
+			counts[s.days[k]]++
+		}
+	}
+
+	level := make([]float64, sessions)
+	current := float64(compositeBase)
+	for d := first; d < sessions; d++ {
+		if counts[d] > 0 {
+			current *= 1 + sums[d]/float64(counts[d])
+		}
+		level[d] = current
+	}
+	return level, counts
+}
+
+// carryForward returns the close of s on every session, the last close on sessions it missed
+// and 0 before its first
+func carryForward(s *priceSeries, sessions int) []float64 {
+	level := make([]float64, sessions)
+	k := 0
+	for d := range level {
+		for k < len(s.days) && s.days[k] <= d {
+			k++
+		}
+		if k > 0 {
+			level[d] = s.closes[k-1]
+		}
+	}
+	return level
+}
+
+// rsRanks ranks every ticker's return over the last period sessions on each session, IBD style
+// from 1 to 99. Tickers without a trade in the period or a close before it are left unranked (0).
+func rsRanks(universe []*priceSeries, sessions, period int) map[string][]float64 {
+	levels := make([][]float64, len(universe))
+	lastTrade := make([][]int, len(universe))
+	for n, s := range universe {
+		levels[n] = carryForward(s, sessions)
+		lastTrade[n] = make([]int, sessions)
+		k := 0
+		for d := 0; d < sessions; d++ {
+			for k < len(s.days) && s.days[k] <= d {
+				k++
+			}
+			lastTrade[n][d] = -1
+			if k > 0 {
+				lastTrade[n][d] = s.days[k-1]
+			}
+		}
+	}
+
+	ranks := make(map[string][]float64, len(universe))
+	for _, s := range universe {
+		ranks[s.ticker] = make([]float64, sessions)
+	}
+	performance := make([]float64, 0, len(universe))
+	ranked := make([]int, 0, len(universe))
+	for d := period; d < sessions; d++ {
+		performance, ranked = performance[:0], ranked[:0]
+		for n := range universe {
+			then := levels[n][d-period]
+			if then > 0 && lastTrade[n][d] > d-period {
+				performance = append(performance, levels[n][d]/then-1)
+				ranked = append(ranked, n)
+			}
+		}
+		if len(ranked) < 2 {
+			continue
+		}
+		for i, n := range ranked {
+			lower := 0
+			for _, p := range performance {
+				if p < performance[i] {
+					lower++
+				}
+			}
+			ranks[universe[n].ticker][d] = math.Round(1 + 98*float64(lower)/float64(len(ranked)-1))
+		}
+	}
+	return ranks
+}
+
+// betaCorrelation returns the beta of returns to market and their correlation
+func betaCorrelation(returns, market []float64) (float64, float64) {
+	meanR, meanM := numeric.Mean(returns), numeric.Mean(market)
+	cov, varR, varM := 0.0, 0.0, 0.0
+	for i := range returns {
+		dr, dm := returns[i]-meanR, market[i]-meanM
+		cov += dr * dm
+		varR += dr * dr
+		varM += dm * dm
+	}
+	if varM == 0 {
+		return 0, 0
+	}
+	beta := cov / varM
+	correlation := 0.0
+	if varR > 0 {
+		correlation = cov / math.Sqrt(varR*varM)
+	}
+	return numeric.Round(beta, 4), numeric.Round(correlation, 4)
+}
+
+// saveMarketIndex writes the market level of every session to market_index.csv
+func saveMarketIndex(dates []time.Time, market []float64, members []int) error {
+	records := make([]*MarketIndexRecord, 0, len(dates))
+	for d, date := range dates {
+		if market[d] > 0 {
+			records = append(records, &MarketIndexRecord{Date: date.Format("2006-01-02"), Level: numeric.Round(market[d], 4), Members: members[d]})
+		}
+	}
+
+	file, err := os.Create(MarketIndexFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return gocsv.MarshalFile(&records, file)
+}
+
+// saveRelative writes the relative strength rows of one ticker to filePath
+func saveRelative(records []*RelativeRecord, filePath string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return gocsv.MarshalFile(&records, file)
+}
+
+// LoadRelative reads a relative_<TICKER>.csv file
+func LoadRelative(filePath string) ([]*RelativeRecord, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []*RelativeRecord
+	if err := gocsv.UnmarshalFile(file, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -0,0 +1,149 @@
+package indicators
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"isx-auto-scrapper/internal/calendar"
+	"isx-auto-scrapper/internal/common"
+	"isx-auto-scrapper/internal/numeric"
+)
+
+// Timeframe is the bar period indicators and strategies are calculated on
+type Timeframe string
+
+const (
+	Daily   Timeframe = "D"
+	Weekly  Timeframe = "W"
+	Monthly Timeframe = "M"
+)
+
+// ParseTimeframe accepts D, W or M in either case
+func ParseTimeframe(s string) (Timeframe, error) {
+	switch tf := Timeframe(strings.ToUpper(strings.TrimSpace(s))); tf {
+	case Daily, Weekly, Monthly:
+		return tf, nil
+	}
+	return "", fmt.Errorf("unknown timeframe %q (want D, W or M)", s)
+}
+
+// Suffix returns the file name suffix of the timeframe: none for daily, e.g. "_W" otherwise
+func (tf Timeframe) Suffix() string {
+	if tf == Daily || tf == "" {
+		return ""
+	}
+	return "_" + string(tf)
+}
+
+// Name returns the timeframe as a word for logs
+func (tf Timeframe) Name() string {
+	switch tf {
+	case Weekly:
+		return "weekly"
+	case Monthly:
+		return "monthly"
+	}
+	return "daily"
+}
+
+// ConfiguredTimeframes returns daily followed by the timeframes listed in indicators.timeframes
+func ConfiguredTimeframes() []Timeframe {
+	tfs := []Timeframe{Daily}
+	for _, name := range common.AppConfig.Indicators.Timeframes {
+		if tf, err := ParseTimeframe(name); err == nil && tf != Daily {
+			tfs = append(tfs, tf)
+		}
+	}
+	return tfs
+}
+
+// TrimTimeframe strips a timeframe suffix from the ticker part of a file name, e.g. "BBOB_W" gives "BBOB"
+func TrimTimeframe(name string) string {
+	for _, tf := range []Timeframe{Weekly, Monthly} {
+		if trimmed, ok := strings.CutSuffix(name, tf.Suffix()); ok {
+			return trimmed
+		}
+	}
+	return name
+}
+
+// Resample aggregates daily bars into weekly or monthly bars. Weeks follow the trading calendar,
+// so an ISX week runs from Sunday to Thursday. Each bar is dated by its last daily bar and
+// Change is measured against the previous bar's close. Days without prices are skipped.
+// A period whose last scheduled session has not completed at now is partial: it is kept as
+// the last bar when keepPartial is set, and the second result reports it. A partial bar changes
+// as its period fills, which invalidates the saved indicator state and recalculates the file.
+func Resample(f *Frame, tf Timeframe, cal *calendar.Calendar, now time.Time, keepPartial bool) (*Frame, bool) {
+	if tf == Daily || tf == "" {
+		return f, false
+	}
+
+	completed := cal.LastCompletedSession(now)
+	out := NewFrame(f.Len()/4 + 1)
+	partial := false
+	var period time.Time
+	for i := 0; i < f.Len(); i++ {
+		if f.Close[i] <= 0 {
+			continue
+		}
+		last := out.Len() - 1
+		if start := periodStart(f.Dates[i], tf, cal); last < 0 || !start.Equal(period) {
+			// Only the latest period can still be open
+			if periodLastSession(start, tf, cal).After(completed) {
+				if !keepPartial {
+					break
+				}
+				partial = true
+			}
+			period = start
+			change, changePercent := 0.0, 0.0
+			if last >= 0 {
+				prevClose := out.Close[last]
+				change = numeric.Round(f.Close[i]-prevClose, 4)
+				changePercent = numeric.Round(change/prevClose*100, 2)
+			}
+			out.Dates = append(out.Dates, f.Dates[i])
+			out.Open = append(out.Open, f.Open[i])
+			out.High = append(out.High, f.High[i])
+			out.Low = append(out.Low, f.Low[i])
+			out.Close = append(out.Close, f.Close[i])
+			out.Change = append(out.Change, change)
+			out.ChangePercent = append(out.ChangePercent, changePercent)
+			out.Volume = append(out.Volume, f.Volume[i])
+			out.Trades = append(out.Trades, f.Trades[i])
+			continue
+		}
+
+		out.Dates[last] = f.Dates[i]
+		out.High[last] = max(out.High[last], f.High[i])
+		if f.Low[i] > 0 && (out.Low[last] <= 0 || f.Low[i] < out.Low[last]) {
+			out.Low[last] = f.Low[i]
+		}
+		out.Close[last] = f.Close[i]
+		out.Volume[last] += f.Volume[i]
+		out.Trades[last] += f.Trades[i]
+		if last > 0 {
+			prevClose := out.Close[last-1]
+			out.Change[last] = numeric.Round(f.Close[i]-prevClose, 4)
+			out.ChangePercent[last] = numeric.Round((f.Close[i]-prevClose)/prevClose*100, 2)
+		}
+	}
+	return out, partial
+}
+
+// periodStart returns the first day of the week or month holding t
+func periodStart(t time.Time, tf Timeframe, cal *calendar.Calendar) time.Time {
+	if tf == Monthly {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+	return cal.WeekStart(t)
+}
+
+// periodLastSession returns the last scheduled session of the period starting at start
+func periodLastSession(start time.Time, tf Timeframe, cal *calendar.Calendar) time.Time {
+	if tf == Monthly {
+		return cal.LastSession(start.AddDate(0, 1, -1))
+	}
+	return cal.LastSession(start.AddDate(0, 0, 6))
+}
@@ -0,0 +1,96 @@
+package indicators
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stateVersion changes whenever a stepper changes the meaning of its saved fields
+const stateVersion = 3
+
+// indicatorState is saved next to an indicator file so new bars can be added without the full history
+type indicatorState struct {
+	Version     int                        `json:"version"`
+	Rows        int                        `json:"rows"`
+	LastDate    time.Time                  `json:"last_date"`
+	Fingerprint string                     `json:"fingerprint"` // Hash of the raw bars the file was built from
+	Columns     []string                   `json:"columns"`
+	Steppers    map[string]json.RawMessage `json:"steppers"`           // Keyed by spec, e.g. "MACD(12,26,9)"
+	Language    string                     `json:"language,omitempty"` // Language of the descriptions; "" is English
+}
+
+// StatePath returns the state file kept next to an indicator file
+func StatePath(indicatorsFilePath string) string {
+	return strings.TrimSuffix(indicatorsFilePath, ".csv") + ".state.json"
+}
+
+// newIndicatorState captures the stepper states after the first rows bars of f
+func newIndicatorState(f *Frame, rows int, columns []string, specs []Spec, steppers []Stepper) (*indicatorState, error) {
+	state := &indicatorState{
+		Version:     stateVersion,
+		Rows:        rows,
+		LastDate:    f.Dates[rows-1],
+		Fingerprint: fingerprint(f, rows),
+		Columns:     columns,
+		Steppers:    make(map[string]json.RawMessage, len(specs)),
+	}
+	for k, spec := range specs {
+		data, err := json.Marshal(steppers[k])
+		if err != nil {
+			return nil, err
+		}
+		state.Steppers[spec.String()] = data
+	}
+	return state, nil
+}
+
+// loadIndicatorState reads a state file
+func loadIndicatorState(filePath string) (*indicatorState, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var state indicatorState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// save writes the state through a temporary file so a crash never leaves half a state behind
+func (s *indicatorState) save(filePath string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	tempPath := filePath + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, filePath)
+}
+
+// fingerprint hashes the first rows bars; any edit to them changes the result
+func fingerprint(f *Frame, rows int) string {
+	h := sha256.New()
+	buf := make([]byte, 0, 128)
+	for i := 0; i < rows; i++ {
+		buf = append(buf[:0], f.Dates[i].Format("2006-01-02")...)
+		for _, v := range []float64{f.Open[i], f.High[i], f.Low[i], f.Close[i], f.Change[i], f.ChangePercent[i]} {
+			buf = append(buf, ',')
+			buf = strconv.AppendFloat(buf, v, 'g', -1, 64)
+		}
+		buf = append(buf, ',')
+		buf = strconv.AppendInt(buf, f.Volume[i], 10)
+		buf = append(buf, ',')
+		buf = strconv.AppendInt(buf, f.Trades[i], 10)
+		buf = append(buf, '\n')
+		h.Write(buf)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
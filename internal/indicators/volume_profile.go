@@ -0,0 +1,148 @@
+package indicators
+
+import (
+	"math"
+
+	"isx-auto-scrapper/internal/numeric"
+)
+
+// valueAreaShare is the part of the volume the value area holds around the point of control
+const valueAreaShare = 0.7
+
+// ProfileBin is the volume traded in one price band
+type ProfileBin struct {
+	Low        float64 `json:"low"`
+	High       float64 `json:"high"`
+	Volume     float64 `json:"volume"`
+	UpVolume   float64 `json:"up_volume"`   // From bars closing at or above their open
+	DownVolume float64 `json:"down_volume"` // From bars closing below their open
+}
+
+// VolumeProfile is the volume traded at each price over a window of bars
+type VolumeProfile struct {
+	From          string       `json:"from"`
+	To            string       `json:"to"`
+	Bars          int          `json:"bars"`
+	Low           float64      `json:"low"`
+	High          float64      `json:"high"`
+	Volume        float64      `json:"volume"`
+	POC           float64      `json:"poc"` // Middle of the band with the most volume
+	ValueAreaLow  float64      `json:"value_area_low"`
+	ValueAreaHigh float64      `json:"value_area_high"`
+	Bins          []ProfileBin `json:"bins"`
+}
+
+// NewVolumeProfile builds the profile of the last bars bars in bins equal price bands.
+// Daily bars have no intraday prices, so each bar's volume is spread evenly over its high-low range.
+// Bars without prices or volume are skipped.
+func NewVolumeProfile(f *Frame, bars, bins int) *VolumeProfile {
+	from := max(f.Len()-bars, 0)
+	var used []int
+	low, high := math.Inf(1), math.Inf(-1)
+	for i := from; i < f.Len(); i++ {
+		if f.Low[i] <= 0 || f.High[i] < f.Low[i] || f.Volume[i] <= 0 {
+			continue
+		}
+		used = append(used, i)
+		low = math.Min(low, f.Low[i])
+		high = math.Max(high, f.High[i])
+	}
+
+	profile := &VolumeProfile{Bars: len(used), Bins: []ProfileBin{}}
+	if len(used) == 0 {
+		return profile
+	}
+	profile.From = f.Dates[used[0]].Format("2006-01-02")
+	profile.To = f.Dates[used[len(used)-1]].Format("2006-01-02")
+	profile.Low, profile.High = low, high
+
+	// A window traded at a single price has one band
+	if high == low {
+		bins = 1
+	}
+	width := (high - low) / float64(bins)
+	profile.Bins = make([]ProfileBin, bins)
+	for b := range profile.Bins {
+		profile.Bins[b].Low = low + float64(b)*width
+		profile.Bins[b].High = low + float64(b+1)*width
+	}
+	profile.Bins[bins-1].High = high
+
+	for _, i := range used {
+		volume := float64(f.Volume[i])
+		profile.Volume += volume
+		first, last := profile.bin(f.Low[i], width), profile.bin(f.High[i], width)
+		for b := first; b <= last; b++ {
+			share := 1.0
+			if f.High[i] > f.Low[i] {
+				overlap := math.Min(f.High[i], profile.Bins[b].High) - math.Max(f.Low[i], profile.Bins[b].Low)
+				share = math.Max(overlap, 0) / (f.High[i] - f.Low[i])
+			} else if b != first {
+				break
+			}
+			profile.Bins[b].Volume += volume * share
+			if f.Close[i] >= f.Open[i] {
+				profile.Bins[b].UpVolume += volume * share
+			} else {
+				profile.Bins[b].DownVolume += volume * share
+			}
+		}
+	}
+
+	profile.valueArea()
+	profile.round()
+	return profile
+}
+
+// bin returns the band holding price
+func (p *VolumeProfile) bin(price, width float64) int {
+	if width == 0 {
+		return 0
+	}
+	return min(max(int((price-p.Low)/width), 0), len(p.Bins)-1)
+}
+
+// valueArea sets the point of control and widens the value area from it,
+// one band at a time towards the busier neighbour, until it holds valueAreaShare of the volume
+func (p *VolumeProfile) valueArea() {
+	poc := 0
+	for b, bin := range p.Bins {
+		if bin.Volume > p.Bins[poc].Volume {
+			poc = b
+		}
+	}
+	p.POC = (p.Bins[poc].Low + p.Bins[poc].High) / 2
+
+	lo, hi := poc, poc
+	volume := p.Bins[poc].Volume
+	for volume < valueAreaShare*p.Volume && (lo > 0 || hi < len(p.Bins)-1) {
+		below, above := -1.0, -1.0
+		if lo > 0 {
+			below = p.Bins[lo-1].Volume
+		}
+		if hi < len(p.Bins)-1 {
+			above = p.Bins[hi+1].Volume
+		}
+		if above >= below {
+			hi++
+			volume += above
+		} else {
+			lo--
+			volume += below
+		}
+	}
+	p.ValueAreaLow, p.ValueAreaHigh = p.Bins[lo].Low, p.Bins[hi].High
+}
+
+// round rounds prices to 4 decimals and volumes to whole shares for output
+func (p *VolumeProfile) round() {
+	p.POC = numeric.Round(p.POC, 4)
+	p.ValueAreaLow = numeric.Round(p.ValueAreaLow, 4)
+	p.ValueAreaHigh = numeric.Round(p.ValueAreaHigh, 4)
+	for b := range p.Bins {
+		bin := &p.Bins[b]
+		bin.Low, bin.High = numeric.Round(bin.Low, 4), numeric.Round(bin.High, 4)
+		bin.Volume = numeric.Round(bin.Volume, 0)
+		bin.UpVolume, bin.DownVolume = numeric.Round(bin.UpVolume, 0), numeric.Round(bin.DownVolume, 0)
+	}
+}
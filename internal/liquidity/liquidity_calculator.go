@@ -10,8 +10,10 @@ import (
 	"github.com/gocarina/gocsv"
 	"github.com/shopspring/decimal"
 
+	"isx-auto-scrapper/internal/calendar"
 	"isx-auto-scrapper/internal/common"
 	"isx-auto-scrapper/internal/indicators"
+	"isx-auto-scrapper/internal/numeric"
 )
 
 // LiquidityScoreRecord represents a liquidity score record for a ticker
@@ -36,13 +38,17 @@ type LiquidityScoreRecord struct {
 
 // LiquidityCalc handles liquidity score calculations (separate from the stub in data_calculator.go)
 type LiquidityCalc struct {
-	logger *common.Logger
+	logger   *common.Logger
+	calendar *calendar.Calendar
+	now      time.Time // Ends the 12-month window
 }
 
 // NewLiquidityCalc creates a new LiquidityCalc instance
-func NewLiquidityCalc() *LiquidityCalc {
+func NewLiquidityCalc(logger *common.Logger) *LiquidityCalc {
 	return &LiquidityCalc{
-		logger: common.NewLogger(),
+		logger:   logger,
+		calendar: calendar.Default(),
+		now:      time.Now(),
 	}
 }
 
@@ -89,23 +95,7 @@ func (lc *LiquidityCalc) CalculateScores() error {
 
 // loadTickers loads ticker symbols from TICKERS.csv
 func (lc *LiquidityCalc) loadTickers() ([]string, error) {
-	file, err := os.Open("TICKERS.csv")
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	var tickers []common.TickerInfo
-	if err := gocsv.UnmarshalFile(file, &tickers); err != nil {
-		return nil, err
-	}
-
-	var tickerSymbols []string
-	for _, ticker := range tickers {
-		tickerSymbols = append(tickerSymbols, ticker.Symbol)
-	}
-
-	return tickerSymbols, nil
+	return common.LoadTickers("TICKERS.csv")
 }
 
 // calculateTickerLiquidity calculates liquidity metrics for a single ticker
@@ -128,11 +118,11 @@ func (lc *LiquidityCalc) calculateTickerLiquidity(ticker string) (*LiquidityScor
 		return nil, nil
 	}
 
-	// Filter data to last 12 months
-	oneYearAgo := time.Now().AddDate(-1, 0, 0)
+	// Filter data to the last 12 months of ISX sessions
+	windowStart, _, sessionsInYear := lc.calendar.YearWindow(lc.now)
 	var last12MonthsData []*StockDataForLiquidity
 	for _, data := range stockData {
-		if data.Date.After(oneYearAgo) {
+		if !data.Date.Before(windowStart) {
 			last12MonthsData = append(last12MonthsData, data)
 		}
 	}
@@ -160,28 +150,28 @@ func (lc *LiquidityCalc) calculateTickerLiquidity(ticker string) (*LiquidityScor
 	marketImpactScore := lc.calculateMarketImpactScore(last12MonthsData)
 	intradayVolatilityScore := lc.calculateIntradayVolatilityScore(last12MonthsData)
 
-	lc.logger.Info("Average volume traded for %s is %s", ticker, averageTradedVolume.String())
+	lc.logger.Info("Average volume traded for %s is %s", ticker, numeric.Decimal(averageTradedVolume).String())
 
-	// Calculate trading activity score
-	tradingActivityScore := decimal.NewFromInt(int64(daysTraded)).Div(decimal.NewFromInt(252))
+	// Calculate trading activity score (share of the year's sessions with a trade)
+	tradingActivityScore := float64(daysTraded) / float64(sessionsInYear)
 	if daysTraded < 100 {
-		tradingActivityScore = decimal.Zero
+		tradingActivityScore = 0
 	}
 
 	return &LiquidityScoreRecord{
 		Ticker:                  ticker,
-		AverageVolume:           averageVolume,
-		AverageTradedVolume:     averageTradedVolume,
-		TimeWeightedVolume:      timeWeightedVolume,
-		VolumeSTD:               volumeSTD,
+		AverageVolume:           numeric.Decimal(averageVolume),
+		AverageTradedVolume:     numeric.Decimal(averageTradedVolume),
+		TimeWeightedVolume:      numeric.Decimal(timeWeightedVolume),
+		VolumeSTD:               numeric.Decimal(volumeSTD),
 		DaysTraded:              daysTraded,
 		DaysTraded2:             daysTraded2,
 		ZeroVolumeDays:          zeroVolumeDays,
-		TradingActivityScore:    tradingActivityScore,
-		VolumeConsistencyScore:  volumeConsistencyScore,
-		ZeroVolumePenalty:       zeroVolumePenalty,
-		MarketImpactScore:       marketImpactScore,
-		IntradayVolatilityScore: intradayVolatilityScore,
+		TradingActivityScore:    numeric.Decimal(tradingActivityScore),
+		VolumeConsistencyScore:  numeric.Decimal(volumeConsistencyScore),
+		ZeroVolumePenalty:       numeric.Decimal(zeroVolumePenalty),
+		MarketImpactScore:       numeric.Decimal(marketImpactScore),
+		IntradayVolatilityScore: numeric.Decimal(intradayVolatilityScore),
 		RelativeVolumeScore:     decimal.Zero, // Will be calculated later
 		LiquidityScore:          decimal.Zero, // Will be calculated later
 		LiquidityScorePercent:   decimal.Zero, // Will be calculated later
@@ -190,41 +180,36 @@ func (lc *LiquidityCalc) calculateTickerLiquidity(ticker string) (*LiquidityScor
 
 // StockDataForLiquidity represents stock data needed for liquidity calculations
 type StockDataForLiquidity struct {
-	Date          time.Time       `csv:"Date"`
-	Close         decimal.Decimal `csv:"Close"`
-	Open          decimal.Decimal `csv:"Open"`
-	High          decimal.Decimal `csv:"High"`
-	Low           decimal.Decimal `csv:"Low"`
-	Change        decimal.Decimal `csv:"Change"`
-	ChangePercent decimal.Decimal `csv:"Change%"`
-	Volume        int64           `csv:"Volume"`
+	Date          time.Time
+	Close         float64
+	Open          float64
+	High          float64
+	Low           float64
+	Change        float64
+	ChangePercent float64
+	Volume        int64
 }
 
 // loadStockDataForLiquidity loads stock data specifically for liquidity calculations
 func (lc *LiquidityCalc) loadStockDataForLiquidity(filePath string) ([]*StockDataForLiquidity, error) {
-	file, err := os.Open(filePath)
+	// Cleaned like the indicator inputs, so bars with a missing price do not distort the price-based factors
+	frame, err := indicators.LoadRawFrame(filePath)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
-
-	var rawData []*indicators.StockDataCSV
-	if err := gocsv.UnmarshalFile(file, &rawData); err != nil {
-		return nil, err
-	}
 
 	// Convert to liquidity-specific data structure
 	var stockData []*StockDataForLiquidity
-	for _, data := range rawData {
+	for i := 0; i < frame.Len(); i++ {
 		stockData = append(stockData, &StockDataForLiquidity{
-			Date:          data.Date.Time,
-			Close:         data.Close,
-			Open:          data.Open,
-			High:          data.High,
-			Low:           data.Low,
-			Change:        data.Change,
-			ChangePercent: indicators.ParsePercentage(data.ChangePercent),
-			Volume:        data.Volume,
+			Date:          frame.Dates[i],
+			Close:         frame.Close[i],
+			Open:          frame.Open[i],
+			High:          frame.High[i],
+			Low:           frame.Low[i],
+			Change:        frame.Change[i],
+			ChangePercent: frame.ChangePercent[i],
+			Volume:        frame.Volume[i],
 		})
 	}
 
@@ -237,57 +222,35 @@ func (lc *LiquidityCalc) loadStockDataForLiquidity(filePath string) ([]*StockDat
 }
 
 // calculateAverageVolume calculates the average volume for a dataset
-func (lc *LiquidityCalc) calculateAverageVolume(data []*StockDataForLiquidity) decimal.Decimal {
+func (lc *LiquidityCalc) calculateAverageVolume(data []*StockDataForLiquidity) float64 {
 	if len(data) == 0 {
-		return decimal.Zero
+		return 0
 	}
 
-	total := decimal.Zero
+	total := 0.0
 	for _, d := range data {
-		total = total.Add(decimal.NewFromInt(d.Volume))
+		total += float64(d.Volume)
 	}
 
-	return total.Div(decimal.NewFromInt(int64(len(data))))
+	return total / float64(len(data))
 }
 
 // calculateVolumeSTD calculates the standard deviation of volume percentage changes
-func (lc *LiquidityCalc) calculateVolumeSTD(data []*StockDataForLiquidity) decimal.Decimal {
+func (lc *LiquidityCalc) calculateVolumeSTD(data []*StockDataForLiquidity) float64 {
 	if len(data) <= 1 {
-		return decimal.Zero
+		return 0
 	}
 
 	// Calculate percentage changes
-	var pctChanges []decimal.Decimal
+	var pctChanges []float64
 	for i := 1; i < len(data); i++ {
 		if data[i-1].Volume > 0 {
-			prevVolume := decimal.NewFromInt(data[i-1].Volume)
-			currVolume := decimal.NewFromInt(data[i].Volume)
-			pctChange := currVolume.Sub(prevVolume).Div(prevVolume)
-			pctChanges = append(pctChanges, pctChange)
+			prevVolume := float64(data[i-1].Volume)
+			pctChanges = append(pctChanges, (float64(data[i].Volume)-prevVolume)/prevVolume)
 		}
 	}
 
-	if len(pctChanges) == 0 {
-		return decimal.Zero
-	}
-
-	// Calculate mean
-	sum := decimal.Zero
-	for _, change := range pctChanges {
-		sum = sum.Add(change)
-	}
-	mean := sum.Div(decimal.NewFromInt(int64(len(pctChanges))))
-
-	// Calculate variance
-	variance := decimal.Zero
-	for _, change := range pctChanges {
-		diff := change.Sub(mean)
-		variance = variance.Add(diff.Mul(diff))
-	}
-	variance = variance.Div(decimal.NewFromInt(int64(len(pctChanges))))
-
-	// Return standard deviation (square root of variance)
-	return lc.sqrt(variance)
+	return numeric.Std(pctChanges)
 }
 
 // removeVolumeOutliers removes the top and bottom 5% of volume data
@@ -334,68 +297,60 @@ func (lc *LiquidityCalc) calculateRelativeScores(scores []*LiquidityScoreRecord)
 	}
 
 	// Find maximum average traded volume for normalization
-	maxVolume := decimal.Zero
+	maxVolume := 0.0
 	for _, score := range scores {
-		if score.AverageTradedVolume.GreaterThan(maxVolume) {
-			maxVolume = score.AverageTradedVolume
-		}
+		maxVolume = math.Max(maxVolume, numeric.Float(score.AverageTradedVolume))
 	}
 
 	// Calculate relative volume scores and enhanced liquidity scores
-	totalLiquidityScore := decimal.Zero
-	for _, score := range scores {
+	liquidity := make([]float64, len(scores))
+	totalLiquidityScore := 0.0
+	for i, score := range scores {
 		// Relative Volume Score (normalized to 0-1)
-		if !maxVolume.IsZero() {
-			score.RelativeVolumeScore = score.AverageTradedVolume.Div(maxVolume)
+		relativeVolume := 0.0
+		if maxVolume != 0 {
+			relativeVolume = numeric.Float(score.AverageTradedVolume) / maxVolume
 		}
+		score.RelativeVolumeScore = numeric.Decimal(relativeVolume)
 
 		// Enhanced Liquidity Score using weighted combination
-		score.LiquidityScore = lc.calculateEnhancedLiquidityScore(score)
-		totalLiquidityScore = totalLiquidityScore.Add(score.LiquidityScore)
+		liquidity[i] = lc.calculateEnhancedLiquidityScore(score, relativeVolume)
+		score.LiquidityScore = numeric.Decimal(liquidity[i])
+		totalLiquidityScore += liquidity[i]
 	}
 
 	// Calculate liquidity score percentages
-	for _, score := range scores {
-		if !totalLiquidityScore.IsZero() {
-			score.LiquidityScorePercent = score.LiquidityScore.Div(totalLiquidityScore)
+	for i, score := range scores {
+		if totalLiquidityScore != 0 {
+			score.LiquidityScorePercent = numeric.Decimal(liquidity[i] / totalLiquidityScore)
 		}
 	}
 }
 
 // calculateEnhancedLiquidityScore calculates the final weighted liquidity score
-func (lc *LiquidityCalc) calculateEnhancedLiquidityScore(score *LiquidityScoreRecord) decimal.Decimal {
+func (lc *LiquidityCalc) calculateEnhancedLiquidityScore(score *LiquidityScoreRecord, relativeVolume float64) float64 {
 	// Weighted combination of all factors
-	weights := map[string]decimal.Decimal{
-		"tradingActivity":       decimal.NewFromFloat(0.25), // 25% - trading frequency
-		"volumeConsistency":     decimal.NewFromFloat(0.20), // 20% - predictable volume
-		"relativeVolume":        decimal.NewFromFloat(0.20), // 20% - volume size
-		"marketImpact":          decimal.NewFromFloat(0.15), // 15% - price impact
-		"intradayVolatility":    decimal.NewFromFloat(0.10), // 10% - intraday stability
-		"timeWeightedRelevance": decimal.NewFromFloat(0.10), // 10% - recent activity
-	}
+	w := common.AppConfig.Liquidity.Weights
 
 	// Calculate time-weighted relevance score (based on recent vs average volume)
-	timeWeightedRelevance := decimal.Zero
-	if !score.AverageTradedVolume.IsZero() {
-		timeWeightedRelevance = score.TimeWeightedVolume.Div(score.AverageTradedVolume)
-		if timeWeightedRelevance.GreaterThan(decimal.NewFromInt(1)) {
-			timeWeightedRelevance = decimal.NewFromInt(1)
-		}
+	timeWeightedRelevance := 0.0
+	if averageTradedVolume := numeric.Float(score.AverageTradedVolume); averageTradedVolume != 0 {
+		timeWeightedRelevance = math.Min(numeric.Float(score.TimeWeightedVolume)/averageTradedVolume, 1)
 	}
 
 	// Calculate weighted score
-	enhancedScore := weights["tradingActivity"].Mul(score.TradingActivityScore).
-		Add(weights["volumeConsistency"].Mul(score.VolumeConsistencyScore)).
-		Add(weights["relativeVolume"].Mul(score.RelativeVolumeScore)).
-		Add(weights["marketImpact"].Mul(score.MarketImpactScore)).
-		Add(weights["intradayVolatility"].Mul(score.IntradayVolatilityScore)).
-		Add(weights["timeWeightedRelevance"].Mul(timeWeightedRelevance))
+	enhancedScore := w.TradingActivity*numeric.Float(score.TradingActivityScore) + // trading frequency
+		w.VolumeConsistency*numeric.Float(score.VolumeConsistencyScore) + // predictable volume
+		w.RelativeVolume*relativeVolume + // volume size
+		w.MarketImpact*numeric.Float(score.MarketImpactScore) + // price impact
+		w.IntradayVolatility*numeric.Float(score.IntradayVolatilityScore) + // intraday stability
+		w.TimeWeightedRelevance*timeWeightedRelevance // recent activity
 
 	// Apply zero-volume penalty (multiply by penalty factor)
-	enhancedScore = enhancedScore.Mul(score.ZeroVolumePenalty)
+	enhancedScore *= numeric.Float(score.ZeroVolumePenalty)
 
 	// Scale to 0-100
-	return enhancedScore.Mul(decimal.NewFromInt(100))
+	return enhancedScore * 100
 }
 
 // saveLiquidityScores saves liquidity scores to CSV file
@@ -421,162 +376,106 @@ func (lc *LiquidityCalc) countZeroVolumeDays(data []*StockDataForLiquidity) int
 }
 
 // calculateTimeWeightedVolume calculates volume with exponential time weighting
-func (lc *LiquidityCalc) calculateTimeWeightedVolume(data []*StockDataForLiquidity) decimal.Decimal {
+func (lc *LiquidityCalc) calculateTimeWeightedVolume(data []*StockDataForLiquidity) float64 {
 	if len(data) == 0 {
-		return decimal.Zero
+		return 0
 	}
 
-	totalWeightedVolume := decimal.Zero
-	totalWeight := decimal.Zero
+	totalWeightedVolume := 0.0
+	totalWeight := 0.0
 
 	for i, d := range data {
 		// More recent data gets higher weight (exponential decay with 90-day half-life)
 		daysFromMostRecent := len(data) - i - 1
-		weight := decimal.NewFromFloat(math.Exp(-float64(daysFromMostRecent) / 90.0))
+		weight := math.Exp(-float64(daysFromMostRecent) / 90.0)
 
-		weightedVolume := decimal.NewFromInt(d.Volume).Mul(weight)
-		totalWeightedVolume = totalWeightedVolume.Add(weightedVolume)
-		totalWeight = totalWeight.Add(weight)
+		totalWeightedVolume += float64(d.Volume) * weight
+		totalWeight += weight
 	}
 
-	if totalWeight.IsZero() {
-		return decimal.Zero
+	if totalWeight == 0 {
+		return 0
 	}
 
-	return totalWeightedVolume.Div(totalWeight)
+	return totalWeightedVolume / totalWeight
 }
 
 // calculateVolumeConsistencyScore calculates how consistent the volume is
-func (lc *LiquidityCalc) calculateVolumeConsistencyScore(data []*StockDataForLiquidity) decimal.Decimal {
+func (lc *LiquidityCalc) calculateVolumeConsistencyScore(data []*StockDataForLiquidity) float64 {
 	if len(data) <= 1 {
-		return decimal.Zero
+		return 0
 	}
 
 	avgVolume := lc.calculateAverageVolume(data)
-	if avgVolume.IsZero() {
-		return decimal.Zero
+	if avgVolume == 0 {
+		return 0
 	}
 
-	stdDev := lc.calculateVolumeSTD(data)
-
 	// Coefficient of Variation
-	cv := stdDev.Div(avgVolume)
+	cv := lc.calculateVolumeSTD(data) / avgVolume
 
 	// Convert to consistency score: 1/(1+CV) gives 0-1 scale where 1 = perfect consistency
-	return decimal.NewFromInt(1).Div(decimal.NewFromInt(1).Add(cv))
+	return 1 / (1 + cv)
 }
 
 // calculateZeroVolumePenalty calculates penalty for days with zero volume
-func (lc *LiquidityCalc) calculateZeroVolumePenalty(data []*StockDataForLiquidity) decimal.Decimal {
+func (lc *LiquidityCalc) calculateZeroVolumePenalty(data []*StockDataForLiquidity) float64 {
 	if len(data) == 0 {
-		return decimal.Zero
+		return 0
 	}
 
-	zeroVolumeDays := lc.countZeroVolumeDays(data)
-	penaltyRate := decimal.NewFromInt(int64(zeroVolumeDays)).Div(decimal.NewFromInt(int64(len(data))))
+	penaltyRate := float64(lc.countZeroVolumeDays(data)) / float64(len(data))
 
 	// Return multiplier (1.0 = no penalty, 0.0 = maximum penalty)
-	return decimal.NewFromInt(1).Sub(penaltyRate)
+	return 1 - penaltyRate
 }
 
 // calculateMarketImpactScore calculates how much volume affects price
-func (lc *LiquidityCalc) calculateMarketImpactScore(data []*StockDataForLiquidity) decimal.Decimal {
+func (lc *LiquidityCalc) calculateMarketImpactScore(data []*StockDataForLiquidity) float64 {
 	if len(data) <= 1 {
-		return decimal.Zero
+		return 0
 	}
 
-	var impacts []decimal.Decimal
 	avgVolume := lc.calculateAverageVolume(data)
-
-	if avgVolume.IsZero() {
-		return decimal.Zero
+	if avgVolume == 0 {
+		return 0
 	}
 
+	var impacts []float64
 	for _, d := range data {
-		if d.Volume > 0 && !d.ChangePercent.IsZero() {
-			// Price change percentage (absolute value)
-			priceChangePercent := d.ChangePercent.Abs()
-
-			// Volume relative to average
-			volumeRatio := decimal.NewFromInt(d.Volume).Div(avgVolume)
-
-			// Market impact = price change per unit of relative volume
-			if !volumeRatio.IsZero() {
-				impact := priceChangePercent.Div(volumeRatio)
-				impacts = append(impacts, impact)
-			}
+		if d.Volume > 0 && d.ChangePercent != 0 {
+			// Market impact = absolute price change per unit of volume relative to average
+			volumeRatio := float64(d.Volume) / avgVolume
+			impacts = append(impacts, math.Abs(d.ChangePercent)/volumeRatio)
 		}
 	}
 
 	if len(impacts) == 0 {
-		return decimal.Zero
-	}
-
-	// Calculate average impact
-	sum := decimal.Zero
-	for _, impact := range impacts {
-		sum = sum.Add(impact)
+		return 0
 	}
-	avgImpact := sum.Div(decimal.NewFromInt(int64(len(impacts))))
 
-	// Convert to 0-1 score where lower impact = higher liquidity
-	// Use exponential decay to convert to score
-	impactFloat, _ := avgImpact.Float64()
-	score := math.Exp(-impactFloat / 2.0)
-
-	return decimal.NewFromFloat(score)
+	// Convert the average impact to a 0-1 score where lower impact = higher liquidity
+	return math.Exp(-numeric.Mean(impacts) / 2.0)
 }
 
 // calculateIntradayVolatilityScore calculates intraday price volatility
-func (lc *LiquidityCalc) calculateIntradayVolatilityScore(data []*StockDataForLiquidity) decimal.Decimal {
+func (lc *LiquidityCalc) calculateIntradayVolatilityScore(data []*StockDataForLiquidity) float64 {
 	if len(data) == 0 {
-		return decimal.Zero
+		return 0
 	}
 
-	var ranges []decimal.Decimal
-
+	var ranges []float64
 	for _, d := range data {
-		if !d.High.IsZero() && !d.Low.IsZero() && !d.Close.IsZero() {
-			// True Range calculation as percentage of close price
-			hl := d.High.Sub(d.Low)
-			rangePct := hl.Div(d.Close)
-			ranges = append(ranges, rangePct)
+		if d.High != 0 && d.Low != 0 && d.Close != 0 {
+			// High-low range as percentage of close price
+			ranges = append(ranges, (d.High-d.Low)/d.Close)
 		}
 	}
 
 	if len(ranges) == 0 {
-		return decimal.Zero
-	}
-
-	// Calculate average range
-	sum := decimal.Zero
-	for _, r := range ranges {
-		sum = sum.Add(r)
-	}
-	avgRange := sum.Div(decimal.NewFromInt(int64(len(ranges))))
-
-	// Convert to 0-1 score where lower volatility = higher liquidity
-	rangeFloat, _ := avgRange.Float64()
-	score := math.Exp(-rangeFloat * 10.0)
-
-	return decimal.NewFromFloat(score)
-}
-
-// sqrt calculates square root using Newton's method for decimal
-func (lc *LiquidityCalc) sqrt(x decimal.Decimal) decimal.Decimal {
-	if x.LessThanOrEqual(decimal.Zero) {
-		return decimal.Zero
-	}
-
-	// Newton's method for square root
-	guess := x.Div(decimal.NewFromInt(2))
-	for i := 0; i < 10; i++ { // 10 iterations should be enough for precision
-		newGuess := guess.Add(x.Div(guess)).Div(decimal.NewFromInt(2))
-		if newGuess.Sub(guess).Abs().LessThan(decimal.NewFromFloat(0.0001)) {
-			break
-		}
-		guess = newGuess
+		return 0
 	}
 
-	return guess
+	// Convert the average range to a 0-1 score where lower volatility = higher liquidity
+	return math.Exp(-numeric.Mean(ranges) * 10.0)
 }
@@ -0,0 +1,119 @@
+package liquidity
+
+import (
+	"encoding/csv"
+	"io"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"isx-auto-scrapper/internal/common"
+)
+
+// testdata/liquidity_scores_decimal.csv was written by the decimal calculator the float64 one replaced
+// (commit 22ca65d) from the raw files beside it, its year window ending 2026-10-16 and its Newton square
+// root run to convergence. Scores are written unrounded, so each may differ from it by 1e-9 of its size.
+const decimalTolerance = 1e-9
+
+var scoreDate = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+// inFixtureDir runs the test in a temporary copy of testdata, the calculator reading its working directory
+func inFixtureDir(t testing.TB) {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range []string{"TICKERS.csv", "raw_BBOB.csv", "raw_BASH.csv", "raw_TASC.csv"} {
+		data, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func newTestCalc() *LiquidityCalc {
+	lc := NewLiquidityCalc(common.NewLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	lc.now = scoreDate
+	return lc
+}
+
+func readScores(t *testing.T, path string) [][]string {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return records
+}
+
+func TestScoresMatchDecimalEngine(t *testing.T) {
+	golden, err := filepath.Abs(filepath.Join("testdata", "liquidity_scores_decimal.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := readScores(t, golden)
+
+	inFixtureDir(t)
+	if err := newTestCalc().CalculateScores(); err != nil {
+		t.Fatal(err)
+	}
+	got := readScores(t, "liquidity_scores.csv")
+
+	if len(got) != len(want) {
+		t.Fatalf("liquidity_scores.csv has %d rows, decimal engine %d", len(got), len(want))
+	}
+	for k, name := range want[0] {
+		if got[0][k] != name {
+			t.Fatalf("column %d = %s, decimal engine %s", k, got[0][k], name)
+		}
+	}
+
+	for i, row := range want[1:] {
+		ticker := row[0]
+		if got[i+1][0] != ticker {
+			t.Fatalf("row %d is %s, decimal engine %s", i+1, got[i+1][0], ticker)
+		}
+		for k, cell := range row[1:] {
+			name := want[0][k+1]
+			wantValue, err := strconv.ParseFloat(cell, 64)
+			if err != nil {
+				t.Fatalf("%s %s: %v", ticker, name, err)
+			}
+			gotValue, err := strconv.ParseFloat(got[i+1][k+1], 64)
+			if err != nil {
+				t.Fatalf("%s %s: %v", ticker, name, err)
+			}
+			if math.Abs(gotValue-wantValue) > decimalTolerance*max(1, math.Abs(wantValue)) {
+				t.Errorf("%s %s = %v, decimal engine %s", ticker, name, gotValue, cell)
+			}
+		}
+	}
+}
+
+func BenchmarkCalculateScores(b *testing.B) {
+	inFixtureDir(b)
+	lc := newTestCalc()
+	for i := 0; i < b.N; i++ {
+		if err := lc.CalculateScores(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package numeric
+
+import (
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Check is the outcome of running one fast function against its decimal reference
+type Check struct {
+	Name          string        `json:"name"`
+	Points        int           `json:"points"`
+	MaxError      float64       `json:"max_error"` // Absolute below 1, relative above
+	FastTime      time.Duration `json:"fast_ns"`
+	ReferenceTime time.Duration `json:"reference_ns"`
+	Passed        bool          `json:"passed"`
+}
+
+// Speedup returns how many times faster the fast path ran
+func (c Check) Speedup() float64 {
+	if c.FastTime <= 0 {
+		return 0
+	}
+	return float64(c.ReferenceTime) / float64(c.FastTime)
+}
+
+// CheckSeries compares the rolling functions over xs with window n against the decimal references
+func CheckSeries(label string, xs []decimal.Decimal, n int, tolerance float64) []Check {
+	floats := make([]float64, len(xs))
+	for i, x := range xs {
+		floats[i] = Float(x)
+	}
+
+	type pair struct {
+		name      string
+		fast      func([]float64, int) []float64
+		reference func([]decimal.Decimal, int) []decimal.Decimal
+	}
+	pairs := []pair{
+		{"SMA", RollingMean, ReferenceSMA},
+		{"STD", RollingStd, ReferenceStd},
+		{"MAX", RollingHighest, ReferenceHighest},
+		{"MIN", RollingLowest, ReferenceLowest},
+		{"EMA", EMA, ReferenceEMA},
+	}
+
+	checks := make([]Check, 0, len(pairs))
+	for _, p := range pairs {
+		start := time.Now()
+		got := p.fast(floats, n)
+		fastTime := time.Since(start)
+
+		start = time.Now()
+		want := p.reference(xs, n)
+		referenceTime := time.Since(start)
+
+		maxError := 0.0
+		for i := range want {
+			maxError = math.Max(maxError, relativeError(got[i], Float(want[i])))
+		}
+		checks = append(checks, Check{
+			Name:          label + " " + p.name,
+			Points:        len(xs),
+			MaxError:      maxError,
+			FastTime:      fastTime,
+			ReferenceTime: referenceTime,
+			Passed:        maxError <= tolerance,
+		})
+	}
+	return checks
+}
+
+// relativeError is the absolute difference, scaled by the expected value when that exceeds 1
+func relativeError(got, want float64) float64 {
+	return math.Abs(got-want) / math.Max(1, math.Abs(want))
+}
@@ -0,0 +1,89 @@
+// Package numeric holds the float64 maths shared by indicators, liquidity scores and backtests.
+// Values stay float64 while computing and become decimal only where they are stored.
+package numeric
+
+import (
+	"math"
+
+	"github.com/shopspring/decimal"
+)
+
+var pow10 = [...]float64{1, 10, 100, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8}
+
+// tieNudge moves values away from zero before rounding, so a float that lands just short of an
+// exact decimal half still rounds like decimal.Round would round the exact value
+const tieNudge = 1e-10
+
+// Round rounds v half away from zero to places decimals, like decimal.Round
+func Round(v float64, places int) float64 {
+	p := pow10[places]
+	r := math.Round(v*p*(1+tieNudge)) / p
+	if r == 0 || math.IsNaN(r) || math.IsInf(r, 0) {
+		return 0 // Never write -0, NaN or Inf
+	}
+	return r
+}
+
+// Decimal converts v for storage; NaN and Inf become zero
+func Decimal(v float64) decimal.Decimal {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return decimal.Zero
+	}
+	return decimal.NewFromFloat(v)
+}
+
+// Float converts a stored decimal for computing
+func Float(d decimal.Decimal) float64 {
+	return d.InexactFloat64()
+}
+
+// Sum returns the sum of xs
+func Sum(xs []float64) float64 {
+	total := 0.0
+	for _, x := range xs {
+		total += x
+	}
+	return total
+}
+
+// Mean returns the average of xs, or 0 for none
+func Mean(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	return Sum(xs) / float64(len(xs))
+}
+
+// Variance returns the population variance of xs
+func Variance(xs []float64) float64 {
+	return sumSquaredDeviations(xs) / float64(max(len(xs), 1))
+}
+
+// SampleVariance returns the sample variance of xs, or 0 for fewer than two values
+func SampleVariance(xs []float64) float64 {
+	if len(xs) < 2 {
+		return 0
+	}
+	return sumSquaredDeviations(xs) / float64(len(xs)-1)
+}
+
+// Std returns the population standard deviation of xs
+func Std(xs []float64) float64 {
+	return math.Sqrt(Variance(xs))
+}
+
+// SampleStd returns the sample standard deviation of xs
+func SampleStd(xs []float64) float64 {
+	return math.Sqrt(SampleVariance(xs))
+}
+
+// sumSquaredDeviations returns the sum of squared distances from the mean, in two passes for accuracy
+func sumSquaredDeviations(xs []float64) float64 {
+	mean := Mean(xs)
+	total := 0.0
+	for _, x := range xs {
+		d := x - mean
+		total += d * d
+	}
+	return total
+}
@@ -0,0 +1,79 @@
+package numeric
+
+import (
+	"math"
+
+	"github.com/shopspring/decimal"
+)
+
+// The reference functions recompute each window from scratch in decimal, as the calculators did
+// before the float64 engine; only the square root is taken in float64, as decimal has none.
+// They are slow on purpose and only used to check the fast path.
+
+// ReferenceSMA returns the decimal mean of each full window of n values
+func ReferenceSMA(xs []decimal.Decimal, n int) []decimal.Decimal {
+	out := make([]decimal.Decimal, len(xs))
+	for i := n - 1; i < len(xs); i++ {
+		out[i] = referenceMean(xs[i-n+1 : i+1])
+	}
+	return out
+}
+
+// ReferenceStd returns the decimal population standard deviation of each full window of n values
+func ReferenceStd(xs []decimal.Decimal, n int) []decimal.Decimal {
+	out := make([]decimal.Decimal, len(xs))
+	for i := n - 1; i < len(xs); i++ {
+		values := xs[i-n+1 : i+1]
+		mean := referenceMean(values)
+		variance := decimal.Zero
+		for _, x := range values {
+			diff := x.Sub(mean)
+			variance = variance.Add(diff.Mul(diff))
+		}
+		out[i] = decimal.NewFromFloat(math.Sqrt(variance.Div(decimal.NewFromInt(int64(n))).InexactFloat64()))
+	}
+	return out
+}
+
+// ReferenceHighest returns the decimal maximum of each full window of n values
+func ReferenceHighest(xs []decimal.Decimal, n int) []decimal.Decimal {
+	out := make([]decimal.Decimal, len(xs))
+	for i := n - 1; i < len(xs); i++ {
+		out[i] = decimal.Max(xs[i-n+1], xs[i-n+2:i+1]...)
+	}
+	return out
+}
+
+// ReferenceLowest returns the decimal minimum of each full window of n values
+func ReferenceLowest(xs []decimal.Decimal, n int) []decimal.Decimal {
+	out := make([]decimal.Decimal, len(xs))
+	for i := n - 1; i < len(xs); i++ {
+		out[i] = decimal.Min(xs[i-n+1], xs[i-n+2:i+1]...)
+	}
+	return out
+}
+
+// ReferenceEMA returns the decimal exponential moving average seeded with the first value
+func ReferenceEMA(xs []decimal.Decimal, n int) []decimal.Decimal {
+	out := make([]decimal.Decimal, len(xs))
+	k := decimal.NewFromFloat(2.0).Div(decimal.NewFromInt(int64(n + 1)))
+	one := decimal.NewFromInt(1)
+	for i, x := range xs {
+		if i == 0 {
+			out[i] = x
+			continue
+		}
+		// Exact products grow by a dozen digits a bar; 20 places keep the reference usable
+		out[i] = x.Mul(k).Add(out[i-1].Mul(one.Sub(k))).Round(20)
+	}
+	return out
+}
+
+// referenceMean returns the decimal average of xs
+func referenceMean(xs []decimal.Decimal) decimal.Decimal {
+	total := decimal.Zero
+	for _, x := range xs {
+		total = total.Add(x)
+	}
+	return total.Div(decimal.NewFromInt(int64(len(xs))))
+}
@@ -0,0 +1,44 @@
+package numeric
+
+import "math"
+
+// Returns returns the simple return between consecutive values, skipping steps from zero
+func Returns(values []float64) []float64 {
+	returns := make([]float64, 0, max(len(values)-1, 0))
+	for i := 1; i < len(values); i++ {
+		if values[i-1] != 0 {
+			returns = append(returns, (values[i]-values[i-1])/values[i-1])
+		}
+	}
+	return returns
+}
+
+// MaxDrawdown returns the deepest fall from a running peak, in percent of that peak
+func MaxDrawdown(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	maxDrawdown := 0.0
+	peak := values[0]
+	for _, v := range values {
+		peak = math.Max(peak, v)
+		if peak != 0 {
+			maxDrawdown = math.Max(maxDrawdown, (peak-v)/peak*100)
+		}
+	}
+	return maxDrawdown
+}
+
+// SharpeRatio annualises the mean over the sample standard deviation of returns
+func SharpeRatio(returns []float64, periodsPerYear int) float64 {
+	if len(returns) <= 1 {
+		return 0
+	}
+	std := SampleStd(returns)
+	if std == 0 {
+		return 0
+	}
+	n := float64(periodsPerYear)
+	return Mean(returns) * n / (std * math.Sqrt(n))
+}
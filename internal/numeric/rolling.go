@@ -0,0 +1,176 @@
+package numeric
+
+import "math"
+
+// Window keeps the last values of a series with their running sum and sum of squares.
+// Each push is O(1); the sums are recomputed once per window length to stop rounding drift.
+// Squares are taken relative to a recent value, so flat windows do not lose their variance
+// to cancellation. The exported fields are saved with the indicator state.
+type Window struct {
+	Values []float64 `json:"values"`
+	Sum    float64   `json:"sum"`
+	Shift  float64   `json:"shift"`  // Reference value of SumSq
+	SumSq  float64   `json:"sum_sq"` // Sum of (value - Shift)^2
+	Pushes int       `json:"pushes"`
+}
+
+// Push adds v, drops values beyond size and reports whether the window is full
+func (w *Window) Push(v float64, size int) bool {
+	if w.Pushes == 0 {
+		w.Shift = v
+	}
+	w.Values = append(w.Values, v)
+	w.Sum += v
+	w.SumSq += (v - w.Shift) * (v - w.Shift)
+	if len(w.Values) > size {
+		old := w.Values[0]
+		w.Sum -= old
+		w.SumSq -= (old - w.Shift) * (old - w.Shift)
+		w.Values = w.Values[1:]
+	}
+
+	w.Pushes++
+	if w.Pushes%size == 0 {
+		w.Sum, w.SumSq, w.Shift = 0, 0, v
+		for _, x := range w.Values {
+			w.Sum += x
+			w.SumSq += (x - v) * (x - v)
+		}
+	}
+	return len(w.Values) == size
+}
+
+// Mean returns the window average
+func (w *Window) Mean() float64 {
+	if len(w.Values) == 0 {
+		return 0
+	}
+	return w.Sum / float64(len(w.Values))
+}
+
+// Variance returns the population variance of the window
+func (w *Window) Variance() float64 {
+	n := float64(len(w.Values))
+	if n == 0 {
+		return 0
+	}
+	offset := w.Sum/n - w.Shift
+	return math.Max(w.SumSq/n-offset*offset, 0)
+}
+
+// Std returns the population standard deviation of the window
+func (w *Window) Std() float64 {
+	return math.Sqrt(w.Variance())
+}
+
+// deque is a monotonic deque of the values that can still become the window extreme
+type deque struct {
+	Bars   []int     `json:"bars"` // Bar number of each value, counted from the first push
+	Values []float64 `json:"values"`
+	Seen   int       `json:"seen"`
+}
+
+// push adds v, dropping values it dominates and values older than size bars
+func (d *deque) push(v float64, size int, dominates func(v, old float64) bool) {
+	n := len(d.Values)
+	for n > 0 && dominates(v, d.Values[n-1]) {
+		n--
+	}
+	d.Values = append(d.Values[:n], v)
+	d.Bars = append(d.Bars[:n], d.Seen)
+	d.Seen++
+
+	for d.Bars[0] <= d.Seen-1-size {
+		d.Bars = d.Bars[1:]
+		d.Values = d.Values[1:]
+	}
+}
+
+// RollingMax tracks the highest value of the last size values, O(1) amortised per push
+type RollingMax struct {
+	deque
+}
+
+// Push adds v and returns the highest value of the window
+func (m *RollingMax) Push(v float64, size int) float64 {
+	m.push(v, size, func(v, old float64) bool { return v >= old })
+	return m.Values[0]
+}
+
+// RollingMin tracks the lowest value of the last size values, O(1) amortised per push
+type RollingMin struct {
+	deque
+}
+
+// Push adds v and returns the lowest value of the window
+func (m *RollingMin) Push(v float64, size int) float64 {
+	m.push(v, size, func(v, old float64) bool { return v <= old })
+	return m.Values[0]
+}
+
+// RollingMean returns the mean of each full window of n values; earlier entries are 0
+func RollingMean(xs []float64, n int) []float64 {
+	out := make([]float64, len(xs))
+	var w Window
+	for i, x := range xs {
+		if w.Push(x, n) {
+			out[i] = w.Mean()
+		}
+	}
+	return out
+}
+
+// RollingStd returns the population standard deviation of each full window of n values
+func RollingStd(xs []float64, n int) []float64 {
+	out := make([]float64, len(xs))
+	var w Window
+	for i, x := range xs {
+		if w.Push(x, n) {
+			out[i] = w.Std()
+		}
+	}
+	return out
+}
+
+// RollingHighest returns the highest of each full window of n values
+func RollingHighest(xs []float64, n int) []float64 {
+	out := make([]float64, len(xs))
+	var m RollingMax
+	for i, x := range xs {
+		if high := m.Push(x, n); i >= n-1 {
+			out[i] = high
+		}
+	}
+	return out
+}
+
+// RollingLowest returns the lowest of each full window of n values
+func RollingLowest(xs []float64, n int) []float64 {
+	out := make([]float64, len(xs))
+	var m RollingMin
+	for i, x := range xs {
+		if low := m.Push(x, n); i >= n-1 {
+			out[i] = low
+		}
+	}
+	return out
+}
+
+// EMAMultiplier returns the smoothing factor 2/(period+1)
+func EMAMultiplier(period int) float64 {
+	return 2 / float64(period+1)
+}
+
+// EMA returns the exponential moving average of xs seeded with the first value
+func EMA(xs []float64, n int) []float64 {
+	out := make([]float64, len(xs))
+	k := EMAMultiplier(n)
+	for i, x := range xs {
+		if i == 0 {
+			out[i] = x
+			continue
+		}
+		out[i] = x*k + out[i-1]*(1-k)
+	}
+	return out
+}
@@ -0,0 +1,67 @@
+package numeric
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+// Rolling functions may differ from their decimal references by 1e-9, relative above 1
+const referenceTolerance = 1e-9
+
+// priceSeries returns n prices wandering around base, written to three places like ISX quotes
+func priceSeries(n int, base float64) []decimal.Decimal {
+	xs := make([]decimal.Decimal, n)
+	for i := range xs {
+		price := base * (1 + 0.2*math.Sin(float64(i)*0.05) + 0.05*math.Cos(float64(i)*1.3))
+		xs[i] = decimal.NewFromFloat(price).Round(3)
+	}
+	return xs
+}
+
+func TestRollingMatchesReference(t *testing.T) {
+	series := []struct {
+		name string
+		xs   []decimal.Decimal
+	}{
+		{"thin below 1", priceSeries(400, 0.26)},
+		{"bank near 4", priceSeries(400, 3.9)},
+		{"telecom near 12", priceSeries(400, 12.5)},
+		{"single price", priceSeries(1, 1.0)},
+		{"shorter than window", priceSeries(5, 2.5)},
+	}
+
+	for _, s := range series {
+		for _, window := range []int{1, 2, 14, 50, 200} {
+			t.Run(fmt.Sprintf("%s/%d", s.name, window), func(t *testing.T) {
+				for _, check := range CheckSeries("Close", s.xs, window, referenceTolerance) {
+					if !check.Passed {
+						t.Errorf("%s differs from its reference by %g", check.Name, check.MaxError)
+					}
+				}
+			})
+		}
+	}
+}
+
+func benchmarkRolling(b *testing.B, fast func([]float64, int) []float64) {
+	xs := make([]float64, 5000)
+	for i, x := range priceSeries(len(xs), 3.9) {
+		xs[i] = Float(x)
+	}
+	for _, window := range []int{14, 200} {
+		b.Run(fmt.Sprint(window), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				fast(xs, window)
+			}
+		})
+	}
+}
+
+func BenchmarkRollingMean(b *testing.B)    { benchmarkRolling(b, RollingMean) }
+func BenchmarkRollingStd(b *testing.B)     { benchmarkRolling(b, RollingStd) }
+func BenchmarkRollingHighest(b *testing.B) { benchmarkRolling(b, RollingHighest) }
+func BenchmarkRollingLowest(b *testing.B)  { benchmarkRolling(b, RollingLowest) }
+func BenchmarkEMA(b *testing.B)            { benchmarkRolling(b, EMA) }
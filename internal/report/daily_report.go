@@ -9,7 +9,9 @@ import (
 	"strings"
 	"time"
 
+	"isx-auto-scrapper/internal/calendar"
 	"isx-auto-scrapper/internal/common"
+	"isx-auto-scrapper/internal/i18n"
 
 	"github.com/xuri/excelize/v2"
 )
@@ -45,56 +47,39 @@ type CompanyData struct {
 
 // DailyReport aggregates all sections for the daily market report.
 type DailyReport struct {
-	Date      string        `json:"date"`
-	TopVolume []ReportEntry `json:"top_volume"`
-	TopValue  []ReportEntry `json:"top_value"`
-	TopGain   []ReportEntry `json:"top_gain"`
-	TopLoss   []ReportEntry `json:"top_loss"`
-	Traded    []CompanyData `json:"traded"`
-	NonTraded []CompanyData `json:"non_traded"`
+	Date            string        `json:"date"`
+	ExpectedSession string        `json:"expected_session"`
+	Holiday         string        `json:"holiday,omitempty"`
+	TopVolume       []ReportEntry `json:"top_volume"`
+	TopValue        []ReportEntry `json:"top_value"`
+	TopGain         []ReportEntry `json:"top_gain"`
+	TopLoss         []ReportEntry `json:"top_loss"`
+	Traded          []CompanyData `json:"traded"`
+	NonTraded       []CompanyData `json:"non_traded"`
+	Divergences     []Divergence  `json:"divergences"`
+
+	// Language of the company names, Excel sheets and Labels, the page headings in that language
+	Language string            `json:"language"`
+	Labels   map[string]string `json:"labels"`
 }
 
-// GenerateDailyReport builds a DailyReport from the latest raw_*.csv files.
-func GenerateDailyReport(_ time.Time) (*DailyReport, error) {
+// GenerateDailyReport builds a DailyReport in lang from the latest raw_*.csv files.
+// Only sessions on or before the given date are considered; the ISX calendar
+// decides which session the report is expected to cover.
+func GenerateDailyReport(date time.Time, lang string) (*DailyReport, error) {
 	tickers, err := common.LoadTickersWithInfo("TICKERS.csv")
 	if err != nil {
 		return nil, err
 	}
 
+	cal := calendar.Default()
+	cutoff := cal.LastSession(date).Format("2006-01-02")
+	expectedSession := cal.LastCompletedSession(date).Format("2006-01-02")
+
 	// -------------------------------------------------
-	// Pass 1: discover the latest date that had ANY trade
+	// Pass 1: discover the latest session that had ANY trade
 	// -------------------------------------------------
-	latestTradeDate := ""
-
-	for _, t := range tickers {
-		filename := fmt.Sprintf("raw_%s.csv", t.Symbol)
-		content, err := os.ReadFile(filename)
-		if err != nil {
-			continue // skip missing files
-		}
-		lines := strings.Split(string(content), "\n")
-
-		// Scan from newest to oldest until we find a row with volume>0
-		for i := len(lines) - 1; i >= 0; i-- {
-			row := strings.TrimSpace(lines[i])
-			if row == "" {
-				continue
-			}
-			parts := strings.Split(row, ",")
-			if len(parts) < 9 {
-				continue // malformed
-			}
-			closeVal, _ := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
-			volumeVal, _ := strconv.ParseInt(strings.TrimSpace(parts[8]), 10, 64)
-			if volumeVal > 0 || closeVal > 0 {
-				dateStr := strings.TrimSpace(parts[0])
-				if dateStr > latestTradeDate {
-					latestTradeDate = dateStr
-				}
-				break // done with this ticker
-			}
-		}
-	}
+	latestTradeDate := findLatestTradeDate(tickers, cutoff)
 
 	if latestTradeDate == "" {
 		return nil, fmt.Errorf("could not determine latest trade date")
@@ -155,6 +140,9 @@ func GenerateDailyReport(_ time.Time) (*DailyReport, error) {
 				if len(parts) < 9 {
 					continue
 				}
+				if strings.TrimSpace(parts[0]) > latestTradeDate {
+					continue
+				}
 				closeVal, _ := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
 				volumeVal, _ := strconv.ParseInt(strings.TrimSpace(parts[8]), 10, 64)
 				if volumeVal > 0 || closeVal > 0 {
@@ -163,7 +151,7 @@ func GenerateDailyReport(_ time.Time) (*DailyReport, error) {
 				}
 			}
 
-			cd := CompanyData{Code: t.Symbol, Name: t.CompanyName}
+			cd := CompanyData{Code: t.Symbol, Name: t.DisplayName(lang)}
 
 			if lastTradeLine != "" {
 				lp := strings.Split(lastTradeLine, ",")
@@ -271,7 +259,7 @@ func GenerateDailyReport(_ time.Time) (*DailyReport, error) {
 
 		cd := CompanyData{
 			Code:         t.Symbol,
-			Name:         t.CompanyName,
+			Name:         t.DisplayName(lang),
 			LastTraded:   latestTradeDate,
 			Open:         openVal,
 			High:         highVal,
@@ -333,58 +321,119 @@ func GenerateDailyReport(_ time.Time) (*DailyReport, error) {
 	topGain := buildTop(traded, func(a, b CompanyData) bool { return a.ChangePct > b.ChangePct })
 	topLoss := buildTop(traded, func(a, b CompanyData) bool { return a.ChangePct < b.ChangePct })
 
+	holiday, _ := cal.HolidayName(date)
+
 	return &DailyReport{
-		Date:      latestTradeDate,
-		TopVolume: topVol,
-		TopValue:  topVal,
-		TopGain:   topGain,
-		TopLoss:   topLoss,
-		Traded:    traded,
-		NonTraded: nonTraded,
+		Date:            latestTradeDate,
+		ExpectedSession: expectedSession,
+		Holiday:         holiday,
+		TopVolume:       topVol,
+		TopValue:        topVal,
+		TopGain:         topGain,
+		TopLoss:         topLoss,
+		Traded:          traded,
+		NonTraded:       nonTraded,
+		Divergences:     MarketDivergences(tickers, latestTradeDate, lang),
+		Language:        lang,
+		Labels:          i18n.Messages(lang, "report."),
 	}, nil
 }
 
+// findLatestTradeDate returns the latest session on or before cutoff in which any ticker traded,
+// or "" when no raw file has one
+func findLatestTradeDate(tickers []common.TickerInfo, cutoff string) string {
+	latestTradeDate := ""
+
+	for _, t := range tickers {
+		filename := fmt.Sprintf("raw_%s.csv", t.Symbol)
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			continue // skip missing files
+		}
+		lines := strings.Split(string(content), "\n")
+
+		// Scan from newest to oldest until we find a row with volume>0
+		for i := len(lines) - 1; i >= 0; i-- {
+			row := strings.TrimSpace(lines[i])
+			if row == "" {
+				continue
+			}
+			parts := strings.Split(row, ",")
+			if len(parts) < 9 {
+				continue // malformed
+			}
+			dateStr := strings.TrimSpace(parts[0])
+			if dateStr > cutoff {
+				continue // after the requested session
+			}
+			closeVal, _ := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+			volumeVal, _ := strconv.ParseInt(strings.TrimSpace(parts[8]), 10, 64)
+			if volumeVal > 0 || closeVal > 0 {
+				if dateStr > latestTradeDate {
+					latestTradeDate = dateStr
+				}
+				break // done with this ticker
+			}
+		}
+	}
+	return latestTradeDate
+}
+
 // SaveDailyReportExcel writes the report to an Excel file with one sheet per section.
+// Sheet names and headings are in the report language, and Arabic sheets read right to left.
 func SaveDailyReportExcel(r *DailyReport, path string) error {
+	lang := r.Language
 	f := excelize.NewFile()
-	// Top Volume
-	f.NewSheet("TopVolume")
-	f.SetSheetRow("TopVolume", "A1", &[]string{"Ticker", "Company", "Close", "Change%", "Volume", "Value"})
-	for i, row := range r.TopVolume {
-		f.SetSheetRow("TopVolume", fmt.Sprintf("A%d", i+2), &[]interface{}{row.Ticker, row.Name, row.Close, row.ChangePct, row.Volume, row.Value})
-	}
-	// Top Value
-	f.NewSheet("TopValue")
-	f.SetSheetRow("TopValue", "A1", &[]string{"Ticker", "Company", "Close", "Change%", "Volume", "Value"})
-	for i, row := range r.TopValue {
-		f.SetSheetRow("TopValue", fmt.Sprintf("A%d", i+2), &[]interface{}{row.Ticker, row.Name, row.Close, row.ChangePct, row.Volume, row.Value})
-	}
-	// Top Gain
-	f.NewSheet("TopGain")
-	f.SetSheetRow("TopGain", "A1", &[]string{"Ticker", "Company", "Close", "Change%", "Volume", "Value"})
-	for i, row := range r.TopGain {
-		f.SetSheetRow("TopGain", fmt.Sprintf("A%d", i+2), &[]interface{}{row.Ticker, row.Name, row.Close, row.ChangePct, row.Volume, row.Value})
+	addSheet := func(name string, header []string, rows [][]interface{}) {
+		sheet := i18n.Or(lang, "report.sheet."+name, name)
+		f.NewSheet(sheet)
+		if i18n.RTL(lang) {
+			rtl := true
+			f.SetSheetView(sheet, 0, &excelize.ViewOptions{RightToLeft: &rtl})
+		}
+		labels := make([]string, len(header))
+		for k, column := range header {
+			labels[k] = i18n.Or(lang, "report.column."+column, column)
+		}
+		f.SetSheetRow(sheet, "A1", &labels)
+		for i, row := range rows {
+			f.SetSheetRow(sheet, fmt.Sprintf("A%d", i+2), &row)
+		}
 	}
-	// Top Loss
-	f.NewSheet("TopLoss")
-	f.SetSheetRow("TopLoss", "A1", &[]string{"Ticker", "Company", "Close", "Change%", "Volume", "Value"})
-	for i, row := range r.TopLoss {
-		f.SetSheetRow("TopLoss", fmt.Sprintf("A%d", i+2), &[]interface{}{row.Ticker, row.Name, row.Close, row.ChangePct, row.Volume, row.Value})
+
+	topHeader := []string{"Ticker", "Company", "Close", "Change%", "Volume", "Value"}
+	topRows := func(entries []ReportEntry) [][]interface{} {
+		rows := make([][]interface{}, len(entries))
+		for i, row := range entries {
+			rows[i] = []interface{}{row.Ticker, row.Name, row.Close, row.ChangePct, row.Volume, row.Value}
+		}
+		return rows
 	}
-	// Traded
-	f.NewSheet("Traded")
-	f.SetSheetRow("Traded", "A1", &[]string{"Code", "Company", "Open", "High", "Low", "AvgPrice", "PrevAvg", "Close", "PrevClose", "Change%", "Trades", "Volume", "Value"})
-	for i, row := range r.Traded {
-		f.SetSheetRow("Traded", fmt.Sprintf("A%d", i+2), &[]interface{}{row.Code, row.Name, row.Open, row.High, row.Low, row.AvgPrice, row.PrevAvgPrice, row.Close, row.PrevClose, row.ChangePct, row.Trades, row.Volume, row.Value})
+	addSheet("TopVolume", topHeader, topRows(r.TopVolume))
+	addSheet("TopValue", topHeader, topRows(r.TopValue))
+	addSheet("TopGain", topHeader, topRows(r.TopGain))
+	addSheet("TopLoss", topHeader, topRows(r.TopLoss))
+
+	companyHeader := []string{"Code", "Company", "Open", "High", "Low", "AvgPrice", "PrevAvg", "Close", "PrevClose", "Change%", "Trades", "Volume", "Value"}
+	companyRows := func(companies []CompanyData) [][]interface{} {
+		rows := make([][]interface{}, len(companies))
+		for i, row := range companies {
+			rows[i] = []interface{}{row.Code, row.Name, row.Open, row.High, row.Low, row.AvgPrice, row.PrevAvgPrice, row.Close, row.PrevClose, row.ChangePct, row.Trades, row.Volume, row.Value}
+		}
+		return rows
 	}
-	// Non traded
-	f.NewSheet("NonTraded")
-	f.SetSheetRow("NonTraded", "A1", &[]string{"Code", "Company", "Open", "High", "Low", "AvgPrice", "PrevAvg", "Close", "PrevClose", "Change%", "Trades", "Volume", "Value"})
-	for i, row := range r.NonTraded {
-		f.SetSheetRow("NonTraded", fmt.Sprintf("A%d", i+2), &[]interface{}{row.Code, row.Name, row.Open, row.High, row.Low, row.AvgPrice, row.PrevAvgPrice, row.Close, row.PrevClose, row.ChangePct, row.Trades, row.Volume, row.Value})
+	addSheet("Traded", companyHeader, companyRows(r.Traded))
+	addSheet("NonTraded", companyHeader, companyRows(r.NonTraded))
+
+	divergenceRows := make([][]interface{}, len(r.Divergences))
+	for i, row := range r.Divergences {
+		divergenceRows[i] = []interface{}{row.Ticker, row.Name, row.Indicator,
+			i18n.Or(lang, "report.value."+row.Type, row.Type), i18n.Or(lang, "report.value."+row.Bias, row.Bias),
+			row.Start, row.End, row.StartPrice, row.EndPrice, row.Strength}
 	}
+	addSheet("Divergences", []string{"Ticker", "Company", "Indicator", "Type", "Bias", "Start", "End", "StartPrice", "EndPrice", "Strength"}, divergenceRows)
 
-	if idx, err := f.GetSheetIndex("TopVolume"); err == nil {
+	if idx, err := f.GetSheetIndex(i18n.Or(lang, "report.sheet.TopVolume", "TopVolume")); err == nil {
 		f.SetActiveSheet(idx)
 	}
 	return f.SaveAs(path)
@@ -0,0 +1,52 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"isx-auto-scrapper/internal/calendar"
+	"isx-auto-scrapper/internal/common"
+	"isx-auto-scrapper/internal/indicators"
+)
+
+// Divergence is a divergence confirmed on the report session.
+type Divergence struct {
+	Ticker string `json:"ticker"`
+	Name   string `json:"name"`
+	indicators.DivergenceEvent
+}
+
+// MarketDivergences lists the divergences confirmed on date across all tickers, strongest first,
+// with company names in lang. It reads the daily divergences_<TICKER>.csv files written by calc.
+func MarketDivergences(tickers []common.TickerInfo, date, lang string) []Divergence {
+	entries := []Divergence{}
+	for _, t := range tickers {
+		events, err := indicators.LoadDivergences(indicators.DivergenceFile(t.Symbol, indicators.Daily))
+		if err != nil {
+			continue
+		}
+		for _, e := range events {
+			if e.Detected == date {
+				entries = append(entries, Divergence{Ticker: t.Symbol, Name: t.DisplayName(lang), DivergenceEvent: e})
+			}
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Strength > entries[j].Strength })
+	return entries
+}
+
+// TodaysDivergences returns the latest session with trades on or before date and the divergences confirmed on it
+func TodaysDivergences(date time.Time, lang string) (string, []Divergence, error) {
+	tickers, err := common.LoadTickersWithInfo("TICKERS.csv")
+	if err != nil {
+		return "", nil, err
+	}
+
+	cutoff := calendar.Default().LastSession(date).Format("2006-01-02")
+	session := findLatestTradeDate(tickers, cutoff)
+	if session == "" {
+		return "", nil, fmt.Errorf("could not determine latest trade date")
+	}
+	return session, MarketDivergences(tickers, session, lang), nil
+}
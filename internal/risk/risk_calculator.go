@@ -0,0 +1,295 @@
+package risk
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/gocarina/gocsv"
+
+	"isx-auto-scrapper/internal/calendar"
+	"isx-auto-scrapper/internal/common"
+	"isx-auto-scrapper/internal/indicators"
+	"isx-auto-scrapper/internal/numeric"
+)
+
+// SummaryFile is the cross-sectional risk table of all tickers
+const SummaryFile = "risk_summary.csv"
+
+// Metrics are the risk measures of one rolling window. Volatilities and the downside deviation
+// are annualised, VaR and CVaR are one-session losses; all are in percent.
+type Metrics struct {
+	CloseToClose   float64 `csv:"Vol_Close_Close" json:"vol_close_close"`   // Sample std of log returns
+	Parkinson      float64 `csv:"Vol_Parkinson" json:"vol_parkinson"`       // From the high-low range
+	GarmanKlass    float64 `csv:"Vol_Garman_Klass" json:"vol_garman_klass"` // From open, high, low and close
+	YangZhang      float64 `csv:"Vol_Yang_Zhang" json:"vol_yang_zhang"`     // Overnight, open-close and range terms
+	VaRHistorical  float64 `csv:"VaR_Hist" json:"var_hist"`                 // Loss exceeded on (1 - confidence) of sessions
+	CVaRHistorical float64 `csv:"CVaR_Hist" json:"cvar_hist"`               // Average loss in that tail
+	VaRParametric  float64 `csv:"VaR_Param" json:"var_param"`               // Normal-distribution VaR
+	CVaRParametric float64 `csv:"CVaR_Param" json:"cvar_param"`             // Normal-distribution CVaR
+	DownsideDev    float64 `csv:"Downside_Dev" json:"downside_dev"`         // Root mean square of negative returns
+	MaxDrawdown    float64 `csv:"Max_Drawdown" json:"max_drawdown"`         // Deepest fall from a peak in the window
+}
+
+// RiskRecord is one session of risk_<TICKER>.csv with the metrics of the window ending on it
+type RiskRecord struct {
+	Date   string  `csv:"Date" json:"date"`
+	Close  float64 `csv:"Close" json:"close"`
+	Return float64 `csv:"Return" json:"return"` // Simple return from the previous session in percent
+	Metrics
+}
+
+// RiskSummaryRecord is the latest window of one ticker in risk_summary.csv
+type RiskSummaryRecord struct {
+	Ticker string  `csv:"Ticker" json:"ticker"`
+	Name   string  `csv:"Name" json:"name"`
+	Date   string  `csv:"Date" json:"date"`
+	Close  float64 `csv:"Close" json:"close"`
+	Metrics
+	VolatilityRank float64 `csv:"Volatility_Rank" json:"volatility_rank"` // Percent of tickers with a lower Yang-Zhang volatility
+}
+
+// RiskCalc computes rolling volatility estimators and risk metrics per ticker
+type RiskCalc struct {
+	logger   *common.Logger
+	calendar *calendar.Calendar
+	config   common.RiskConfig
+}
+
+// NewRiskCalc creates a new RiskCalc instance
+func NewRiskCalc(logger *common.Logger) *RiskCalc {
+	return &RiskCalc{
+		logger:   logger,
+		calendar: calendar.Default(),
+		config:   common.AppConfig.Risk,
+	}
+}
+
+// File returns the rolling risk file of ticker, e.g. risk_BBOB.csv
+func File(ticker string) string {
+	return "risk_" + ticker + ".csv"
+}
+
+// CalculateAll writes risk_<TICKER>.csv for every ticker and the cross-sectional risk_summary.csv
+func (rc *RiskCalc) CalculateAll() error {
+	rc.logger.Info("Starting risk calculation")
+
+	tickers, err := common.LoadTickersWithInfo("TICKERS.csv")
+	if err != nil {
+		return fmt.Errorf("failed to load tickers: %w", err)
+	}
+
+	// Only tickers that traded in the last year are compared
+	windowStart, _, _ := rc.calendar.YearWindow(time.Now())
+
+	var summary []*RiskSummaryRecord
+	for _, t := range tickers {
+		records, err := rc.calculateTicker(t.Symbol)
+		if err != nil {
+			rc.logger.Error("Failed to calculate risk for ticker %s: %v", t.Symbol, err)
+			continue
+		}
+		if len(records) == 0 {
+			rc.logger.Info("Not enough price data for %s to fill a %d-session window", t.Symbol, rc.config.Window)
+			continue
+		}
+		if err := SaveRecords(records, File(t.Symbol)); err != nil {
+			rc.logger.Error("Failed to save risk data for ticker %s: %v", t.Symbol, err)
+			continue
+		}
+
+		last := records[len(records)-1]
+		if last.Date < windowStart.Format("2006-01-02") {
+			rc.logger.Info("No risk window for %s in the past 12 months", t.Symbol)
+			continue
+		}
+		summary = append(summary, &RiskSummaryRecord{
+			Ticker:  t.Symbol,
+			Name:    t.CompanyName,
+			Date:    last.Date,
+			Close:   last.Close,
+			Metrics: last.Metrics,
+		})
+	}
+
+	if len(summary) == 0 {
+		rc.logger.Error("No risk metrics calculated")
+		return fmt.Errorf("no risk metrics calculated")
+	}
+
+	rankVolatility(summary)
+	if err := saveSummary(summary, SummaryFile); err != nil {
+		return fmt.Errorf("failed to save risk summary: %w", err)
+	}
+
+	rc.logger.Info("Risk metrics calculated for %d tickers", len(summary))
+	return nil
+}
+
+// calculateTicker returns the rolling metrics of ticker, one record per session once the window is full
+func (rc *RiskCalc) calculateTicker(ticker string) ([]*RiskRecord, error) {
+	frame, err := indicators.LoadRawFrame(fmt.Sprintf("raw_%s.csv", ticker))
+	if err != nil {
+		return nil, err
+	}
+
+	// Sessions without a full set of prices cannot be measured
+	var bars []int
+	for i := 0; i < frame.Len(); i++ {
+		if frame.Open[i] > 0 && frame.High[i] > 0 && frame.Low[i] > 0 && frame.Close[i] > 0 && frame.High[i] >= frame.Low[i] {
+			bars = append(bars, i)
+		}
+	}
+
+	n := rc.config.Window
+	var records []*RiskRecord
+	for end := n; end < len(bars); end++ {
+		window := bars[end-n : end+1] // n returns need n+1 sessions
+		last, prev := window[n], window[n-1]
+		records = append(records, &RiskRecord{
+			Date:    frame.Dates[last].Format("2006-01-02"),
+			Close:   frame.Close[last],
+			Return:  numeric.Round((frame.Close[last]/frame.Close[prev]-1)*100, 4),
+			Metrics: rc.windowMetrics(frame, window),
+		})
+	}
+	return records, nil
+}
+
+// windowMetrics measures the returns between consecutive sessions of window
+func (rc *RiskCalc) windowMetrics(f *indicators.Frame, window []int) Metrics {
+	n := len(window) - 1
+	annualise := math.Sqrt(float64(rc.config.PeriodsPerYear)) * 100
+
+	returns := make([]float64, n)
+	logReturns := make([]float64, n)
+	overnight := make([]float64, n)
+	openClose := make([]float64, n)
+	closes := make([]float64, 0, n+1)
+	closes = append(closes, f.Close[window[0]])
+	parkinson, garmanKlass, rogersSatchell, downside := 0.0, 0.0, 0.0, 0.0
+	for j := 1; j <= n; j++ {
+		i, prev := window[j], window[j-1]
+		o, h, l, c := f.Open[i], f.High[i], f.Low[i], f.Close[i]
+
+		returns[j-1] = c/f.Close[prev] - 1
+		logReturns[j-1] = math.Log(c / f.Close[prev])
+		overnight[j-1] = math.Log(o / f.Close[prev])
+		openClose[j-1] = math.Log(c / o)
+		closes = append(closes, c)
+
+		hl, co := math.Log(h/l), math.Log(c/o)
+		parkinson += hl * hl
+		garmanKlass += 0.5*hl*hl - (2*math.Ln2-1)*co*co
+		rogersSatchell += math.Log(h/c)*math.Log(h/o) + math.Log(l/c)*math.Log(l/o)
+		downside += math.Pow(math.Min(returns[j-1], 0), 2)
+	}
+
+	// Yang-Zhang weights the open-to-close variance by k, chosen to minimise the estimator's variance
+	k := 0.34 / (1.34 + float64(n+1)/float64(n-1))
+	yangZhang := numeric.SampleVariance(overnight) + k*numeric.SampleVariance(openClose) + (1-k)*rogersSatchell/float64(n)
+
+	varHist, cvarHist := historicalVaR(returns, rc.config.Confidence)
+	varParam, cvarParam := parametricVaR(returns, rc.config.Confidence)
+
+	return Metrics{
+		CloseToClose:   numeric.Round(numeric.SampleStd(logReturns)*annualise, 4),
+		Parkinson:      numeric.Round(math.Sqrt(parkinson/(4*float64(n)*math.Ln2))*annualise, 4),
+		GarmanKlass:    numeric.Round(math.Sqrt(math.Max(garmanKlass/float64(n), 0))*annualise, 4),
+		YangZhang:      numeric.Round(math.Sqrt(math.Max(yangZhang, 0))*annualise, 4),
+		VaRHistorical:  numeric.Round(varHist*100, 4),
+		CVaRHistorical: numeric.Round(cvarHist*100, 4),
+		VaRParametric:  numeric.Round(varParam*100, 4),
+		CVaRParametric: numeric.Round(cvarParam*100, 4),
+		DownsideDev:    numeric.Round(math.Sqrt(downside/float64(n))*annualise, 4),
+		MaxDrawdown:    numeric.Round(numeric.MaxDrawdown(closes), 4),
+	}
+}
+
+// historicalVaR returns the loss at the (1 - confidence) quantile of returns and the average loss
+// of the returns at or below it, both as positive fractions
+func historicalVaR(returns []float64, confidence float64) (float64, float64) {
+	sorted := append([]float64{}, returns...)
+	sort.Float64s(sorted)
+
+	// Nearest rank; the epsilon keeps 0.05 * 60 at 3 despite float error
+	tail := max(int(math.Ceil((1-confidence)*float64(len(sorted))-1e-9)), 1)
+	return -sorted[tail-1], -numeric.Mean(sorted[:tail])
+}
+
+// parametricVaR returns VaR and CVaR of a normal distribution with the mean and sample
+// standard deviation of returns
+func parametricVaR(returns []float64, confidence float64) (float64, float64) {
+	mean, std := numeric.Mean(returns), numeric.SampleStd(returns)
+	z := math.Sqrt2 * math.Erfinv(2*confidence-1)
+	density := math.Exp(-z*z/2) / math.Sqrt(2*math.Pi)
+	return z*std - mean, std*density/(1-confidence) - mean
+}
+
+// rankVolatility sets each ticker's percentile among all tickers by Yang-Zhang volatility
+func rankVolatility(summary []*RiskSummaryRecord) {
+	if len(summary) < 2 {
+		return
+	}
+	for _, s := range summary {
+		lower := 0
+		for _, other := range summary {
+			if other.YangZhang < s.YangZhang {
+				lower++
+			}
+		}
+		s.VolatilityRank = numeric.Round(float64(lower)/float64(len(summary)-1)*100, 2)
+	}
+}
+
+// SaveRecords writes the rolling records of one ticker to filePath
+func SaveRecords(records []*RiskRecord, filePath string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return gocsv.MarshalFile(&records, file)
+}
+
+// LoadRecords reads a file written by SaveRecords
+func LoadRecords(filePath string) ([]*RiskRecord, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []*RiskRecord
+	if err := gocsv.UnmarshalFile(file, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// saveSummary writes the cross-sectional table to filePath
+func saveSummary(summary []*RiskSummaryRecord, filePath string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return gocsv.MarshalFile(&summary, file)
+}
+
+// LoadSummary reads risk_summary.csv
+func LoadSummary() ([]*RiskSummaryRecord, error) {
+	file, err := os.Open(SummaryFile)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var summary []*RiskSummaryRecord
+	if err := gocsv.UnmarshalFile(file, &summary); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
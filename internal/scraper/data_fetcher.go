@@ -15,12 +15,14 @@ import (
 	"github.com/chromedp/chromedp"
 	"github.com/shopspring/decimal"
 
+	"isx-auto-scrapper/internal/calendar"
 	"isx-auto-scrapper/internal/common"
 )
 
 // DataFetcher handles web scraping of stock data
 type DataFetcher struct {
-	logger *common.Logger
+	logger   *common.Logger
+	calendar *calendar.Calendar
 	// Reporting fields
 	currentReport *common.ProcessingReport
 	startTime     time.Time
@@ -36,9 +38,10 @@ type DataFetcher struct {
 }
 
 // NewDataFetcher creates a new DataFetcher instance
-func NewDataFetcher() *DataFetcher {
+func NewDataFetcher(logger *common.Logger) *DataFetcher {
 	return &DataFetcher{
-		logger: common.NewLogger(),
+		logger:   logger,
+		calendar: calendar.Default(),
 	}
 }
 
@@ -91,12 +94,12 @@ func (df *DataFetcher) FetchDataWithReport(ticker, sector, companyName string) (
 			df.currentReport.PagesBeforeUpdate = pagesBeforeUpdate
 
 			if len(existingDataFull) > 0 {
+				// Up to date when the last row covers the latest completed ISX session
 				lastDate := existingDataFull[len(existingDataFull)-1].Date
-				currentDate := time.Now()
-				daysDiff := int(currentDate.Sub(lastDate).Hours() / 24)
+				expectedDate := df.calendar.LastCompletedSession(time.Now())
 
-				if daysDiff <= 1 {
-					df.logger.Info("Data is up to date for ticker %s", ticker)
+				if !lastDate.Before(expectedDate) {
+					df.logger.Info("Data is up to date for ticker %s (last session %s)", ticker, expectedDate.Format("2006-01-02"))
 					df.currentReport.Status = "UP_TO_DATE"
 					df.currentReport.EndTime = time.Now()
 					df.currentReport.ProcessingDuration = time.Since(df.startTime).String()
@@ -124,7 +127,7 @@ func (df *DataFetcher) FetchDataWithReport(ticker, sector, companyName string) (
 
 	// Setup chrome options for better performance
 	opts := append(chromedp.DefaultExecAllocatorOptions[:],
-		chromedp.Flag("headless", false), // Disable headless mode to show browser window
+		chromedp.Flag("headless", common.AppConfig.Scraper.Headless), // Visible by default so the operator can watch the portal
 		chromedp.Flag("disable-gpu", true),
 		chromedp.Flag("disable-dev-shm-usage", true),
 		chromedp.Flag("disable-extensions", true),
@@ -149,6 +152,9 @@ func (df *DataFetcher) FetchDataWithReport(ticker, sector, companyName string) (
 		chromedp.Flag("no-sandbox", true),
 		chromedp.WindowSize(1024, 768), // Smaller window for better performance
 	)
+	if path := common.AppConfig.Scraper.BrowserPath; path != "" {
+		opts = append(opts, chromedp.ExecPath(path))
+	}
 
 	allocCtx, cancel := chromedp.NewExecAllocator(context.Background(), opts...)
 	defer cancel()
@@ -157,18 +163,18 @@ func (df *DataFetcher) FetchDataWithReport(ticker, sector, companyName string) (
 	defer cancel()
 
 	// Set timeout
-	ctx, cancel = context.WithTimeout(ctx, 1100*time.Second) // Increased from 45 to 60 seconds due to optimizations working well
+	ctx, cancel = context.WithTimeout(ctx, time.Duration(common.AppConfig.Scraper.TimeoutSeconds)*time.Second)
 	defer cancel()
 
 	// Set up JavaScript dialog handler IMMEDIATELY before any navigation
 	// This catches popups that appear during page load
-	df.logger.Info("Setting up early popup detection before navigation...")
+	df.logger.Debug("Setting up early popup detection before navigation...")
 	dialogHandled := make(chan bool, 1)
 
 	chromedp.ListenTarget(ctx, func(ev interface{}) {
 		switch ev := ev.(type) {
 		case *page.EventJavascriptDialogOpening:
-			df.logger.Info("EARLY POPUP DETECTED: Type=%s, Message='%s'", ev.Type, ev.Message)
+			df.logger.Debug("EARLY POPUP DETECTED: Type=%s, Message='%s'", ev.Type, ev.Message)
 
 			// Automatically accept the dialog immediately
 			go func() {
@@ -176,7 +182,7 @@ func (df *DataFetcher) FetchDataWithReport(ticker, sector, companyName string) (
 				if err != nil {
 					df.logger.Error("Failed to handle early popup: %v", err)
 				} else {
-					df.logger.Info("Successfully auto-accepted early popup")
+					df.logger.Debug("Successfully auto-accepted early popup")
 					select {
 					case dialogHandled <- true:
 					default:
@@ -187,7 +193,7 @@ func (df *DataFetcher) FetchDataWithReport(ticker, sector, companyName string) (
 	})
 
 	// Navigate to the company profile page with Performance tab active
-	url := fmt.Sprintf("%s?currLanguage=en&companyCode=%s&activeTab=0", common.AppConfig.BaseURL, ticker)
+	url := fmt.Sprintf("%s?currLanguage=en&companyCode=%s&activeTab=0", common.AppConfig.Scraper.BaseURL, ticker)
 
 	df.logger.Info("Fetching data from URL %s for ticker %s", url, ticker)
 
@@ -201,14 +207,14 @@ func (df *DataFetcher) FetchDataWithReport(ticker, sector, companyName string) (
 	// Check if a dialog was handled during navigation
 	select {
 	case <-dialogHandled:
-		df.logger.Info("Early popup was automatically handled during navigation")
+		df.logger.Debug("Early popup was automatically handled during navigation")
 	default:
 		// No early popup detected
 	}
 
 	// Wait for page to be completely ready using event-driven detection
 	if err == nil {
-		err = df.waitForPageComplete(ctx, 15*time.Second) // Max 15 seconds for initial page load
+		err = df.waitForPageComplete(ctx, time.Duration(common.AppConfig.Scraper.PageWaitSeconds)*time.Second)
 	}
 
 	// Handle any popups that might appear (like year validation popup)
@@ -230,13 +236,13 @@ func (df *DataFetcher) FetchDataWithReport(ticker, sector, companyName string) (
 
 	// After setDateAndSearch, the page should already have the correct data loaded
 	// So we skip the manual company code setting and AJAX triggering
-	df.logger.Info("Skipping manual company code and AJAX setup since search was already performed")
+	df.logger.Debug("Skipping manual company code and AJAX setup since search was already performed")
 
 	// Set timing placeholders since we skipped those steps
 	df.timingReport.CompanyCodeSetTime = 0
 	df.timingReport.AjaxTriggerTime = 0
 
-	df.logger.Info("Page loaded successfully, extracting historical data...")
+	df.logger.Debug("Page loaded successfully, extracting historical data...")
 
 	// Extract data from the page
 	dataExtractionStart := time.Now()
@@ -253,7 +259,7 @@ func (df *DataFetcher) FetchDataWithReport(ticker, sector, companyName string) (
 			if err := os.WriteFile(htmlFile, []byte(htmlContent), 0644); err != nil {
 				df.logger.Error("Failed to save HTML content: %v", err)
 			} else {
-				df.logger.Info("HTML content saved to %s for inspection", htmlFile)
+				df.logger.Debug("HTML content saved to %s for inspection", htmlFile)
 			}
 
 			return nil
@@ -335,7 +341,7 @@ func (df *DataFetcher) FetchDataWithReport(ticker, sector, companyName string) (
 	// Clean up the temporary CSV that was written after each page
 	if _, statErr := os.Stat(tempFilename); statErr == nil {
 		if remErr := os.Remove(tempFilename); remErr != nil {
-			df.logger.Info("Could not delete temp file %s: %v", tempFilename, remErr)
+			df.logger.Warn("Could not delete temp file %s: %v", tempFilename, remErr)
 		} else {
 			df.logger.Info("Deleted temporary file %s after successful scrape", tempFilename)
 		}
@@ -361,13 +367,13 @@ func (df *DataFetcher) extractDataFromAllPages(ctx context.Context, stockData *[
 	if len(*stockData) > 0 {
 		firstDate := (*stockData)[0].Date.Format("2006-01-02")
 		lastDate := (*stockData)[len(*stockData)-1].Date.Format("2006-01-02")
-		df.logger.Info("Starting with %d existing records in memory. Range: %s to %s", len(*stockData), firstDate, lastDate)
+		df.logger.Debug("Starting with %d existing records in memory. Range: %s to %s", len(*stockData), firstDate, lastDate)
 	} else {
-		df.logger.Info("No existing data provided, starting fresh")
+		df.logger.Debug("No existing data provided, starting fresh")
 	}
 
 	for len(*stockData) < maxRows {
-		df.logger.Info("Extracting data from page %d", pageNum)
+		df.logger.Debug("Extracting data from page %d", pageNum)
 
 		// Track page processing time
 		pageStart := time.Now()
@@ -387,7 +393,7 @@ func (df *DataFetcher) extractDataFromAllPages(ctx context.Context, stockData *[
 		if len(pageData) > 0 {
 			firstPageDate := pageData[0].Date.Format("2006-01-02")
 			lastPageDate := pageData[len(pageData)-1].Date.Format("2006-01-02")
-			df.logger.Info("Page %d data range: %s to %s (%d records)", pageNum, firstPageDate, lastPageDate, len(pageData))
+			df.logger.Debug("Page %d data range: %s to %s (%d records)", pageNum, firstPageDate, lastPageDate, len(pageData))
 		}
 
 		// Check for overlap with existing data and filter duplicates
@@ -408,16 +414,16 @@ func (df *DataFetcher) extractDataFromAllPages(ctx context.Context, stockData *[
 			}
 		}
 
-		df.logger.Info("Page %d: %d new records, %d overlapping records", pageNum, newDataCount, overlapCount)
+		df.logger.Debug("Page %d: %d new records, %d overlapping records", pageNum, newDataCount, overlapCount)
 
 		// Log overlapping dates for analysis
 		if overlapCount > 0 {
-			df.logger.Info("Overlapping dates on page %d: %v", pageNum, overlappingDates)
+			df.logger.Debug("Overlapping dates on page %d: %v", pageNum, overlappingDates)
 		}
 
 		// Add only the new records from this page
 		*stockData = append(*stockData, newRecords...)
-		df.logger.Info("Extracted %d records from page %d, total so far: %d", len(newRecords), pageNum, len(*stockData))
+		df.logger.Debug("Extracted %d records from page %d, total so far: %d", len(newRecords), pageNum, len(*stockData))
 
 		// Stop paginating only if the page contributed NO new records at all.
 		// A small overlap is normal because some dates repeat on consecutive pages.
@@ -454,7 +460,7 @@ func (df *DataFetcher) extractDataFromAllPages(ctx context.Context, stockData *[
 		if err := df.saveDataToCSV(sortedData, tempFilename); err != nil {
 			df.logger.Error("Failed to save temporary CSV after page %d: %v", pageNum, err)
 		} else {
-			df.logger.Info("Saved %d sorted records to %s after page %d", len(sortedData), tempFilename, pageNum)
+			df.logger.Debug("Saved %d sorted records to %s after page %d", len(sortedData), tempFilename, pageNum)
 		}
 		df.timingReport.CSVSaveTime += time.Since(csvSaveStart)
 
@@ -470,11 +476,11 @@ func (df *DataFetcher) extractDataFromAllPages(ctx context.Context, stockData *[
 		}
 
 		if hasNextPage {
-			df.logger.Info("Navigating to page %d using AJAX...", nextPageNum)
+			df.logger.Debug("Navigating to page %d using AJAX...", nextPageNum)
 			// Wait for AJAX pagination to complete using event-driven detection
 			ajaxWaitStart := time.Now()
 			if err := df.waitForDataTablePopulated(ctx, 8*time.Second); err != nil {
-				df.logger.Error("AJAX pagination timeout, proceeding anyway: %v", err)
+				df.logger.Warn("AJAX pagination timeout, proceeding anyway: %v", err)
 			}
 			df.totalAjaxWaitTime += time.Since(ajaxWaitStart)
 		} else {
@@ -520,7 +526,7 @@ func (df *DataFetcher) extractDataFromCurrentPage(ctx context.Context) ([]common
 	// Wait for data table to be populated before extracting
 	err := df.waitForDataTablePopulated(ctx, 5*time.Second) // Max 5 seconds wait
 	if err != nil {
-		df.logger.Error("Data table not populated, proceeding anyway: %v", err)
+		df.logger.Warn("Data table not populated, proceeding anyway: %v", err)
 	}
 
 	err = chromedp.Run(ctx,
@@ -566,7 +572,7 @@ func (df *DataFetcher) extractDataFromCurrentPage(ctx context.Context) ([]common
 		return nil, err
 	}
 
-	df.logger.Info("Found %d data rows on current page", len(rows))
+	df.logger.Debug("Found %d data rows on current page", len(rows))
 
 	var stockData []common.StockData
 	for _, row := range rows {
@@ -631,7 +637,7 @@ func (df *DataFetcher) extractDataDirectly(ctx context.Context, stockData *[]com
 		return err
 	}
 
-	df.logger.Info("Found %d potential data rows using direct extraction", len(rows))
+	df.logger.Debug("Found %d potential data rows using direct extraction", len(rows))
 
 	for _, row := range rows {
 		if row["date"] == "" {
@@ -768,7 +774,7 @@ func (df *DataFetcher) navigateToNextPageAjax(ctx context.Context, pageNum int)
 	}
 
 	if hasNextPage {
-		df.logger.Info("Successfully triggered AJAX navigation to page %d", pageNum)
+		df.logger.Debug("Successfully triggered AJAX navigation to page %d", pageNum)
 		// No artificial delay needed - event-driven waiting will handle detection
 	} else {
 		df.logger.Info("No more pages available")
@@ -824,7 +830,7 @@ func (df *DataFetcher) waitForNetworkIdle(ctx context.Context, maxWaitTime time.
 
 		if err == nil && networkState != nil {
 			if getBoolFromMap(networkState, "isIdle") {
-				df.logger.Info("Network idle achieved in %v", time.Since(start))
+				df.logger.Debug("Network idle achieved in %v", time.Since(start))
 				return nil
 			}
 		}
@@ -1390,7 +1396,7 @@ func (df *DataFetcher) waitForPageComplete(ctx context.Context, maxWaitTime time
 
 			// Log progress every 2 seconds for debugging
 			if time.Since(lastLogTime) >= 2*time.Second {
-				df.logger.Info("Page completion status: network=%v dom=%v ajax=%v table=%v (elapsed: %v)",
+				df.logger.Debug("Page completion status: network=%v dom=%v ajax=%v table=%v (elapsed: %v)",
 					networkIdle, domReady, ajaxComplete, dataTableReady, time.Since(start))
 				lastLogTime = time.Now()
 			}
@@ -1398,7 +1404,7 @@ func (df *DataFetcher) waitForPageComplete(ctx context.Context, maxWaitTime time
 			// For initial page load, we only need DOM ready and network idle
 			// Data table is not expected on the initial page
 			if networkIdle && domReady {
-				df.logger.Info("Initial page load completed in %v", time.Since(start))
+				df.logger.Debug("Initial page load completed in %v", time.Since(start))
 				return nil
 			}
 		}
@@ -1406,7 +1412,7 @@ func (df *DataFetcher) waitForPageComplete(ctx context.Context, maxWaitTime time
 		time.Sleep(50 * time.Millisecond) // Ultra-fast polling
 	}
 
-	df.logger.Error("Timeout waiting for page completion after %v, proceeding anyway", maxWaitTime)
+	df.logger.Warn("Timeout waiting for page completion after %v, proceeding anyway", maxWaitTime)
 	return nil // Don't fail hard, just proceed
 }
 
@@ -1476,19 +1482,19 @@ func (df *DataFetcher) waitForAjaxOperation(ctx context.Context, operationName s
 
 			// Log progress every 2 seconds
 			if time.Since(lastLogTime) >= 2*time.Second {
-				df.logger.Info("AJAX operation '%s': started=%v completed=%v (elapsed: %v)",
+				df.logger.Debug("AJAX operation '%s': started=%v completed=%v (elapsed: %v)",
 					operationName, started, completed, time.Since(start))
 				lastLogTime = time.Now()
 			}
 
 			if completed {
-				df.logger.Info("AJAX operation '%s' completed in %v", operationName, time.Since(start))
+				df.logger.Debug("AJAX operation '%s' completed in %v", operationName, time.Since(start))
 				return nil
 			}
 
 			// If operation hasn't started after 3 seconds, assume it's not going to happen
 			if time.Since(start) > 3*time.Second && !started {
-				df.logger.Info("AJAX operation '%s' didn't start, assuming no AJAX needed", operationName)
+				df.logger.Debug("AJAX operation '%s' didn't start, assuming no AJAX needed", operationName)
 				return nil
 			}
 		}
@@ -1496,7 +1502,7 @@ func (df *DataFetcher) waitForAjaxOperation(ctx context.Context, operationName s
 		time.Sleep(25 * time.Millisecond) // Ultra-fast polling for AJAX
 	}
 
-	df.logger.Error("Timeout waiting for AJAX operation '%s' after %v, proceeding anyway", operationName, maxWaitTime)
+	df.logger.Warn("Timeout waiting for AJAX operation '%s' after %v, proceeding anyway", operationName, maxWaitTime)
 	return nil // Don't fail hard
 }
 
@@ -1506,7 +1512,7 @@ func (df *DataFetcher) waitForDataTablePopulated(ctx context.Context, maxWaitTim
 	previousRowCount := 0
 	stableCount := 0
 
-	df.logger.Info("Waiting for data table to be populated...")
+	df.logger.Debug("Waiting for data table to be populated...")
 
 	for time.Since(start) < maxWaitTime {
 		var currentRowCount int
@@ -1538,7 +1544,7 @@ func (df *DataFetcher) waitForDataTablePopulated(ctx context.Context, maxWaitTim
 		if err == nil {
 			// Log progress every 2 seconds
 			if int(time.Since(start).Seconds())%2 == 0 && time.Since(start) >= 2*time.Second {
-				df.logger.Info("Data table check: %d rows found (elapsed: %v)", currentRowCount, time.Since(start))
+				df.logger.Debug("Data table check: %d rows found (elapsed: %v)", currentRowCount, time.Since(start))
 			}
 
 			if currentRowCount > 0 {
@@ -1546,13 +1552,13 @@ func (df *DataFetcher) waitForDataTablePopulated(ctx context.Context, maxWaitTim
 				if currentRowCount == previousRowCount {
 					stableCount++
 					if stableCount >= 3 { // Stable for 3 checks (300ms)
-						df.logger.Info("Data table populated with %d rows in %v", currentRowCount, time.Since(start))
+						df.logger.Debug("Data table populated with %d rows in %v", currentRowCount, time.Since(start))
 						return nil
 					}
 				} else {
 					stableCount = 0 // Reset stability counter
 					previousRowCount = currentRowCount
-					df.logger.Info("Data table rows changed to %d, waiting for stability", currentRowCount)
+					df.logger.Debug("Data table rows changed to %d, waiting for stability", currentRowCount)
 				}
 			}
 		} else {
@@ -1562,13 +1568,13 @@ func (df *DataFetcher) waitForDataTablePopulated(ctx context.Context, maxWaitTim
 		time.Sleep(50 * time.Millisecond) // Faster polling for table detection
 	}
 
-	df.logger.Error("Timeout waiting for data table population after %v, proceeding anyway", maxWaitTime)
+	df.logger.Warn("Timeout waiting for data table population after %v, proceeding anyway", maxWaitTime)
 	return nil // Don't fail hard, let the extraction attempt to proceed
 }
 
 // handleInitialPopups handles any initial popups that might appear (like year validation popup)
 func (df *DataFetcher) handleInitialPopups(ctx context.Context) error {
-	df.logger.Info("Checking for initial popups...")
+	df.logger.Debug("Checking for initial popups...")
 
 	// Check immediately first (some popups appear instantly with invalid dates)
 	if df.tryDismissPopup(ctx) {
@@ -1581,13 +1587,13 @@ func (df *DataFetcher) handleInitialPopups(ctx context.Context) error {
 		return nil
 	}
 
-	df.logger.Info("No popups detected after initial checks")
+	df.logger.Debug("No popups detected after initial checks")
 	return nil
 }
 
 // tryDismissPopup attempts to detect and dismiss any popup, returns true if successful
 func (df *DataFetcher) tryDismissPopup(ctx context.Context) bool {
-	df.logger.Info("Starting synchronous popup detection and dismissal...")
+	df.logger.Debug("Starting synchronous popup detection and dismissal...")
 
 	// Channel to track dialog events
 	dialogDetected := make(chan bool, 1)
@@ -1596,14 +1602,14 @@ func (df *DataFetcher) tryDismissPopup(ctx context.Context) bool {
 	chromedp.ListenTarget(ctx, func(ev interface{}) {
 		switch ev := ev.(type) {
 		case *page.EventJavascriptDialogOpening:
-			df.logger.Info("JavaScript dialog detected: Type=%s, Message='%s'", ev.Type, ev.Message)
+			df.logger.Debug("JavaScript dialog detected: Type=%s, Message='%s'", ev.Type, ev.Message)
 
 			// Handle the dialog immediately and synchronously
 			err := chromedp.Run(ctx, page.HandleJavaScriptDialog(true))
 			if err != nil {
 				df.logger.Error("Failed to handle JavaScript dialog: %v", err)
 			} else {
-				df.logger.Info("Successfully accepted JavaScript dialog")
+				df.logger.Debug("Successfully accepted JavaScript dialog")
 				select {
 				case dialogDetected <- true:
 				default:
@@ -1613,7 +1619,7 @@ func (df *DataFetcher) tryDismissPopup(ctx context.Context) bool {
 	})
 
 	// Immediate check for popup conditions using synchronous JavaScript execution
-	df.logger.Info("Checking for popup indicators in page content...")
+	df.logger.Debug("Checking for popup indicators in page content...")
 
 	var popupResultStr string
 
@@ -1669,25 +1675,25 @@ func (df *DataFetcher) tryDismissPopup(ctx context.Context) bool {
 		// Parse the JSON result
 		if parseErr := json.Unmarshal([]byte(popupResultStr), &popupResult); parseErr != nil {
 			df.logger.Error("Failed to parse popup result: %v", parseErr)
-			df.logger.Info("Raw result: %s", popupResultStr)
+			df.logger.Debug("Raw result: %s", popupResultStr)
 		} else {
-			df.logger.Info("Popup check results: HasPopup=%v, Type=%s, Text=%s",
+			df.logger.Debug("Popup check results: HasPopup=%v, Type=%s, Text=%s",
 				popupResult.HasPopup, popupResult.PopupType, popupResult.PopupText)
-			df.logger.Info("Page body preview: %s", popupResult.BodyPreview)
+			df.logger.Debug("Page body preview: %s", popupResult.BodyPreview)
 		}
 	}
 
 	// If popup detected, attempt to dismiss it
 	if popupResult.HasPopup {
-		df.logger.Info("Popup detected, attempting immediate dismissal...")
+		df.logger.Debug("Popup detected, attempting immediate dismissal...")
 
 		// Strategy 1: Try keyboard shortcuts (most reliable)
 		shortcuts := []string{"Enter", "Escape"}
 		for _, key := range shortcuts {
-			df.logger.Info("Trying %s key...", key)
+			df.logger.Debug("Trying %s key...", key)
 			keyErr := chromedp.Run(ctx, chromedp.KeyEvent(key))
 			if keyErr == nil {
-				df.logger.Info("Successfully sent %s key", key)
+				df.logger.Debug("Successfully sent %s key", key)
 				time.Sleep(500 * time.Millisecond) // Brief wait for response
 
 				// Check if popup is gone
@@ -1701,14 +1707,14 @@ func (df *DataFetcher) tryDismissPopup(ctx context.Context) bool {
 					`, &stillHasPopup),
 				)
 				if checkErr == nil && !stillHasPopup {
-					df.logger.Info("Popup successfully dismissed with %s key", key)
+					df.logger.Debug("Popup successfully dismissed with %s key", key)
 					return true
 				}
 			}
 		}
 
 		// Strategy 2: Try clicking OK buttons
-		df.logger.Info("Keyboard shortcuts failed, trying to click OK buttons...")
+		df.logger.Warn("Keyboard shortcuts failed, trying to click OK buttons...")
 		var buttonClicked bool
 		err = chromedp.Run(ctx,
 			chromedp.Evaluate(`
@@ -1742,7 +1748,7 @@ func (df *DataFetcher) tryDismissPopup(ctx context.Context) bool {
 		)
 
 		if err == nil && buttonClicked {
-			df.logger.Info("Successfully clicked a button to dismiss popup")
+			df.logger.Debug("Successfully clicked a button to dismiss popup")
 			time.Sleep(500 * time.Millisecond)
 			return true
 		}
@@ -1751,17 +1757,17 @@ func (df *DataFetcher) tryDismissPopup(ctx context.Context) bool {
 	// Check if a JavaScript dialog was detected during our execution
 	select {
 	case <-dialogDetected:
-		df.logger.Info("JavaScript dialog was handled during execution")
+		df.logger.Debug("JavaScript dialog was handled during execution")
 		return true
 	default:
 		// No dialog detected
 	}
 
 	// Wait briefly for any delayed dialogs
-	df.logger.Info("Waiting briefly for any delayed dialogs...")
+	df.logger.Debug("Waiting briefly for any delayed dialogs...")
 	select {
 	case <-dialogDetected:
-		df.logger.Info("Delayed JavaScript dialog was handled")
+		df.logger.Debug("Delayed JavaScript dialog was handled")
 		return true
 	case <-time.After(1 * time.Second):
 		// Timeout - no dialog appeared
@@ -1772,13 +1778,13 @@ func (df *DataFetcher) tryDismissPopup(ctx context.Context) bool {
 		return false
 	}
 
-	df.logger.Info("No popup detected")
+	df.logger.Debug("No popup detected")
 	return false
 }
 
 // setDateAndSearch sets the fromDate field to 01/01/2010 and clicks the Search button
 func (df *DataFetcher) setDateAndSearch(ctx context.Context, ticker string) error {
-	df.logger.Info("Setting company code to %s, fromDate to 01/01/2010 and triggering search", ticker)
+	df.logger.Debug("Setting company code to %s, fromDate to %s and triggering search", ticker, common.AppConfig.Scraper.FromDate)
 
 	// Set the company code, fromDate field and click Search button
 	err := chromedp.Run(ctx,
@@ -1792,10 +1798,11 @@ func (df *DataFetcher) setDateAndSearch(ctx context.Context, ticker string) erro
 				return err
 			}
 
-			df.logger.Info("Successfully set company code to %s", ticker)
+			df.logger.Debug("Successfully set company code to %s", ticker)
 
-			// Set the fromDate field to 1/1/2010 exactly like Python version
-			df.logger.Info("Setting fromDate field to 1/1/2010 (matching Python implementation)...")
+			// Set the fromDate field exactly like the Python version
+			fromDate := common.AppConfig.Scraper.FromDate
+			df.logger.Debug("Setting fromDate field to %s (matching Python implementation)...", fromDate)
 
 			// First, wait for the fromDate input field to be present (like Python does)
 			err = chromedp.Run(ctx,
@@ -1806,18 +1813,18 @@ func (df *DataFetcher) setDateAndSearch(ctx context.Context, ticker string) erro
 				return err
 			}
 
-			// Use JavaScript to set the value exactly like Python: document.querySelector("#fromDate").value = "1/1/2010"
+			// Use JavaScript to set the value exactly like Python: document.querySelector("#fromDate").value = fromDate
 			// Clear field first, then set the value to avoid validation issues
 			err = chromedp.Run(ctx,
-				chromedp.Evaluate(`
+				chromedp.Evaluate(fmt.Sprintf(`
 					var fromDateField = document.querySelector("#fromDate");
 					if (fromDateField) {
 						fromDateField.value = "";
 						fromDateField.focus();
-						fromDateField.value = "1/1/2010";
+						fromDateField.value = %q;
 						fromDateField.blur();
 					}
-				`, nil),
+				`, fromDate), nil),
 			)
 			if err != nil {
 				df.logger.Error("Failed to set fromDate value with JavaScript: %v", err)
@@ -1837,15 +1844,15 @@ func (df *DataFetcher) setDateAndSearch(ctx context.Context, ticker string) erro
 				return err
 			}
 
-			if actualValue != "1/1/2010" {
-				df.logger.Error("Failed to set the fromDate input value. Expected '1/1/2010', got '%s'", actualValue)
+			if actualValue != fromDate {
+				df.logger.Error("Failed to set the fromDate input value. Expected '%s', got '%s'", fromDate, actualValue)
 				return fmt.Errorf("failed to set the fromDate input value")
 			}
 
-			df.logger.Info("Successfully set and verified fromDate to 1/1/2010")
+			df.logger.Debug("Successfully set and verified fromDate to %s", fromDate)
 
 			// Find and click the search button with id="button"
-			df.logger.Info("Finding and clicking the search button with id='button'...")
+			df.logger.Debug("Finding and clicking the search button with id='button'...")
 
 			// First try to click the correct search button that calls submitForm()
 			var clickSuccess bool
@@ -1867,7 +1874,7 @@ func (df *DataFetcher) setDateAndSearch(ctx context.Context, ticker string) erro
 			)
 
 			if err != nil || !clickSuccess {
-				df.logger.Info("submitForm() button click failed, trying alternative approaches...")
+				df.logger.Warn("submitForm() button click failed, trying alternative approaches...")
 
 				// Try calling submitForm() directly since that's what the onclick does
 				err = chromedp.Run(ctx,
@@ -1882,7 +1889,7 @@ func (df *DataFetcher) setDateAndSearch(ctx context.Context, ticker string) erro
 					`, &clickSuccess),
 				)
 				if err == nil && clickSuccess {
-					df.logger.Info("Successfully called submitForm() directly")
+					df.logger.Debug("Successfully called submitForm() directly")
 				} else {
 					// Try finding button by name="Search"
 					err = chromedp.Run(ctx,
@@ -1898,7 +1905,7 @@ func (df *DataFetcher) setDateAndSearch(ctx context.Context, ticker string) erro
 						`, &clickSuccess),
 					)
 					if err == nil && clickSuccess {
-						df.logger.Info("Successfully clicked search button by name and onclick")
+						df.logger.Debug("Successfully clicked search button by name and onclick")
 					} else {
 						// Last resort: try to find any button with submitForm in onclick
 						err = chromedp.Run(ctx,
@@ -1917,7 +1924,7 @@ func (df *DataFetcher) setDateAndSearch(ctx context.Context, ticker string) erro
 							`, &clickSuccess),
 						)
 						if err == nil && clickSuccess {
-							df.logger.Info("Successfully clicked button with submitForm onclick")
+							df.logger.Debug("Successfully clicked button with submitForm onclick")
 						} else {
 							df.logger.Error("Failed to click search button with any method")
 							return fmt.Errorf("failed to click search button")
@@ -1925,7 +1932,7 @@ func (df *DataFetcher) setDateAndSearch(ctx context.Context, ticker string) erro
 					}
 				}
 			} else {
-				df.logger.Info("Successfully clicked search button that calls submitForm()")
+				df.logger.Debug("Successfully clicked search button that calls submitForm()")
 			}
 			return nil
 		}),
@@ -1937,7 +1944,7 @@ func (df *DataFetcher) setDateAndSearch(ctx context.Context, ticker string) erro
 	}
 
 	// Wait for the search results to load - specifically wait for the data table like Python does
-	df.logger.Info("Waiting for data table to load after search (like Python implementation)...")
+	df.logger.Debug("Waiting for data table to load after search (like Python implementation)...")
 	err = chromedp.Run(ctx,
 		chromedp.WaitVisible(`#dispTable`, chromedp.ByID),
 	)
@@ -1945,7 +1952,7 @@ func (df *DataFetcher) setDateAndSearch(ctx context.Context, ticker string) erro
 		df.logger.Error("Failed to wait for data table: %v", err)
 		// Don't fail hard, continue with page completion wait
 	} else {
-		df.logger.Info("Data table is now visible")
+		df.logger.Debug("Data table is now visible")
 	}
 
 	// Also wait for page completion as backup
@@ -0,0 +1,210 @@
+package scraper
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gocarina/gocsv"
+
+	"isx-auto-scrapper/internal/calendar"
+	"isx-auto-scrapper/internal/common"
+)
+
+// maxListedMissingDates limits how many missing dates are written per ticker
+const maxListedMissingDates = 50
+
+// GenerateGapReport compares each ticker's raw_*.csv dates with the sessions the market was open.
+// A session counts as open when the calendar lists it as a trading day and at least one
+// ticker in the universe traded. It also returns calendar trading days on which no ticker
+// traded at all in the years ISX_HOLIDAYS.csv covers; those are usually holidays missing from it.
+// Other years have no lunar holidays listed, so their closures are not reported.
+func GenerateGapReport(tickers []common.TickerInfo, cal *calendar.Calendar, now time.Time) ([]common.GapReport, []time.Time, error) {
+	tickerDates := make(map[string][]time.Time)
+	observed := make(map[string]bool)
+
+	for _, t := range tickers {
+		dates, err := loadRawDates(fmt.Sprintf("raw_%s.csv", t.Symbol))
+		if err != nil {
+			continue
+		}
+		tickerDates[t.Symbol] = dates
+		for _, d := range dates {
+			observed[d.Format("2006-01-02")] = true
+		}
+	}
+
+	if len(observed) == 0 {
+		return nil, nil, fmt.Errorf("no raw data files found")
+	}
+
+	// Universe date range
+	var universeFirst, universeLast time.Time
+	for key := range observed {
+		d, _ := time.Parse("2006-01-02", key)
+		if universeFirst.IsZero() || d.Before(universeFirst) {
+			universeFirst = d
+		}
+		if d.After(universeLast) {
+			universeLast = d
+		}
+	}
+
+	var closures []time.Time
+	for _, d := range cal.TradingDays(universeFirst, universeLast) {
+		if cal.Covers(d) && !observed[d.Format("2006-01-02")] {
+			closures = append(closures, d)
+		}
+	}
+
+	lastCompleted := cal.LastCompletedSession(now)
+
+	var reports []common.GapReport
+	for _, t := range tickers {
+		report := common.GapReport{
+			Ticker:      t.Symbol,
+			Sector:      t.Sector,
+			CompanyName: t.CompanyName,
+		}
+
+		dates := tickerDates[t.Symbol]
+		if len(dates) == 0 {
+			report.Status = "NO_DATA"
+			reports = append(reports, report)
+			continue
+		}
+
+		first := dates[0]
+		last := dates[len(dates)-1]
+		report.FirstDataDate = first.Format("2006-01-02")
+		report.LastDataDate = last.Format("2006-01-02")
+
+		present := make(map[string]bool, len(dates))
+		for _, d := range dates {
+			present[d.Format("2006-01-02")] = true
+			if !cal.IsTradingDay(d) {
+				report.NonTradingRows++
+			}
+		}
+
+		var missing []string
+		run := 0
+		for _, d := range cal.TradingDays(first, last) {
+			key := d.Format("2006-01-02")
+			if !observed[key] {
+				continue // market closed for everyone
+			}
+			report.ExpectedSessions++
+			if present[key] {
+				report.PresentSessions++
+				run = 0
+				continue
+			}
+			missing = append(missing, key)
+			run++
+			if run > report.LongestGap {
+				report.LongestGap = run
+			}
+		}
+
+		report.MissingSessions = len(missing)
+		if report.ExpectedSessions > 0 {
+			report.CoveragePercent = fmt.Sprintf("%.2f", float64(report.PresentSessions)/float64(report.ExpectedSessions)*100)
+		}
+		if len(missing) > maxListedMissingDates {
+			report.MissingDates = strings.Join(missing[len(missing)-maxListedMissingDates:], ";") +
+				fmt.Sprintf(";(+%d earlier)", len(missing)-maxListedMissingDates)
+		} else {
+			report.MissingDates = strings.Join(missing, ";")
+		}
+
+		if last.Before(lastCompleted) {
+			report.SessionsBehind = cal.CountTradingDays(last.AddDate(0, 0, 1), lastCompleted)
+		}
+
+		switch {
+		case report.SessionsBehind > 0:
+			report.Status = "STALE"
+		case report.MissingSessions > 0:
+			report.Status = "GAPS"
+		default:
+			report.Status = "COMPLETE"
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, closures, nil
+}
+
+// loadRawDates reads the sorted dates from a raw_*.csv file
+func loadRawDates(filename string) ([]time.Time, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var dates []time.Time
+	// Skip header row
+	for i := 1; i < len(records); i++ {
+		if len(records[i]) == 0 {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(records[i][0]))
+		if err != nil {
+			continue
+		}
+		dates = append(dates, date)
+	}
+
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+
+	return dates, nil
+}
+
+// SaveGapReport saves the per-ticker gap report to CSV
+func SaveGapReport(reports []common.GapReport, filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return gocsv.MarshalFile(&reports, file)
+}
+
+// SaveMarketClosures saves calendar trading days on which no ticker traded
+func SaveMarketClosures(closures []time.Time, filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"Date", "Weekday"}); err != nil {
+		return err
+	}
+	for _, d := range closures {
+		if err := writer.Write([]string{d.Format("2006-01-02"), d.Weekday().String()}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -9,12 +10,13 @@ import (
 	"strings"
 	"time"
 
-	"github.com/gocarina/gocsv"
-
+	"isx-auto-scrapper/internal/calendar"
 	"isx-auto-scrapper/internal/common"
+	"isx-auto-scrapper/internal/i18n"
 	"isx-auto-scrapper/internal/indicators"
 	"isx-auto-scrapper/internal/liquidity"
 	"isx-auto-scrapper/internal/report"
+	"isx-auto-scrapper/internal/risk"
 	"isx-auto-scrapper/internal/scraper"
 	"isx-auto-scrapper/internal/strategies"
 )
@@ -22,13 +24,16 @@ import (
 // WebServer handles HTTP requests for the dashboard
 type WebServer struct {
 	logger *common.Logger
+	host   string
 	port   int
 }
 
-// NewWebServer creates a new WebServer instance
-func NewWebServer(port int) *WebServer {
+// NewWebServer creates a new WebServer instance listening on host:port.
+// An empty host listens on all interfaces.
+func NewWebServer(logger *common.Logger, host string, port int) *WebServer {
 	return &WebServer{
-		logger: common.NewLogger(),
+		logger: logger,
+		host:   host,
 		port:   port,
 	}
 }
@@ -51,7 +56,10 @@ func (ws *WebServer) Start() error {
 	mux.HandleFunc("/api/calculate_num", ws.handleCalculateNum)
 	mux.HandleFunc("/api/fetch", ws.handleFetch)
 	mux.HandleFunc("/api/liquidity", ws.handleLiquidity)
+	mux.HandleFunc("/api/risk", ws.handleRisk)
 	mux.HandleFunc("/api/daily_report", ws.handleDailyReport)
+	mux.HandleFunc("/api/divergences", ws.handleDivergences)
+	mux.HandleFunc("/api/signals/changes", ws.handleSignalChanges)
 	mux.HandleFunc("/api/daily_report_excel", ws.handleDailyReportExcel)
 
 	// CORS middleware
@@ -71,12 +79,16 @@ func (ws *WebServer) Start() error {
 	}
 
 	server := &http.Server{
-		Addr:    fmt.Sprintf(":%d", ws.port),
+		Addr:    fmt.Sprintf("%s:%d", ws.host, ws.port),
 		Handler: corsHandler(mux),
 	}
 
-	ws.logger.Info("Web server starting on http://localhost:%d", ws.port)
-	ws.logger.Info("Dashboard available at: http://localhost:%d", ws.port)
+	displayHost := ws.host
+	if displayHost == "" || displayHost == "0.0.0.0" {
+		displayHost = "localhost"
+	}
+	ws.logger.Info("Web server starting on %s", server.Addr)
+	ws.logger.Info("Dashboard available at: http://%s:%d", displayHost, ws.port)
 
 	return server.ListenAndServe()
 }
@@ -86,6 +98,12 @@ func (ws *WebServer) Start() error {
 func (ws *WebServer) handleTickers(w http.ResponseWriter, r *http.Request) {
 	ws.logger.Info("API: Getting tickers list")
 
+	lang, err := requestLanguage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Read TICKERS.csv
 	tickers, err := ws.loadTickersList()
 	if err != nil {
@@ -95,6 +113,7 @@ func (ws *WebServer) handleTickers(w http.ResponseWriter, r *http.Request) {
 
 	// Add price data from raw files
 	for i, ticker := range tickers {
+		tickers[i].CompanyName = ticker.DisplayName(lang)
 		priceData, err := ws.getLastPrice(ticker.Symbol)
 		if err == nil {
 			tickers[i].Date = priceData.Date
@@ -131,20 +150,77 @@ func (ws *WebServer) handleTickerData(w http.ResponseWriter, r *http.Request) {
 		dataType = "price" // default
 	}
 
+	tf, err := requestTimeframe(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	lang, err := requestLanguage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	switch dataType {
 	case "price":
-		ws.handlePriceData(w, symbol)
+		ws.handlePriceData(w, symbol, tf)
 	case "indicators":
-		ws.handleIndicatorData(w, symbol)
+		ws.handleIndicatorData(w, r, symbol, tf)
 	case "strategies":
-		ws.handleTickerStrategies(w, r, symbol)
+		ws.handleTickerStrategies(w, r, symbol, tf, lang)
+	case "profile":
+		ws.handleVolumeProfile(w, r, symbol, tf)
+	case "patterns":
+		ws.handlePatterns(w, r, symbol, tf)
+	case "levels":
+		ws.handleLevels(w, r, symbol, tf)
+	case "divergences":
+		ws.handleTickerDivergences(w, symbol, tf)
+	case "risk":
+		ws.handleTickerRisk(w, symbol, tf)
+	case "relative":
+		ws.handleTickerRelative(w, symbol, tf)
+	case "adjustments":
+		ws.handleTickerAdjustments(w, symbol)
 	default:
 		http.Error(w, "Invalid data type", http.StatusBadRequest)
 	}
 }
 
-func (ws *WebServer) handlePriceData(w http.ResponseWriter, symbol string) {
-	priceData, err := ws.loadPriceData(symbol)
+// requestTimeframe reads the tf query parameter; daily when absent
+func requestTimeframe(r *http.Request) (indicators.Timeframe, error) {
+	value := r.URL.Query().Get("tf")
+	if value == "" {
+		return indicators.Daily, nil
+	}
+	return indicators.ParseTimeframe(value)
+}
+
+// requestLanguage reads the lang query parameter; the configured language when absent
+func requestLanguage(r *http.Request) (string, error) {
+	lang := r.URL.Query().Get("lang")
+	if lang == "" {
+		return common.AppConfig.Language, nil
+	}
+	if !i18n.Valid(lang) {
+		return "", fmt.Errorf("lang %q must be one of %s", lang, strings.Join(i18n.Supported(), ", "))
+	}
+	return lang, nil
+}
+
+// loadFrame reads the raw bars of symbol and resamples them to tf.
+// The second result reports whether the last bar covers a period still in progress.
+func (ws *WebServer) loadFrame(symbol string, tf indicators.Timeframe) (*indicators.Frame, bool, error) {
+	frame, err := indicators.LoadRawFrame(fmt.Sprintf("raw_%s.csv", symbol))
+	if err != nil {
+		return nil, false, fmt.Errorf("price data not found for %s", symbol)
+	}
+	frame, partial := indicators.Resample(frame, tf, calendar.Default(), time.Now(), common.AppConfig.Indicators.PartialPeriods)
+	return frame, partial, nil
+}
+
+func (ws *WebServer) handlePriceData(w http.ResponseWriter, symbol string, tf indicators.Timeframe) {
+	priceData, err := ws.loadPriceData(symbol, tf)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -154,8 +230,9 @@ func (ws *WebServer) handlePriceData(w http.ResponseWriter, symbol string) {
 	json.NewEncoder(w).Encode(priceData)
 }
 
-func (ws *WebServer) handleIndicatorData(w http.ResponseWriter, symbol string) {
-	indicators, err := ws.loadIndicatorData(symbol)
+// handleIndicatorData returns the last indicator row; lang rewrites its descriptions in that language
+func (ws *WebServer) handleIndicatorData(w http.ResponseWriter, r *http.Request, symbol string, tf indicators.Timeframe) {
+	indicators, err := ws.loadIndicatorData(symbol, tf, r.URL.Query().Get("lang"))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -165,9 +242,9 @@ func (ws *WebServer) handleIndicatorData(w http.ResponseWriter, symbol string) {
 	json.NewEncoder(w).Encode(indicators)
 }
 
-func (ws *WebServer) handleTickerStrategies(w http.ResponseWriter, r *http.Request, symbol string) {
+func (ws *WebServer) handleTickerStrategies(w http.ResponseWriter, r *http.Request, symbol string, tf indicators.Timeframe, lang string) {
 	full := r.URL.Query().Get("full") == "1"
-	strategies, err := ws.loadTickerStrategies(symbol, full)
+	strategies, err := ws.loadTickerStrategies(symbol, full, tf, lang)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -177,20 +254,206 @@ func (ws *WebServer) handleTickerStrategies(w http.ResponseWriter, r *http.Reque
 	json.NewEncoder(w).Encode(strategies)
 }
 
+// handleVolumeProfile returns the volume traded at each price; bars and bins override the configured window
+func (ws *WebServer) handleVolumeProfile(w http.ResponseWriter, r *http.Request, symbol string, tf indicators.Timeframe) {
+	bars := common.AppConfig.Indicators.ProfileBars
+	bins := common.AppConfig.Indicators.ProfileBins
+	for name, target := range map[string]*int{"bars": &bars, "bins": &bins} {
+		value := r.URL.Query().Get(name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			http.Error(w, fmt.Sprintf("%s must be a whole number of at least 1", name), http.StatusBadRequest)
+			return
+		}
+		*target = n
+	}
+
+	frame, partial, err := ws.loadFrame(symbol, tf)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	profile := indicators.NewVolumeProfile(frame, bars, bins)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"ticker":    symbol,
+		"timeframe": tf,
+		"partial":   partial,
+		"profile":   profile,
+	})
+}
+
+// handlePatterns returns the candlestick patterns found in the price history; trend overrides the trend window
+func (ws *WebServer) handlePatterns(w http.ResponseWriter, r *http.Request, symbol string, tf indicators.Timeframe) {
+	trend := indicators.DefaultPatternTrend
+	if value := r.URL.Query().Get("trend"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 2 {
+			http.Error(w, "trend must be a whole number of at least 2", http.StatusBadRequest)
+			return
+		}
+		trend = n
+	}
+
+	frame, partial, err := ws.loadFrame(symbol, tf)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"ticker":    symbol,
+		"timeframe": tf,
+		"partial":   partial,
+		"patterns":  indicators.DetectPatterns(frame, trend),
+	})
+}
+
+// handleLevels returns pivots, swings, support/resistance zones and Fibonacci retracements;
+// lookback overrides the configured swing lookback
+func (ws *WebServer) handleLevels(w http.ResponseWriter, r *http.Request, symbol string, tf indicators.Timeframe) {
+	cfg := common.AppConfig.Indicators
+	options := indicators.LevelOptions{
+		Lookback:  cfg.SwingLookback,
+		Tolerance: cfg.ZoneTolerance,
+		Bars:      cfg.LevelBars,
+		FibBars:   cfg.FibBars,
+	}
+	if value := r.URL.Query().Get("lookback"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			http.Error(w, "lookback must be a whole number of at least 1", http.StatusBadRequest)
+			return
+		}
+		options.Lookback = n
+	}
+
+	frame, partial, err := ws.loadFrame(symbol, tf)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"ticker":    symbol,
+		"timeframe": tf,
+		"partial":   partial,
+		"levels":    indicators.NewLevels(frame, options),
+	})
+}
+
+// handleTickerDivergences returns the divergences stored by calc for the ticker, oldest first
+func (ws *WebServer) handleTickerDivergences(w http.ResponseWriter, symbol string, tf indicators.Timeframe) {
+	events, err := indicators.LoadDivergences(indicators.DivergenceFile(symbol, tf))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("divergence data not found for %s", symbol), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"ticker":      symbol,
+		"timeframe":   tf,
+		"divergences": events,
+	})
+}
+
+// handleTickerRisk serves the rolling volatility and risk metrics written by the risk command
+func (ws *WebServer) handleTickerRisk(w http.ResponseWriter, symbol string, tf indicators.Timeframe) {
+	if tf != indicators.Daily {
+		http.Error(w, "risk metrics are calculated on daily bars only", http.StatusBadRequest)
+		return
+	}
+	records, err := risk.LoadRecords(risk.File(symbol))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("risk data not found for %s", symbol), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"ticker":     symbol,
+		"window":     common.AppConfig.Risk.Window,
+		"confidence": common.AppConfig.Risk.Confidence,
+		"records":    records,
+	})
+}
+
+// handleTickerRelative serves relative strength, RS rank and beta; weekly and monthly bars get
+// the row of the session that closed them
+func (ws *WebServer) handleTickerRelative(w http.ResponseWriter, symbol string, tf indicators.Timeframe) {
+	records, err := indicators.LoadRelative(indicators.RelativeFile(symbol))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("relative strength data not found for %s", symbol), http.StatusNotFound)
+		return
+	}
+
+	if tf != indicators.Daily {
+		frame, _, err := ws.loadFrame(symbol, tf)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		barDates := make(map[string]bool, frame.Len())
+		for _, d := range frame.Dates {
+			barDates[d.Format("2006-01-02")] = true
+		}
+		var bars []*indicators.RelativeRecord
+		for _, rec := range records {
+			if barDates[rec.Date] {
+				bars = append(bars, rec)
+			}
+		}
+		records = bars
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"ticker":    symbol,
+		"timeframe": tf,
+		"records":   records,
+	})
+}
+
+// handleTickerAdjustments serves the bar cleaning report written by calc
+func (ws *WebServer) handleTickerAdjustments(w http.ResponseWriter, symbol string) {
+	adjustments, err := indicators.LoadAdjustments(indicators.AdjustmentsFile(symbol))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("bar adjustments not found for %s", symbol), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"ticker":      symbol,
+		"policy":      common.AppConfig.Indicators.BarCleaning,
+		"adjustments": adjustments,
+	})
+}
+
 func (ws *WebServer) handleStrategies(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "POST" {
 		ws.logger.Info("API: Running strategies")
 
 		go func() {
-			strat := strategies.NewStrategies()
-			if err := strat.ApplyStrategiesAndSave(); err != nil {
-				ws.logger.Error("Strategy processing failed: %v", err)
-			}
-			if err := strat.ApplyAlternativeStrategyStates(); err != nil {
-				ws.logger.Error("Alternative states failed: %v", err)
-			}
-			if err := strat.SummarizeStrategyActions(); err != nil {
-				ws.logger.Error("Summary generation failed: %v", err)
+			for _, tf := range indicators.ConfiguredTimeframes() {
+				strat := strategies.NewStrategies(ws.logger.WithStage("strategies"))
+				strat.SetTimeframe(tf)
+				if err := strat.ApplyStrategiesAndSave(); err != nil {
+					ws.logger.Error("Strategy processing failed: %v", err)
+				}
+				if err := strat.ApplyConsensus(); err != nil {
+					ws.logger.Error("Consensus failed: %v", err)
+				}
+				if err := strat.SummarizeStrategyActions(); err != nil {
+					ws.logger.Error("Summary generation failed: %v", err)
+				}
 			}
 			ws.logger.Info("Strategies processing completed")
 		}()
@@ -205,7 +468,12 @@ func (ws *WebServer) handleStrategies(w http.ResponseWriter, r *http.Request) {
 
 	ws.logger.Info("API: Getting strategies summary")
 
-	data, err := os.ReadFile("Strategy_Summary.json")
+	tf, err := requestTimeframe(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, err := os.ReadFile(fmt.Sprintf("Strategy_Summary%s.json", tf.Suffix()))
 	if err != nil {
 		http.Error(w, "Strategy summary not found", http.StatusNotFound)
 		return
@@ -226,7 +494,7 @@ func (ws *WebServer) handleBacktest(w http.ResponseWriter, r *http.Request) {
 	// Run backtesting in background
 	go func() {
 		// You could integrate with your existing backtesting logic here
-		strategyTester := strategies.NewStrategyTester()
+		strategyTester := strategies.NewStrategyTester(ws.logger.WithStage("backtest"))
 
 		// Run simulation
 		strategyTester.SimulateStrategyResults()
@@ -249,10 +517,6 @@ func (ws *WebServer) handleRefresh(w http.ResponseWriter, r *http.Request) {
 
 	tickerParam := r.URL.Query().Get("ticker")
 
-	dataFetcher := scraper.NewDataFetcher()
-	indicatorsCalculator := indicators.NewIndicatorsCalculator()
-	stratSvc := strategies.NewStrategies()
-
 	var tickers []string
 	if tickerParam != "" {
 		ws.logger.Info("API: Refreshing data for %s", tickerParam)
@@ -270,24 +534,37 @@ func (ws *WebServer) handleRefresh(w http.ResponseWriter, r *http.Request) {
 
 	success := true
 	for _, ticker := range tickers {
+		dataFetcher := scraper.NewDataFetcher(ws.logger.WithStage("fetch").WithTicker(ticker))
 		if err := dataFetcher.FetchData(ticker); err != nil {
 			ws.logger.Error("Failed to fetch data for %s: %v", ticker, err)
 			success = false
 			continue
 		}
 
-		if err := indicatorsCalculator.CalculateAll(ticker); err != nil {
-			ws.logger.Error("Failed to calculate indicators for %s: %v", ticker, err)
-			success = false
+		for _, tf := range indicators.ConfiguredTimeframes() {
+			indicatorsCalculator := indicators.NewIndicatorsCalculator(ws.logger.WithStage("calc").WithTicker(ticker))
+			indicatorsCalculator.SetTimeframe(tf)
+			if err := indicatorsCalculator.CalculateAll(ticker); err != nil {
+				ws.logger.Error("Failed to calculate %s indicators for %s: %v", tf.Name(), ticker, err)
+				success = false
+			}
 		}
 	}
-
-	if err := stratSvc.ApplyStrategiesAndSave(); err != nil {
-		ws.logger.Error("Failed to apply strategies: %v", err)
+	if err := indicators.NewRelativeStrengthCalc(ws.logger.WithStage("calc")).CalculateAll(); err != nil {
+		ws.logger.Error("Failed to calculate relative strength: %v", err)
 		success = false
-	} else {
-		if err := stratSvc.ApplyAlternativeStrategyStates(); err != nil {
-			ws.logger.Error("Failed to apply alternative strategy states: %v", err)
+	}
+
+	for _, tf := range indicators.ConfiguredTimeframes() {
+		stratSvc := strategies.NewStrategies(ws.logger.WithStage("strategies"))
+		stratSvc.SetTimeframe(tf)
+		if err := stratSvc.ApplyStrategiesAndSave(); err != nil {
+			ws.logger.Error("Failed to apply strategies: %v", err)
+			success = false
+			continue
+		}
+		if err := stratSvc.ApplyConsensus(); err != nil {
+			ws.logger.Error("Failed to apply consensus signals: %v", err)
 		}
 		if err := stratSvc.SummarizeStrategyActions(); err != nil {
 			ws.logger.Error("Failed to summarize strategies: %v", err)
@@ -323,23 +600,27 @@ func (ws *WebServer) handleCalculate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	go func() {
-		calc := indicators.NewIndicatorsCalculator()
-		if ticker != "" {
-			if err := calc.CalculateAll(ticker); err != nil {
-				ws.logger.Error("Indicator calculation failed: %v", err)
-			}
-		} else {
-			tickers, err := common.LoadTickers("TICKERS.csv")
+		tickers := []string{ticker}
+		if ticker == "" {
+			var err error
+			tickers, err = common.LoadTickers("TICKERS.csv")
 			if err != nil {
 				ws.logger.Error("Failed to load tickers: %v", err)
 				return
 			}
+		}
+		for _, tf := range indicators.ConfiguredTimeframes() {
+			calc := indicators.NewIndicatorsCalculator(ws.logger.WithStage("calc").WithTicker(ticker))
+			calc.SetTimeframe(tf)
 			for _, t := range tickers {
 				if err := calc.CalculateAll(t); err != nil {
-					ws.logger.Error("Failed to calculate for %s: %v", t, err)
+					ws.logger.Error("Failed to calculate %s indicators for %s: %v", tf.Name(), t, err)
 				}
 			}
 		}
+		if err := indicators.NewRelativeStrengthCalc(ws.logger.WithStage("calc")).CalculateAll(); err != nil {
+			ws.logger.Error("Failed to calculate relative strength: %v", err)
+		}
 
 		ws.logger.Info("Indicator calculation completed")
 	}()
@@ -365,7 +646,7 @@ func (ws *WebServer) handleCalculateNum(w http.ResponseWriter, r *http.Request)
 	}
 
 	go func() {
-		calc := indicators.NewNumericalIndicatorsCalculator()
+		calc := indicators.NewNumericalIndicatorsCalculator(ws.logger.WithStage("calc").WithTicker(ticker))
 		if ticker != "" {
 			if err := calc.CalculateAllNums(ticker); err != nil {
 				ws.logger.Error("Numeric indicator calculation failed: %v", err)
@@ -382,6 +663,9 @@ func (ws *WebServer) handleCalculateNum(w http.ResponseWriter, r *http.Request)
 				}
 			}
 		}
+		if err := indicators.NewRelativeStrengthCalc(ws.logger.WithStage("calc")).CalculateAll(); err != nil {
+			ws.logger.Error("Failed to calculate relative strength: %v", err)
+		}
 
 		ws.logger.Info("Numeric indicator calculation completed")
 	}()
@@ -408,7 +692,7 @@ func (ws *WebServer) handleFetch(w http.ResponseWriter, r *http.Request) {
 	ws.logger.Info("API: Fetching data for %s", ticker)
 
 	go func() {
-		df := scraper.NewDataFetcher()
+		df := scraper.NewDataFetcher(ws.logger.WithStage("fetch").WithTicker(ticker))
 		if err := df.FetchData(ticker); err != nil {
 			ws.logger.Error("Failed to fetch data for %s: %v", ticker, err)
 		} else {
@@ -432,7 +716,7 @@ func (ws *WebServer) handleLiquidity(w http.ResponseWriter, r *http.Request) {
 	ws.logger.Info("API: Calculating liquidity scores")
 
 	go func() {
-		lc := liquidity.NewLiquidityCalc()
+		lc := liquidity.NewLiquidityCalc(ws.logger.WithStage("liquidity"))
 		if err := lc.CalculateScores(); err != nil {
 			ws.logger.Error("Liquidity calculation failed: %v", err)
 			return
@@ -447,9 +731,50 @@ func (ws *WebServer) handleLiquidity(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleRisk serves risk_summary.csv; POST recalculates the risk metrics of every ticker
+func (ws *WebServer) handleRisk(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		ws.logger.Info("API: Calculating risk metrics")
+
+		go func() {
+			if err := risk.NewRiskCalc(ws.logger.WithStage("risk")).CalculateAll(); err != nil {
+				ws.logger.Error("Risk calculation failed: %v", err)
+				return
+			}
+			ws.logger.Info("Risk metrics calculation completed")
+		}()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{
+			"status":  "started",
+			"message": "Risk calculation initiated",
+		})
+		return
+	}
+
+	ws.logger.Info("API: Getting risk summary")
+	summary, err := risk.LoadSummary()
+	if err != nil {
+		http.Error(w, "risk summary not found; run the risk command first", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"window":     common.AppConfig.Risk.Window,
+		"confidence": common.AppConfig.Risk.Confidence,
+		"tickers":    summary,
+	})
+}
+
 func (ws *WebServer) handleDailyReport(w http.ResponseWriter, r *http.Request) {
 	ws.logger.Info("API: Generating daily report")
-	rep, err := report.GenerateDailyReport(time.Now())
+	lang, err := requestLanguage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rep, err := report.GenerateDailyReport(time.Now(), lang)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -458,9 +783,125 @@ func (ws *WebServer) handleDailyReport(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(rep)
 }
 
+// handleDivergences lists the divergences confirmed on the latest session across all tickers;
+// date picks another session
+func (ws *WebServer) handleDivergences(w http.ResponseWriter, r *http.Request) {
+	ws.logger.Info("API: Getting divergences")
+
+	lang, err := requestLanguage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var session string
+	var divergences []report.Divergence
+	if date := r.URL.Query().Get("date"); date != "" {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			http.Error(w, "date must be YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		tickers, err := common.LoadTickersWithInfo("TICKERS.csv")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		session, divergences = date, report.MarketDivergences(tickers, date, lang)
+	} else {
+		session, divergences, err = report.TodaysDivergences(time.Now(), lang)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"date":        session,
+		"divergences": divergences,
+	})
+}
+
+// signalChange is a logged signal change with the company name
+type signalChange struct {
+	strategies.SignalEvent
+	Name string `json:"name"`
+}
+
+// handleSignalChanges lists the signal changes logged on or after since, the latest logged session by
+// default; strategy narrows them to one strategy and to to the changes into buy, sell or hold signals
+func (ws *WebServer) handleSignalChanges(w http.ResponseWriter, r *http.Request) {
+	ws.logger.Info("API: Getting signal changes")
+
+	lang, err := requestLanguage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	tf, err := requestTimeframe(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+	since, strategy, to := query.Get("since"), query.Get("strategy"), strings.ToLower(query.Get("to"))
+	if since != "" {
+		if _, err := time.Parse("2006-01-02", since); err != nil {
+			http.Error(w, "since must be YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+	}
+	if to != "" && to != "buy" && to != "sell" && to != "hold" {
+		http.Error(w, "to must be buy, sell or hold", http.StatusBadRequest)
+		return
+	}
+
+	events, err := strategies.LoadSignalEvents(strategies.SignalEventsFile(tf))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("signal changes not found for timeframe %s; run strategies first", tf), http.StatusNotFound)
+		return
+	}
+	if since == "" && len(events) > 0 {
+		since = events[len(events)-1].Date
+	}
+
+	names := make(map[string]string)
+	if tickers, err := common.LoadTickersWithInfo("TICKERS.csv"); err == nil {
+		for _, t := range tickers {
+			names[t.Symbol] = t.DisplayName(lang)
+		}
+	}
+
+	changes := []signalChange{}
+	for _, e := range strategies.SignalChanges(events, since) {
+		switch {
+		case strategy != "" && e.Strategy != strategy,
+			to == "buy" && !e.To.IsBuy(),
+			to == "sell" && !e.To.IsSell(),
+			to == "hold" && e.To != strategies.Hold:
+			continue
+		}
+		e.From = strategies.Signal(i18n.Signal(lang, string(e.From)))
+		e.To = strategies.Signal(i18n.Signal(lang, string(e.To)))
+		changes = append(changes, signalChange{SignalEvent: e, Name: names[e.Ticker]})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"since":     since,
+		"timeframe": tf,
+		"changes":   changes,
+	})
+}
+
 func (ws *WebServer) handleDailyReportExcel(w http.ResponseWriter, r *http.Request) {
 	ws.logger.Info("API: Generating daily report Excel")
-	rep, err := report.GenerateDailyReport(time.Now())
+	lang, err := requestLanguage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rep, err := report.GenerateDailyReport(time.Now(), lang)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -524,11 +965,16 @@ func (ws *WebServer) loadTickersList() ([]common.TickerInfo, error) {
 			if len(parts) >= 3 {
 				companyName = strings.TrimSpace(parts[2])
 			}
+			nameAr := ""
+			if len(parts) >= 4 {
+				nameAr = strings.TrimSpace(parts[3])
+			}
 
 			tickers = append(tickers, common.TickerInfo{
 				Symbol:      symbol,
 				Sector:      sector,
 				CompanyName: companyName,
+				NameAr:      nameAr,
 				Price:       0,
 				Change:      0,
 				Volume:      0,
@@ -539,193 +985,120 @@ func (ws *WebServer) loadTickersList() ([]common.TickerInfo, error) {
 	return tickers, nil
 }
 
-func (ws *WebServer) loadPriceData(symbol string) ([]PriceData, error) {
-	filename := fmt.Sprintf("raw_%s.csv", symbol)
-	content, err := os.ReadFile(filename)
+// loadPriceData returns the bars of symbol in timeframe tf, cleaned like the indicator inputs
+func (ws *WebServer) loadPriceData(symbol string, tf indicators.Timeframe) ([]PriceData, error) {
+	frame, _, err := ws.loadFrame(symbol, tf)
 	if err != nil {
-		return nil, fmt.Errorf("price data not found for %s", symbol)
+		return nil, err
 	}
 
-	lines := strings.Split(string(content), "\n")
-	var priceData []PriceData
-
-	for i, line := range lines {
-		if i == 0 || strings.TrimSpace(line) == "" {
-			continue // Skip header and empty lines
-		}
-
-		parts := strings.Split(line, ",")
-		if len(parts) >= 9 {
-			// CORRECT column mapping: Date,Close,Open,High,Low,Change,Change%,T.Shares,Volume,No. Trades
-			//                         0    1     2    3    4     5      6       7       8       9
-			close, _ := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
-			open, _ := strconv.ParseFloat(strings.TrimSpace(parts[2]), 64)
-			high, _ := strconv.ParseFloat(strings.TrimSpace(parts[3]), 64)
-			low, _ := strconv.ParseFloat(strings.TrimSpace(parts[4]), 64)
-			volume, _ := strconv.ParseInt(strings.TrimSpace(parts[8]), 10, 64)
-
-			// Skip rows with invalid data (like the zero close price entries)
-			if close > 0 && open > 0 && high > 0 && low > 0 {
-				priceData = append(priceData, PriceData{
-					Date:   strings.TrimSpace(parts[0]),
-					Open:   open,
-					High:   high,
-					Low:    low,
-					Close:  close,
-					Volume: volume,
-				})
-			}
+	// Only bar_cleaning "none" leaves bars without a price, and those cannot be drawn
+	priceData := []PriceData{}
+	for i := 0; i < frame.Len(); i++ {
+		if frame.Open[i] > 0 && frame.High[i] > 0 && frame.Low[i] > 0 && frame.Close[i] > 0 {
+			priceData = append(priceData, PriceData{
+				Date:   frame.Dates[i].Format("2006-01-02"),
+				Open:   frame.Open[i],
+				High:   frame.High[i],
+				Low:    frame.Low[i],
+				Close:  frame.Close[i],
+				Volume: frame.Volume[i],
+			})
 		}
 	}
-
 	return priceData, nil
 }
 
 func (ws *WebServer) getLastPrice(symbol string) (*LastPriceData, error) {
-	filename := fmt.Sprintf("raw_%s.csv", symbol)
-	content, err := os.ReadFile(filename)
+	frame, err := indicators.LoadRawFrame(fmt.Sprintf("raw_%s.csv", symbol))
 	if err != nil {
 		return nil, err
 	}
-
-	lines := strings.Split(string(content), "\n")
-	if len(lines) < 2 {
-		return nil, fmt.Errorf("insufficient data")
-	}
-
-	// Get last two lines for price comparison
-	var lastLine, prevLine string
-	for i := len(lines) - 1; i >= 0; i-- {
-		if strings.TrimSpace(lines[i]) != "" {
-			if lastLine == "" {
-				lastLine = lines[i]
-			} else {
-				prevLine = lines[i]
-				break
-			}
-		}
-	}
-
-	if lastLine == "" {
+	n := frame.Len()
+	if n == 0 {
 		return nil, fmt.Errorf("no valid data found")
 	}
 
-	parts := strings.Split(lastLine, ",")
-	if len(parts) < 9 {
-		return nil, fmt.Errorf("invalid data format")
-	}
-
-	// CORRECT column mapping: Date,Close,Open,High,Low,Change,Change%,T.Shares,Volume,No. Trades
-	//                         0    1     2    3    4     5      6       7       8       9
-	date := strings.TrimSpace(parts[0])
-	closeVal, _ := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
-	openVal, _ := strconv.ParseFloat(strings.TrimSpace(parts[2]), 64)
-	highVal, _ := strconv.ParseFloat(strings.TrimSpace(parts[3]), 64)
-	lowVal, _ := strconv.ParseFloat(strings.TrimSpace(parts[4]), 64)
-	volumeVal, _ := strconv.ParseInt(strings.TrimSpace(parts[8]), 10, 64)
-
+	last := n - 1
 	var change float64
-	if prevLine != "" {
-		prevParts := strings.Split(prevLine, ",")
-		if len(prevParts) >= 2 {
-			prevClose, _ := strconv.ParseFloat(strings.TrimSpace(prevParts[1]), 64)
-			change = closeVal - prevClose
-		}
-	}
-
-	var spark []float64
-	count := 0
-	for i := len(lines) - 1; i >= 0 && count < 10; i-- {
-		l := strings.TrimSpace(lines[i])
-		if l == "" || i == 0 {
-			continue
-		}
-		p := strings.Split(l, ",")
-		if len(p) >= 2 {
-			if c, err := strconv.ParseFloat(strings.TrimSpace(p[1]), 64); err == nil {
-				spark = append(spark, c)
-				count++
-			}
-		}
+	if n > 1 {
+		change = frame.Close[last] - frame.Close[last-1]
 	}
 
-	// reverse spark to chronological order
-	for i, j := 0, len(spark)-1; i < j; i, j = i+1, j-1 {
-		spark[i], spark[j] = spark[j], spark[i]
-	}
+	// Sparkline of the last 10 closes in chronological order
+	spark := append([]float64{}, frame.Close[max(n-10, 0):]...)
 
 	return &LastPriceData{
-		Date:      date,
-		Open:      openVal,
-		High:      highVal,
-		Low:       lowVal,
-		Close:     closeVal,
-		Volume:    volumeVal,
-		Value:     closeVal * float64(volumeVal),
+		Date:      frame.Dates[last].Format("2006-01-02"),
+		Open:      frame.Open[last],
+		High:      frame.High[last],
+		Low:       frame.Low[last],
+		Close:     frame.Close[last],
+		Volume:    frame.Volume[last],
+		Value:     frame.Close[last] * float64(frame.Volume[last]),
 		Change:    change,
 		Sparkline: spark,
 	}, nil
 }
 
-func (ws *WebServer) loadIndicatorData(symbol string) (map[string]interface{}, error) {
-	filename := fmt.Sprintf("indicators_%s.csv", symbol)
-	content, err := os.ReadFile(filename)
+// loadIndicatorData returns the last row of an indicator file by column; a non-empty lang
+// rebuilds its descriptions in that language
+func (ws *WebServer) loadIndicatorData(symbol string, tf indicators.Timeframe, lang string) (map[string]interface{}, error) {
+	filename := fmt.Sprintf("indicators_%s%s.csv", symbol, tf.Suffix())
+	file, err := os.Open(filename)
 	if err != nil {
 		return nil, fmt.Errorf("indicator data not found for %s", symbol)
 	}
+	defer file.Close()
 
-	lines := strings.Split(string(content), "\n")
-	if len(lines) < 2 {
+	// Parse as CSV, as some description columns hold quoted commas
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid indicator data for %s: %w", symbol, err)
+	}
+	if len(rows) < 2 {
 		return nil, fmt.Errorf("insufficient indicator data")
 	}
 
 	// Get headers and last data line
-	headers := strings.Split(lines[0], ",")
-	lastLine := ""
-	for i := len(lines) - 1; i >= 0; i-- {
-		if strings.TrimSpace(lines[i]) != "" {
-			lastLine = lines[i]
-			break
-		}
-	}
-
-	if lastLine == "" {
-		return nil, fmt.Errorf("no valid indicator data found")
-	}
-
-	values := strings.Split(lastLine, ",")
-	indicators := make(map[string]interface{})
+	headers := rows[0]
+	values := rows[len(rows)-1]
+	data := make(map[string]interface{})
 
 	for i, header := range headers {
 		if i < len(values) {
 			value := strings.TrimSpace(values[i])
 			if floatVal, err := strconv.ParseFloat(value, 64); err == nil {
-				indicators[strings.TrimSpace(header)] = floatVal
+				data[strings.TrimSpace(header)] = floatVal
 			} else {
-				indicators[strings.TrimSpace(header)] = value
+				data[strings.TrimSpace(header)] = value
 			}
 		}
 	}
 
-	return indicators, nil
+	if lang != "" {
+		specs, err := indicators.ConfiguredSpecs()
+		if err != nil {
+			return nil, err
+		}
+		for column, text := range indicators.Describe(headers, values, specs, lang) {
+			data[column] = text
+		}
+	}
+
+	return data, nil
 }
 
-func (ws *WebServer) loadTickerStrategies(symbol string, full bool) (map[string]interface{}, error) {
-	filename := fmt.Sprintf("Strategies_%s.csv", symbol)
+func (ws *WebServer) loadTickerStrategies(symbol string, full bool, tf indicators.Timeframe, lang string) (map[string]interface{}, error) {
+	filename := fmt.Sprintf("Strategies_%s%s.csv", symbol, tf.Suffix())
 	if _, err := os.Stat(filename); os.IsNotExist(err) {
 		return nil, fmt.Errorf("strategy data not found for %s", symbol)
 	}
 
-	file, err := os.Open(filename)
+	data, err := strategies.LoadStrategyFile(filename)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
-
-	var data []*strategies.StrategyData
-	if err := gocsv.UnmarshalFile(file, &data); err != nil {
-		return nil, err
-	}
 
 	if len(data) == 0 {
 		return nil, fmt.Errorf("no strategy data for %s", symbol)
@@ -733,26 +1106,16 @@ func (ws *WebServer) loadTickerStrategies(symbol string, full bool) (map[string]
 
 	last := data[len(data)-1]
 
-	signals := map[string]string{
-		"RSI Strategy":           last.RSIStrategy,
-		"RSI Strategy2":          last.RSIStrategy2,
-		"RSI14_OBV_RoC Strategy": last.RSI14OBVRoCStrategy,
-		"RSIMACD Strategy":       last.RSIMACDStrategy,
-		"RSICMF Strategy":        last.RSICMFStrategy,
-		"RSI OBV Strategy":       last.RSIOBVStrategy,
-		"OBV Strategy":           last.OBVStrategy,
-		"MACD Strategy":          last.MACDStrategy,
-		"CMF Strategy":           last.CMFStrategy,
-		"EMA5 PSAR Strategy":     last.EMA5PSARStrategy,
-		"EMA5 PSAR Strategy2":    last.EMA5PSARStrategy2,
-		"Rolling Std10 Strategy": last.RollingStd10Strategy,
-		"Rolling Std50 Strategy": last.RollingStd50Strategy,
+	signals := make(map[string]string, len(last.Signals))
+	for name, signal := range last.Signals {
+		signals[name] = i18n.Signal(lang, string(signal))
 	}
 
 	result := map[string]interface{}{
-		"ticker":  symbol,
-		"date":    last.Date.Format("2006-01-02"),
-		"signals": signals,
+		"ticker":    symbol,
+		"timeframe": tf,
+		"date":      last.Date.Format("2006-01-02"),
+		"signals":   signals,
 	}
 
 	if full {
@@ -0,0 +1,178 @@
+package strategies
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"isx-auto-scrapper/internal/common"
+)
+
+// The decimal* functions below are the backtest statistics as computed in decimal before the float64
+// port (commit 22ca65d). The engine's results may differ from them by 1e-9 of their size.
+const decimalTolerance = 1e-9
+
+func decimalMaxDrawdown(history []decimal.Decimal) decimal.Decimal {
+	if len(history) == 0 {
+		return decimal.Zero
+	}
+
+	maxDrawdown := decimal.Zero
+	peak := history[0]
+	for _, value := range history {
+		if value.GreaterThan(peak) {
+			peak = value
+		}
+		drawdown := peak.Sub(value).Div(peak).Mul(decimal.NewFromInt(100))
+		if drawdown.GreaterThan(maxDrawdown) {
+			maxDrawdown = drawdown
+		}
+	}
+	return maxDrawdown
+}
+
+func decimalSharpeRatio(history []decimal.Decimal) decimal.Decimal {
+	if len(history) < 2 {
+		return decimal.Zero
+	}
+
+	var returns []decimal.Decimal
+	for i := 1; i < len(history); i++ {
+		if !history[i-1].IsZero() {
+			returns = append(returns, history[i].Sub(history[i-1]).Div(history[i-1]))
+		}
+	}
+	if len(returns) <= 1 {
+		return decimal.Zero
+	}
+
+	sum := decimal.Zero
+	for _, ret := range returns {
+		sum = sum.Add(ret)
+	}
+	meanReturn := sum.Div(decimal.NewFromInt(int64(len(returns))))
+
+	varianceSum := decimal.Zero
+	for _, ret := range returns {
+		diff := ret.Sub(meanReturn)
+		varianceSum = varianceSum.Add(diff.Mul(diff))
+	}
+	varianceFloat, _ := varianceSum.Div(decimal.NewFromInt(int64(len(returns) - 1))).Float64()
+	if varianceFloat <= 0 {
+		return decimal.Zero
+	}
+
+	stdDev := decimal.NewFromFloat(math.Sqrt(varianceFloat))
+	return meanReturn.Mul(decimal.NewFromInt(252)).Div(stdDev.Mul(decimal.NewFromFloat(math.Sqrt(252))))
+}
+
+// decimalDrawdown is the running drawdown of the last value, against the initial cash and every value
+func decimalDrawdown(initialCash decimal.Decimal, history []decimal.Decimal) decimal.Decimal {
+	maxValue := initialCash
+	for _, value := range history {
+		if value.GreaterThan(maxValue) {
+			maxValue = value
+		}
+	}
+	return history[len(history)-1].Sub(maxValue).Div(maxValue).Mul(decimal.NewFromInt(100))
+}
+
+// equityCurves are the portfolio values the statistics are checked on, from 1,000,000 initial cash. The
+// decimal engine divided to 16 places, so where returns are near 1e-8 its Sharpe ratio kept only a few digits;
+// those curves give the exact ratio to check against instead.
+var equityCurves = []struct {
+	name   string
+	values []float64
+	sharpe float64 // Exact Sharpe ratio, when the decimal one is imprecise
+}{
+	{"single snapshot", []float64{1_000_000}, 0},
+	{"flat", []float64{1_000_000, 1_000_000, 1_000_000}, 0},
+	{"rising", []float64{1_000_000, 1_010_000, 1_030_000, 1_035_500.25}, 0},
+	{"below initial cash", []float64{950_000, 940_000, 990_000, 960_000}, 0},
+	{"dip and recovery", []float64{1_000_000, 1_200_000, 900_000.5, 1_250_000, 1_100_000, 1_300_000}, 0},
+	{"wiped out", []float64{1_000_000, 400_000, 0, 0, 10}, 0},
+	{"fils apart", []float64{1_000_000, 999_999.99, 1_000_000.01, 999_999.98, 1_000_000.03}, 3.401680395927137},
+	{"year of sessions", syntheticCurve(252), 0},
+}
+
+// syntheticCurve returns n values wandering from 1,000,000 by up to 3% a step, rounded to fils
+func syntheticCurve(n int) []float64 {
+	values := make([]float64, n)
+	value := 1_000_000.0
+	for i := range values {
+		values[i] = math.Round(value*1000) / 1000
+		value *= 1 + 0.03*math.Sin(float64(i)*0.7)*math.Cos(float64(i)*0.13)
+	}
+	return values
+}
+
+// replayCurve runs the values through the engine as its daily snapshots
+func replayCurve(values []float64) *BacktestEngine {
+	be := NewBacktestEngine(discardLogger, common.BacktestConfig{InitialCash: decimal.NewFromInt(1_000_000)})
+	for _, value := range values {
+		be.portfolio.Cash = decimal.NewFromFloat(value)
+		be.updatePortfolioMetrics()
+		be.portfolioHistory = append(be.portfolioHistory, be.portfolio)
+	}
+	return be
+}
+
+// closeTo reports whether got is within decimalTolerance of want, relative to its size
+func closeTo(got, want decimal.Decimal) bool {
+	g, w := got.InexactFloat64(), want.InexactFloat64()
+	return math.Abs(g-w) <= decimalTolerance*max(1, math.Abs(w))
+}
+
+func TestBacktestStatisticsMatchDecimalEngine(t *testing.T) {
+	for _, curve := range equityCurves {
+		t.Run(curve.name, func(t *testing.T) {
+			be := replayCurve(curve.values)
+			history := make([]decimal.Decimal, len(be.portfolioHistory))
+			for i, portfolio := range be.portfolioHistory {
+				history[i] = portfolio.TotalValue
+			}
+
+			if got, want := be.calculateMaxDrawdown(), decimalMaxDrawdown(history); !closeTo(got, want) {
+				t.Errorf("max drawdown = %s, decimal engine %s", got, want)
+			}
+			want := decimalSharpeRatio(history)
+			if curve.sharpe != 0 {
+				want = decimal.NewFromFloat(curve.sharpe)
+			}
+			if got := be.calculateSharpeRatio(); !closeTo(got, want) {
+				t.Errorf("Sharpe ratio = %s, decimal engine %s", got, want)
+			}
+			for i := 1; i < len(history); i++ {
+				if got, want := be.portfolioHistory[i].Drawdown, decimalDrawdown(be.config.InitialCash, history[:i+1]); !closeTo(got, want) {
+					t.Errorf("drawdown of snapshot %d = %s, decimal engine %s", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBacktestStatistics(b *testing.B) {
+	be := replayCurve(syntheticCurve(252 * 5))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		be.calculateMaxDrawdown()
+		be.calculateSharpeRatio()
+	}
+}
+
+func TestBacktestStrategiesNameStrategies(t *testing.T) {
+	names := append(Names(), ConsensusName, "Dip Buyer")
+	if err := checkBacktestStrategies(common.NewConfig().Backtest.Strategies, names); err != nil {
+		t.Fatalf("default settings: %v", err)
+	}
+	if err := checkBacktestStrategies([]string{ConsensusName, "Dip Buyer"}, names); err != nil {
+		t.Errorf("consensus and rule strategies: %v", err)
+	}
+
+	err := checkBacktestStrategies([]string{"RSI Strategy", "Dip Buyr"}, names)
+	if err == nil || !strings.Contains(err.Error(), `"Dip Buyr"`) || !strings.Contains(err.Error(), "Dip Buyer") {
+		t.Errorf("misspelt strategy gave %v, want an error naming it and the valid names", err)
+	}
+}
@@ -0,0 +1,536 @@
+package strategies
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"isx-auto-scrapper/internal/common"
+	"isx-auto-scrapper/internal/indicators"
+)
+
+// Built-in strategies, registered in the long-standing column order of Strategies_<TICKER>.csv
+func init() {
+	Register(func(cfg common.StrategyConfig) Strategy {
+		return &barStrategy{"RSI Strategy", levelParams(cfg.RSI), oversold(cfg.RSI)}
+	})
+	// RSI Strategy2: more conservative thresholds
+	Register(func(cfg common.StrategyConfig) Strategy {
+		return &barStrategy{"RSI Strategy2", levelParams(cfg.RSI2), oversold(cfg.RSI2)}
+	})
+	Register(func(cfg common.StrategyConfig) Strategy {
+		return &barStrategy{"RSI14_OBV_RoC Strategy", confirmParams(cfg.OBVRoC), rsiConfirmed(obvRoC, cfg.OBVRoC)}
+	})
+	Register(func(cfg common.StrategyConfig) Strategy {
+		return &barStrategy{"RSIMACD Strategy", nil, rsiMACD}
+	})
+	Register(func(cfg common.StrategyConfig) Strategy {
+		return &barStrategy{"RSICMF Strategy", confirmParams(cfg.CMF), rsiConfirmed(cmfLine, cfg.CMF)}
+	})
+	Register(func(cfg common.StrategyConfig) Strategy {
+		return &barStrategy{"RSI OBV Strategy", confirmParams(cfg.OBVRoC), rsiConfirmed(obvRoC, cfg.OBVRoC)}
+	})
+	Register(func(cfg common.StrategyConfig) Strategy {
+		return &barStrategy{"OBV Strategy", levelParams(cfg.OBVRoC), inflow(obvRoC, cfg.OBVRoC)}
+	})
+	Register(func(cfg common.StrategyConfig) Strategy {
+		params := map[string]float64{"strong": cfg.MACDHist.Strong, "buy": cfg.MACDHist.Buy}
+		return &barStrategy{"MACD Strategy", params, macdStrength(cfg.MACDHist)}
+	})
+	Register(func(cfg common.StrategyConfig) Strategy {
+		return &barStrategy{"CMF Strategy", levelParams(cfg.CMF), inflow(cmfLine, cfg.CMF)}
+	})
+	Register(func(cfg common.StrategyConfig) Strategy {
+		return &barStrategy{"EMA5 PSAR Strategy", nil, ema5PSAR}
+	})
+	Register(func(cfg common.StrategyConfig) Strategy {
+		return &barStrategy{"EMA5 PSAR Strategy2", nil, ema5PSAR}
+	})
+	Register(func(cfg common.StrategyConfig) Strategy {
+		return &barStrategy{"Rolling Std10 Strategy", nil, stdBands(smaShort, stdShort)}
+	})
+	Register(func(cfg common.StrategyConfig) Strategy {
+		return &barStrategy{"Rolling Std50 Strategy", nil, afterShortStd(stdBands(smaLong, stdLong))}
+	})
+	Register(func(cfg common.StrategyConfig) Strategy {
+		return &barStrategy{"Squeeze Strategy", nil, squeezeRelease}
+	})
+	Register(func(cfg common.StrategyConfig) Strategy {
+		return &barStrategy{"Donchian Breakout Strategy", nil, donchianBreakout}
+	})
+	Register(func(cfg common.StrategyConfig) Strategy {
+		return &barStrategy{"Candlestick Strategy", nil, candlestick}
+	})
+	Register(func(cfg common.StrategyConfig) Strategy {
+		return &barStrategy{"Support Resistance Strategy", nil, levelBreaks}
+	})
+	Register(func(cfg common.StrategyConfig) Strategy {
+		return &barStrategy{"Divergence Strategy", nil, divergence}
+	})
+}
+
+// bar is one row of an indicator file
+type bar = indicators.StockDataWithIndicators
+
+// input is a column a built-in strategy reads: output k of the nth configured instance of an indicator,
+// so the strategies follow the periods of the spec file as the descriptions do
+type input struct {
+	indicator string
+	nth       int
+	output    int
+}
+
+// Inputs of the built-in strategies, read from the first configured instance of their indicator;
+// the 50-bar deviation bands read the second SMA and STD
+var (
+	rsiLine              = input{"RSI", 0, 0}
+	macdLine             = input{"MACD", 0, 0}
+	macdSignal           = input{"MACD", 0, 1}
+	macdHist             = input{"MACD", 0, 2}
+	cmfLine              = input{"CMF", 0, 0}
+	obvRoC               = input{"OBV", 0, 1}
+	emaLine              = input{"EMA", 0, 0}
+	psarLine             = input{"PSAR", 0, 0}
+	smaShort, stdShort   = input{"SMA", 0, 0}, input{"STD", 0, 0}
+	smaLong, stdLong     = input{"SMA", 1, 0}, input{"STD", 1, 0}
+	bbLower, bbMiddle    = input{"BBANDS", 0, 0}, input{"BBANDS", 0, 1}
+	bbUpper              = input{"BBANDS", 0, 2}
+	kcLower, kcBasis     = input{"KC", 0, 0}, input{"KC", 0, 1}
+	kcUpper              = input{"KC", 0, 2}
+	dcLower, dcUpper     = input{"DONCHIAN", 0, 0}, input{"DONCHIAN", 0, 2}
+	support, resistance  = input{"SR", 0, 0}, input{"SR", 0, 1}
+	supportDistance      = input{"SR", 0, 2}
+	resistanceDistance   = input{"SR", 0, 3}
+	cdlBias, cdlStrength = input{"CDL", 0, 1}, input{"CDL", 0, 2}
+	divType, divBias     = input{"DIV", 0, 0}, input{"DIV", 0, 1}
+	divStrength          = input{"DIV", 0, 2}
+)
+
+// column returns the name of the input's column among specs
+func (in input) column(specs []indicators.Spec) (string, bool) {
+	n := 0
+	for _, spec := range specs {
+		if !strings.EqualFold(spec.Def.Name, in.indicator) {
+			continue
+		}
+		if n == in.nth {
+			return spec.Columns()[in.output], true
+		}
+		n++
+	}
+	return "", false
+}
+
+// judgement judges a bar on its inputs and those of the bar before it (nil on the first bar)
+type judgement struct {
+	inputs  []input
+	judge   func(prev, d *row) Signal
+	columns map[input]string // Columns of the inputs, found when the strategy is built
+}
+
+// row is a bar read through the columns of a strategy's inputs
+type row struct {
+	*bar
+	columns map[input]string
+}
+
+// get returns the value of an input on the bar; missing values read as zero
+func (r *row) get(in input) decimal.Decimal {
+	v, _ := decimal.NewFromString(r.Cell(r.columns[in]))
+	return v
+}
+
+// barStrategy judges each bar on its own values and those of the bar before it
+type barStrategy struct {
+	name   string
+	params map[string]float64
+	judgement
+}
+
+func (s *barStrategy) Name() string               { return s.name }
+func (s *barStrategy) Params() map[string]float64 { return s.params }
+
+func (s *barStrategy) Evaluate(bars []*bar) []Signal {
+	signals := holdAll(len(bars))
+	var prev *row
+	for i, d := range bars {
+		r := &row{d, s.columns}
+		signals[i] = s.judge(prev, r)
+		prev = r
+	}
+	return signals
+}
+
+// bind finds the columns of the strategy's inputs among the configured specs
+func (s *barStrategy) bind(specs []indicators.Spec) error {
+	s.columns = make(map[input]string, len(s.inputs))
+	for _, in := range s.inputs {
+		name, ok := in.column(specs)
+		if !ok {
+			return fmt.Errorf("%s needs %d %s indicator(s) in %s", s.name, in.nth+1, in.indicator,
+				common.AppConfig.Indicators.SpecFile)
+		}
+		s.columns[in] = name
+	}
+	return nil
+}
+
+// reads returns the indicator columns the strategy reads
+func (s *barStrategy) reads() []string {
+	names := make([]string, 0, len(s.columns))
+	for _, in := range s.inputs {
+		names = append(names, s.columns[in])
+	}
+	return names
+}
+
+// levelParams returns six thresholds as strategy parameters
+func levelParams(l common.Levels) map[string]float64 {
+	return map[string]float64{
+		"strong_buy": l.StrongBuy, "buy": l.Buy, "weak_buy": l.WeakBuy,
+		"weak_sell": l.WeakSell, "sell": l.Sell, "strong_sell": l.StrongSell,
+	}
+}
+
+// confirmParams returns the levels rsiConfirmed reads as strategy parameters
+func confirmParams(l common.Levels) map[string]float64 {
+	return map[string]float64{"buy": l.Buy, "sell": l.Sell}
+}
+
+// oversold buys RSI below the buy levels and sells it above the sell levels
+func oversold(l common.Levels) judgement {
+	return judgement{inputs: []input{rsiLine}, judge: func(prev, d *row) Signal {
+		rsi := d.get(rsiLine)
+		if rsi.IsZero() {
+			return Hold
+		}
+		switch {
+		case rsi.LessThan(decimal.NewFromFloat(l.StrongBuy)):
+			return StrongBuy
+		case rsi.LessThan(decimal.NewFromFloat(l.Buy)):
+			return Buy
+		case rsi.LessThan(decimal.NewFromFloat(l.WeakBuy)):
+			return WeakBuy
+		case rsi.GreaterThan(decimal.NewFromFloat(l.StrongSell)):
+			return StrongSell
+		case rsi.GreaterThan(decimal.NewFromFloat(l.Sell)):
+			return Sell
+		case rsi.GreaterThan(decimal.NewFromFloat(l.WeakSell)):
+			return WeakSell
+		}
+		return Hold
+	}}
+}
+
+// inflow buys a money flow or volume momentum above the buy levels and sells it below the sell levels
+func inflow(flow input, l common.Levels) judgement {
+	return judgement{inputs: []input{flow}, judge: func(prev, d *row) Signal {
+		v := d.get(flow)
+		if v.IsZero() {
+			return Hold
+		}
+		switch {
+		case v.GreaterThan(decimal.NewFromFloat(l.StrongBuy)):
+			return StrongBuy
+		case v.GreaterThan(decimal.NewFromFloat(l.Buy)):
+			return Buy
+		case v.GreaterThan(decimal.NewFromFloat(l.WeakBuy)):
+			return WeakBuy
+		case v.LessThan(decimal.NewFromFloat(l.StrongSell)):
+			return StrongSell
+		case v.LessThan(decimal.NewFromFloat(l.Sell)):
+			return Sell
+		case v.LessThan(decimal.NewFromFloat(l.WeakSell)):
+			return WeakSell
+		}
+		return Hold
+	}}
+}
+
+// rsiConfirmed buys when the flow is above its buy level and RSI is not overbought, stronger the lower RSI is;
+// sells mirror it
+func rsiConfirmed(flow input, l common.Levels) judgement {
+	return judgement{inputs: []input{flow, rsiLine}, judge: func(prev, d *row) Signal {
+		v, rsi := d.get(flow), d.get(rsiLine)
+		if v.IsZero() || rsi.IsZero() {
+			return Hold
+		}
+		if v.GreaterThan(decimal.NewFromFloat(l.Buy)) && rsi.LessThan(decimal.NewFromInt(70)) {
+			if rsi.LessThan(decimal.NewFromInt(30)) {
+				return StrongBuy
+			} else if rsi.LessThan(decimal.NewFromInt(50)) {
+				return Buy
+			}
+			return WeakBuy
+		} else if v.LessThan(decimal.NewFromFloat(l.Sell)) && rsi.GreaterThan(decimal.NewFromInt(30)) {
+			if rsi.GreaterThan(decimal.NewFromInt(70)) {
+				return StrongSell
+			} else if rsi.GreaterThan(decimal.NewFromInt(50)) {
+				return Sell
+			}
+			return WeakSell
+		}
+		return Hold
+	}}
+}
+
+// macdStrength follows the MACD line against its signal line, graded by the histogram
+func macdStrength(l common.MACDHistLevels) judgement {
+	return judgement{inputs: []input{macdLine, macdSignal, macdHist}, judge: func(prev, d *row) Signal {
+		macd, signal := d.get(macdLine), d.get(macdSignal)
+		if macd.IsZero() || signal.IsZero() {
+			return Hold
+		}
+
+		histogramAbs := d.get(macdHist).Abs()
+		if macd.GreaterThan(signal) {
+			if histogramAbs.GreaterThan(decimal.NewFromFloat(l.Strong)) {
+				return StrongBuy
+			} else if histogramAbs.GreaterThan(decimal.NewFromFloat(l.Buy)) {
+				return Buy
+			}
+			return WeakBuy
+		} else if macd.LessThan(signal) {
+			if histogramAbs.GreaterThan(decimal.NewFromFloat(l.Strong)) {
+				return StrongSell
+			} else if histogramAbs.GreaterThan(decimal.NewFromFloat(l.Buy)) {
+				return Sell
+			}
+			return WeakSell
+		}
+		return Hold
+	}}
+}
+
+// rsiMACD buys a bullish MACD while RSI is not overbought and sells a bearish one while it is not oversold
+var rsiMACD = judgement{inputs: []input{macdLine, macdSignal, macdHist, rsiLine}, judge: func(prev, d *row) Signal {
+	macd, signal, rsi := d.get(macdLine), d.get(macdSignal), d.get(rsiLine)
+	if macd.IsZero() || signal.IsZero() || rsi.IsZero() {
+		return Hold
+	}
+
+	histogramAbs := d.get(macdHist).Abs()
+	if macd.GreaterThan(signal) && rsi.LessThan(decimal.NewFromInt(70)) {
+		if rsi.LessThan(decimal.NewFromInt(30)) && histogramAbs.GreaterThan(decimal.NewFromFloat(0.1)) {
+			return StrongBuy
+		} else if rsi.LessThan(decimal.NewFromInt(50)) {
+			return Buy
+		}
+		return WeakBuy
+	} else if macd.LessThan(signal) && rsi.GreaterThan(decimal.NewFromInt(30)) {
+		if rsi.GreaterThan(decimal.NewFromInt(70)) && histogramAbs.GreaterThan(decimal.NewFromFloat(0.1)) {
+			return StrongSell
+		} else if rsi.GreaterThan(decimal.NewFromInt(50)) {
+			return Sell
+		}
+		return WeakSell
+	}
+	return Hold
+}}
+
+// ema5PSAR follows the trend when the close is on the same side of the fast EMA and the PSAR,
+// graded by its distance from the EMA
+var ema5PSAR = judgement{inputs: []input{emaLine, psarLine}, judge: func(prev, d *row) Signal {
+	ema, psar, price := d.get(emaLine), d.get(psarLine), d.Close
+	if ema.IsZero() || psar.IsZero() || price.IsZero() {
+		return Hold
+	}
+
+	emaDistance := price.Sub(ema).Div(ema).Mul(decimal.NewFromInt(100))
+	if price.GreaterThan(ema) && price.GreaterThan(psar) {
+		if emaDistance.GreaterThan(decimal.NewFromInt(5)) {
+			return StrongBuy
+		} else if emaDistance.GreaterThan(decimal.NewFromInt(2)) {
+			return Buy
+		}
+		return WeakBuy
+	} else if price.LessThan(ema) && price.LessThan(psar) {
+		if emaDistance.LessThan(decimal.NewFromInt(-5)) {
+			return StrongSell
+		} else if emaDistance.LessThan(decimal.NewFromInt(-2)) {
+			return Sell
+		}
+		return WeakSell
+	}
+	return Hold
+}}
+
+// stdBands trades the close against bands of 0.5, 2 and 2.5 rolling deviations around an SMA, Bollinger-like
+func stdBands(mean, std input) judgement {
+	return judgement{inputs: []input{mean, std}, judge: func(prev, d *row) Signal {
+		price, sma, dev := d.Close, d.get(mean), d.get(std)
+		if price.IsZero() || sma.IsZero() || dev.IsZero() {
+			return Hold
+		}
+
+		band := func(mult float64) decimal.Decimal { return dev.Mul(decimal.NewFromFloat(mult)) }
+		switch {
+		case price.LessThan(sma.Sub(band(2.5))):
+			return StrongBuy
+		case price.LessThan(sma.Sub(band(2))):
+			return Buy
+		case price.LessThan(sma.Sub(band(0.5))):
+			return WeakBuy
+		case price.GreaterThan(sma.Add(band(2.5))):
+			return StrongSell
+		case price.GreaterThan(sma.Add(band(2))):
+			return Sell
+		case price.GreaterThan(sma.Add(band(0.5))):
+			return WeakSell
+		}
+		return Hold
+	}}
+}
+
+// afterShortStd holds wherever the short deviation bands are undefined; Rolling Std50 has always
+// been judged only on bars Rolling Std10 could judge
+func afterShortStd(j judgement) judgement {
+	return judgement{inputs: append([]input{smaShort, stdShort}, j.inputs...), judge: func(prev, d *row) Signal {
+		if d.Close.IsZero() || d.get(smaShort).IsZero() || d.get(stdShort).IsZero() {
+			return Hold
+		}
+		return j.judge(prev, d)
+	}}
+}
+
+// inSqueeze reports whether the Bollinger bands lie inside the Keltner channel
+func inSqueeze(d *row) bool {
+	if d.get(bbMiddle).IsZero() || d.get(kcBasis).IsZero() {
+		return false
+	}
+	return d.get(bbLower).GreaterThan(d.get(kcLower)) && d.get(bbUpper).LessThan(d.get(kcUpper))
+}
+
+// squeezeRelease trades the release of a Bollinger-inside-Keltner squeeze in the direction of the close
+var squeezeRelease = judgement{inputs: []input{bbLower, bbMiddle, bbUpper, kcLower, kcBasis, kcUpper}, judge: func(prev, d *row) Signal {
+	price := d.Close
+	if prev == nil || price.IsZero() || d.get(bbMiddle).IsZero() || d.get(kcBasis).IsZero() {
+		return Hold
+	}
+
+	// Stay out while volatility is compressed, act on the bar that releases it
+	if inSqueeze(prev) && !inSqueeze(d) {
+		switch {
+		case price.GreaterThan(d.get(bbUpper)):
+			return StrongBuy
+		case price.GreaterThan(d.get(bbMiddle)):
+			return Buy
+		case price.LessThan(d.get(bbLower)):
+			return StrongSell
+		case price.LessThan(d.get(bbMiddle)):
+			return Sell
+		}
+	} else if !inSqueeze(d) {
+		// Expansion beyond the Keltner channel keeps the move going
+		if price.GreaterThan(d.get(kcUpper)) {
+			return WeakBuy
+		} else if price.LessThan(d.get(kcLower)) {
+			return WeakSell
+		}
+	}
+	return Hold
+}}
+
+// donchianBreakout trades closes beyond the previous bar's Donchian channel
+var donchianBreakout = judgement{inputs: []input{dcLower, dcUpper}, judge: func(prev, d *row) Signal {
+	price := d.Close
+	if prev == nil || price.IsZero() {
+		return Hold
+	}
+	upper, lower := prev.get(dcUpper), prev.get(dcLower)
+	if upper.IsZero() || lower.IsZero() {
+		return Hold
+	}
+
+	hundred := decimal.NewFromInt(100)
+	channel := upper.Sub(lower)
+	quarter := channel.Div(decimal.NewFromInt(4))
+
+	// Closes beyond the old channel are breakouts, strong when more than 2% beyond;
+	// closes in the top or bottom quarter of the channel lean the same way
+	if price.GreaterThan(upper) {
+		if price.Sub(upper).Div(upper).Mul(hundred).GreaterThan(decimal.NewFromInt(2)) {
+			return StrongBuy
+		}
+		return Buy
+	} else if price.LessThan(lower) {
+		if lower.Sub(price).Div(lower).Mul(hundred).GreaterThan(decimal.NewFromInt(2)) {
+			return StrongSell
+		}
+		return Sell
+	} else if channel.IsPositive() && price.GreaterThanOrEqual(upper.Sub(quarter)) {
+		return WeakBuy
+	} else if channel.IsPositive() && price.LessThanOrEqual(lower.Add(quarter)) {
+		return WeakSell
+	}
+	return Hold
+}}
+
+// candlestick trades the strongest candlestick pattern of each bar in its bias: strong patterns (70+) such as
+// engulfing, stars and three soldiers/crows, medium patterns (50+) such as hammers and piercing lines, weaker ones only lean
+var candlestick = judgement{inputs: []input{cdlBias, cdlStrength}, judge: func(prev, d *row) Signal {
+	bias, strength := d.get(cdlBias), d.get(cdlStrength)
+	if bias.IsZero() {
+		return Hold
+	}
+	return graded(bias.IsPositive(), strength.GreaterThanOrEqual(decimal.NewFromInt(70)),
+		strength.GreaterThanOrEqual(decimal.NewFromInt(50)))
+}}
+
+// levelBreaks trades a close through the previous bar's resistance (a breakout) or support (a breakdown),
+// strong when more than 2% beyond; closes within 1% of a zone lean towards a bounce
+var levelBreaks = judgement{inputs: []input{support, resistance, supportDistance, resistanceDistance}, judge: func(prev, d *row) Signal {
+	price := d.Close
+	if prev == nil || price.IsZero() {
+		return Hold
+	}
+
+	hundred := decimal.NewFromInt(100)
+	one, two := decimal.NewFromInt(1), decimal.NewFromInt(2)
+	if level := prev.get(resistance); !level.IsZero() && price.GreaterThan(level) {
+		if price.Sub(level).Div(level).Mul(hundred).GreaterThan(two) {
+			return StrongBuy
+		}
+		return Buy
+	} else if level := prev.get(support); !level.IsZero() && price.LessThan(level) {
+		if level.Sub(price).Div(level).Mul(hundred).GreaterThan(two) {
+			return StrongSell
+		}
+		return Sell
+	} else if !d.get(support).IsZero() && d.get(supportDistance).LessThanOrEqual(one) {
+		return WeakBuy
+	} else if !d.get(resistance).IsZero() && d.get(resistanceDistance).LessThanOrEqual(one) {
+		return WeakSell
+	}
+	return Hold
+}}
+
+// divergence trades the strongest divergence confirmed on each bar in its bias: regular divergences signal
+// reversals and are strong from 70, hidden ones signal trend continuation and stop at a plain Buy or Sell;
+// below 50 they only lean
+var divergence = judgement{inputs: []input{divType, divBias, divStrength}, judge: func(prev, d *row) Signal {
+	bias, strength := d.get(divBias), d.get(divStrength)
+	if bias.IsZero() {
+		return Hold
+	}
+	strong := strength.GreaterThanOrEqual(decimal.NewFromInt(70)) && strings.HasPrefix(d.Cell(d.columns[divType]), "Regular")
+	return graded(bias.IsPositive(), strong, strength.GreaterThanOrEqual(decimal.NewFromInt(50)))
+}}
+
+// graded returns the buy or sell signal of a bias: strong, plain when medium, otherwise weak
+func graded(bullish, strong, medium bool) Signal {
+	switch {
+	case strong && bullish:
+		return StrongBuy
+	case strong:
+		return StrongSell
+	case medium && bullish:
+		return Buy
+	case medium:
+		return Sell
+	case bullish:
+		return WeakBuy
+	}
+	return WeakSell
+}
@@ -0,0 +1,175 @@
+package strategies
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"isx-auto-scrapper/internal/common"
+	"isx-auto-scrapper/internal/indicators"
+)
+
+// defaultSpecs returns the default indicator specs with the replacements applied, e.g. "RSI(14)": "RSI(7)";
+// an empty replacement drops the spec
+func defaultSpecs(t *testing.T, replace map[string]string) []indicators.Spec {
+	t.Helper()
+	var texts []string
+	for _, text := range indicators.DefaultSpecTexts {
+		if with, ok := replace[text]; ok {
+			text = with
+		}
+		if text != "" {
+			texts = append(texts, text)
+		}
+	}
+	specs, err := indicators.ParseSpecs(texts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return specs
+}
+
+// loadBars writes an indicator file with the columns the calculator writes for specs and loads it
+// as the strategies do; every cell is 0 unless the row sets it
+func loadBars(t *testing.T, specs []indicators.Spec, rows ...map[string]string) []*bar {
+	t.Helper()
+	header := indicators.Columns(specs, false)
+
+	lines := []string{strings.Join(header, ",")}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, row := range rows {
+		cells := make([]string, len(header))
+		for k, name := range header {
+			cells[k] = "0"
+			if value, ok := row[name]; ok {
+				cells[k] = value
+			}
+		}
+		cells[0] = start.AddDate(0, 0, i).Format(time.RFC3339)
+		lines = append(lines, strings.Join(cells, ","))
+	}
+
+	path := filepath.Join(t.TempDir(), "indicators_TEST.csv")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	bars, err := indicators.LoadIndicatorFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bars
+}
+
+func TestBuiltinStrategiesFire(t *testing.T) {
+	// The bar before and the bar judged, with the signal the default settings give on the second
+	cases := map[string]struct {
+		prev, bar map[string]string
+		want      Signal
+	}{
+		"RSI Strategy":           {bar: map[string]string{"RSI_14": "10"}, want: StrongBuy},
+		"RSI Strategy2":          {bar: map[string]string{"RSI_14": "90"}, want: StrongSell},
+		"RSI14_OBV_RoC Strategy": {bar: map[string]string{"RSI_14": "25", "OBV_RoC": "8"}, want: StrongBuy},
+		"RSIMACD Strategy": {bar: map[string]string{
+			"RSI_14": "25", "MACD_12_26_9": "1", "MACDs_12_26_9": "0.5", "MACDh_12_26_9": "0.5"}, want: StrongBuy},
+		"RSICMF Strategy":        {bar: map[string]string{"RSI_14": "40", "CMF_20": "0.15"}, want: Buy},
+		"RSI OBV Strategy":       {bar: map[string]string{"RSI_14": "75", "OBV_RoC": "-8"}, want: StrongSell},
+		"OBV Strategy":           {bar: map[string]string{"OBV_RoC": "12"}, want: StrongBuy},
+		"MACD Strategy":          {bar: map[string]string{"MACD_12_26_9": "1", "MACDs_12_26_9": "0.5", "MACDh_12_26_9": "0.5"}, want: StrongBuy},
+		"CMF Strategy":           {bar: map[string]string{"CMF_20": "-0.25"}, want: StrongSell},
+		"EMA5 PSAR Strategy":     {bar: map[string]string{"Close": "110", "EMA5": "100", "PSARl_0.02_0.2": "95"}, want: StrongBuy},
+		"EMA5 PSAR Strategy2":    {bar: map[string]string{"Close": "90", "EMA5": "100", "PSARl_0.02_0.2": "95"}, want: StrongSell},
+		"Rolling Std10 Strategy": {bar: map[string]string{"Close": "80", "SMA10": "100", "Rolling_Std_10": "5"}, want: StrongBuy},
+		"Rolling Std50 Strategy": {bar: map[string]string{
+			"Close": "120", "SMA10": "100", "Rolling_Std_10": "50", "SMA50": "100", "Rolling_Std_50": "5"}, want: StrongSell},
+		"Squeeze Strategy": {
+			prev: map[string]string{"Close": "100", "BBL_20_2": "98", "BBM_20_2": "100", "BBU_20_2": "102",
+				"KCL_20_1.5_20": "95", "KCB_20_1.5_20": "100", "KCU_20_1.5_20": "105"},
+			bar: map[string]string{"Close": "112", "BBL_20_2": "90", "BBM_20_2": "100", "BBU_20_2": "110",
+				"KCL_20_1.5_20": "95", "KCB_20_1.5_20": "100", "KCU_20_1.5_20": "105"},
+			want: StrongBuy,
+		},
+		"Donchian Breakout Strategy": {
+			prev: map[string]string{"DCL_20": "90", "DCM_20": "95", "DCU_20": "100"},
+			bar:  map[string]string{"Close": "105"},
+			want: StrongBuy,
+		},
+		"Candlestick Strategy": {bar: map[string]string{"CDL_Pattern": "Bullish Engulfing", "CDL_Bias": "1", "CDL_Strength": "80"}, want: StrongBuy},
+		"Support Resistance Strategy": {
+			prev: map[string]string{"SRR_5_1.5_250": "100"},
+			bar:  map[string]string{"Close": "101"},
+			want: Buy,
+		},
+		"Divergence Strategy": {bar: map[string]string{"DIV_Type": "Regular Bullish RSI", "DIV_Bias": "1", "DIV_Strength": "75"}, want: StrongBuy},
+	}
+
+	specs := defaultSpecs(t, nil)
+	built, _, err := Build(common.NewConfig().Strategies, specs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, strategy := range built {
+		t.Run(strategy.Name(), func(t *testing.T) {
+			c, ok := cases[strategy.Name()]
+			if !ok {
+				t.Fatalf("no fixture on which %s should fire", strategy.Name())
+			}
+			signals := strategy.Evaluate(loadBars(t, specs, c.prev, c.bar))
+			if signals[1] != c.want {
+				t.Errorf("signal = %s, want %s", signals[1], c.want)
+			}
+
+			// A bar without the strategy's indicators is not judged
+			for _, signal := range strategy.Evaluate(loadBars(t, specs, nil, nil)) {
+				if signal != Hold {
+					t.Errorf("signal on empty bars = %s, want Hold", signal)
+				}
+			}
+		})
+	}
+}
+
+func TestBuiltinStrategiesFollowSpecs(t *testing.T) {
+	specs := defaultSpecs(t, map[string]string{"RSI(14)": "RSI(7)", "STD(50)": "STD(30)", "SMA(50)": "SMA(30)"})
+	built, _, err := Build(common.NewConfig().Strategies, specs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bars := loadBars(t, specs, map[string]string{
+		"RSI_7": "10", "Close": "120", "SMA10": "100", "Rolling_Std_10": "50", "SMA30": "100", "Rolling_Std_30": "5"})
+
+	want := map[string]Signal{"RSI Strategy": StrongBuy, "Rolling Std50 Strategy": StrongSell}
+	for _, strategy := range built {
+		if signal, ok := want[strategy.Name()]; ok {
+			if got := strategy.Evaluate(bars)[0]; got != signal {
+				t.Errorf("%s on the tuned columns = %s, want %s", strategy.Name(), got, signal)
+			}
+		}
+	}
+
+	// A file written before the spec change lacks the tuned columns
+	s := &Strategies{}
+	if _, err := s.applyTradingStrategies(built, loadBars(t, defaultSpecs(t, nil), nil)); err == nil ||
+		!strings.Contains(err.Error(), "RSI_7") {
+		t.Errorf("evaluating a file without RSI_7 gave %v, want an error naming it", err)
+	}
+}
+
+func TestBuildLeavesOutStrategiesWithoutIndicators(t *testing.T) {
+	built, skipped, err := Build(common.NewConfig().Strategies, defaultSpecs(t, map[string]string{"STD(50)": ""}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(built) != len(Names())-1 || slices.ContainsFunc(built, func(s Strategy) bool { return s.Name() == "Rolling Std50 Strategy" }) {
+		t.Errorf("Build without a second STD built %d strategies, want all %d but Rolling Std50 Strategy", len(built), len(Names()))
+	}
+	if len(skipped) != 1 || !strings.Contains(skipped[0].Error(), "Rolling Std50 Strategy") || !strings.Contains(skipped[0].Error(), "STD") {
+		t.Errorf("Build without a second STD left out %v, want Rolling Std50 Strategy naming STD", skipped)
+	}
+
+	if _, _, err := Build(common.NewConfig().Strategies, nil); err == nil || !strings.Contains(err.Error(), "RSI") {
+		t.Errorf("Build without specs gave %v, want an error naming the missing indicators", err)
+	}
+}
@@ -0,0 +1,179 @@
+package strategies
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"isx-auto-scrapper/internal/common"
+	"isx-auto-scrapper/internal/numeric"
+)
+
+// ConsensusName is the signal column holding the weighted consensus of the other strategies
+const ConsensusName = "Consensus Strategy"
+
+// signalValues place the signal levels on the consensus scale; Hold and unknown signals count 0
+var signalValues = map[Signal]float64{
+	StrongBuy: 1, Buy: 2.0 / 3, WeakBuy: 1.0 / 3,
+	WeakSell: -1.0 / 3, Sell: -2.0 / 3, StrongSell: -1,
+}
+
+// direction returns 1 for the buy levels, -1 for the sell levels and 0 otherwise
+func (s Signal) direction() int {
+	switch {
+	case s.IsBuy():
+		return 1
+	case s.IsSell():
+		return -1
+	}
+	return 0
+}
+
+// discardLogger silences the backtests behind the consensus weights
+var discardLogger = common.NewLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+// consensusWeights returns the weight of every strategy on each row of one ticker: the configured factor,
+// times the strategy's Sharpe ratio or win rate unless the weighting is equal. The metrics come from a backtest
+// of the rows of the months before the row's own, refitted at the first row of each month, so no row is weighed
+// with its own outcome or a later one. Strategies that lost money or never traded there weigh 0, which holds
+// the consensus of the first month.
+func (s *Strategies) consensusWeights(cfg common.ConsensusConfig, ticker string, data []*StrategyData) []map[string]float64 {
+	names := s.names()
+	factors := make(map[string]float64, len(names))
+	for _, name := range names {
+		factor, ok := cfg.Weights[name]
+		if !ok {
+			factor = 1
+		}
+		factors[name] = factor
+	}
+
+	weights := make([]map[string]float64, len(data))
+	var current map[string]float64
+	for i, d := range data {
+		switch {
+		case cfg.Weighting == "equal":
+			current = factors
+		case i == 0 || d.Date.Year() != data[i-1].Date.Year() || d.Date.Month() != data[i-1].Date.Month():
+			current = s.trainedWeights(cfg, ticker, data[:i], factors)
+		}
+		weights[i] = current
+	}
+	return weights
+}
+
+// trainedWeights multiplies each factor by the strategy's metric in a backtest of the training rows
+func (s *Strategies) trainedWeights(cfg common.ConsensusConfig, ticker string, train []*StrategyData, factors map[string]float64) map[string]float64 {
+	weights := make(map[string]float64, len(factors))
+	for _, name := range s.names() {
+		factor := factors[name]
+		if factor == 0 || len(train) == 0 {
+			weights[name] = 0
+			continue
+		}
+
+		engine := NewBacktestEngine(discardLogger, common.AppConfig.Backtest)
+		if err := engine.runBacktest(dataPoints(ticker, train, name), name); err != nil {
+			s.logger.Warn("Backtest of %s on %s failed, leaving it out of the consensus: %v", name, ticker, err)
+			weights[name] = 0
+			continue
+		}
+		result := engine.calculatePerformanceMetrics(name)
+		metric := result.SharpeRatio.InexactFloat64()
+		if cfg.Weighting == "win_rate" {
+			metric = result.WinRate.InexactFloat64() / 100
+		}
+		weights[name] = factor * math.Max(metric, 0)
+	}
+	return weights
+}
+
+// checkConsensusWeights rejects consensus weights of strategies that are not among names, in cfg and its overrides
+func checkConsensusWeights(cfg common.StrategyConfig, names []string) error {
+	check := func(where string, weights map[string]float64) error {
+		for name := range weights {
+			if !slices.Contains(names, name) {
+				return fmt.Errorf("%s.consensus.weights: %q is not a strategy; use one of %s", where, name, strings.Join(names, ", "))
+			}
+		}
+		return nil
+	}
+	if err := check("strategies", cfg.Consensus.Weights); err != nil {
+		return err
+	}
+	for sector := range cfg.Sectors {
+		profile, _, err := cfg.Profile(sector, "")
+		if err != nil {
+			return err
+		}
+		if err := check("strategies.sectors."+sector, profile.Consensus.Weights); err != nil {
+			return err
+		}
+	}
+	for ticker := range cfg.Tickers {
+		profile, _, err := cfg.Profile("", ticker)
+		if err != nil {
+			return err
+		}
+		if err := check("strategies.tickers."+ticker, profile.Consensus.Weights); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyConsensus sets the consensus signal, score and agreement of every row with the row's weights.
+// The score is the weighted average of the signal values; the agreement is the share of the weight
+// whose signal points the same way as the consensus (Hold agreeing with Hold). The weights are summed in
+// strategy order, so a score on a level falls on the same side of it every run.
+func (s *Strategies) applyConsensus(cfg common.ConsensusConfig, data []*StrategyData, weights []map[string]float64) {
+	levels := cfg.Levels
+	names := s.names()
+	for i, d := range data {
+		var total, score float64
+		for _, name := range names {
+			weight := weights[i][name]
+			total += weight
+			score += weight * signalValues[d.Signals[name]]
+		}
+		if total > 0 {
+			score /= total
+		}
+
+		consensus := Hold
+		switch {
+		case score > levels.StrongBuy:
+			consensus = StrongBuy
+		case score > levels.Buy:
+			consensus = Buy
+		case score > levels.WeakBuy:
+			consensus = WeakBuy
+		case score < levels.StrongSell:
+			consensus = StrongSell
+		case score < levels.Sell:
+			consensus = Sell
+		case score < levels.WeakSell:
+			consensus = WeakSell
+		}
+
+		var agreeing float64
+		for _, name := range names {
+			if d.Signals[name].direction() == consensus.direction() {
+				agreeing += weights[i][name]
+			}
+		}
+		agreement := 0.0
+		if total > 0 {
+			agreement = agreeing / total
+		}
+
+		d.Signals[ConsensusName] = consensus
+		d.ConsensusScore = decimal.NewFromFloat(numeric.Round(score, 4))
+		d.Agreement = decimal.NewFromFloat(numeric.Round(agreement, 4))
+	}
+}
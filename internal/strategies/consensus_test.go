@@ -0,0 +1,113 @@
+package strategies
+
+import (
+	"maps"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"isx-auto-scrapper/internal/common"
+)
+
+// trendRows returns daily rows from January to March 2024 rising 1% a day, on which RSI Strategy buys every
+// Monday and sells every Thursday
+func trendRows() []*StrategyData {
+	var rows []*StrategyData
+	price := 10.0
+	for day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC); day.Month() <= time.March; day = day.AddDate(0, 0, 1) {
+		d := &StrategyData{Signals: map[string]Signal{"RSI Strategy": Hold}}
+		d.Date = day
+		d.Open = decimal.NewFromFloat(price)
+		d.High = decimal.NewFromFloat(price * 1.01)
+		d.Low = decimal.NewFromFloat(price * 0.99)
+		d.Close = decimal.NewFromFloat(price)
+		d.Volume = 1000
+		switch day.Weekday() {
+		case time.Monday:
+			d.Signals["RSI Strategy"] = Buy
+		case time.Thursday:
+			d.Signals["RSI Strategy"] = Sell
+		}
+		rows = append(rows, d)
+		price *= 1.01
+	}
+	return rows
+}
+
+func TestConsensusWeightsLookOnlyBack(t *testing.T) {
+	s := &Strategies{logger: discardLogger, builtin: Names()}
+	cfg := common.NewConfig().Strategies.Consensus
+	cfg.Weighting = "win_rate"
+
+	rows := trendRows()
+	weights := s.consensusWeights(cfg, "TEST", rows)
+
+	var trained bool
+	for i, d := range rows {
+		w := weights[i]["RSI Strategy"]
+		if d.Date.Month() == time.January && w != 0 {
+			t.Errorf("weight on %s = %v, want 0 without earlier months", d.Date.Format("2006-01-02"), w)
+		}
+		trained = trained || w > 0
+	}
+	if !trained {
+		t.Fatal("RSI Strategy never gained weight from its winning trades")
+	}
+
+	// A crash in March changes no weight: March is weighed with January and February only
+	for _, d := range rows {
+		if d.Date.Month() == time.March {
+			d.Close = d.Close.Div(decimal.NewFromInt(3))
+			d.Low = d.Low.Div(decimal.NewFromInt(3))
+		}
+	}
+	for i, w := range s.consensusWeights(cfg, "TEST", rows) {
+		if !maps.Equal(w, weights[i]) {
+			t.Fatalf("weights on %s changed with later bars: %v, was %v", rows[i].Date.Format("2006-01-02"), w, weights[i])
+		}
+	}
+}
+
+func TestConsensusWeightsNameStrategies(t *testing.T) {
+	cfg := common.NewConfig().Strategies
+	if err := checkConsensusWeights(cfg, Names()); err != nil {
+		t.Fatalf("default settings: %v", err)
+	}
+
+	cfg.Consensus.Weights = map[string]float64{"RSI Strategy": 2, "RSI Stratgy": 0}
+	if err := checkConsensusWeights(cfg, Names()); err == nil || !strings.Contains(err.Error(), "RSI Stratgy") {
+		t.Errorf("misspelt weight gave %v, want an error naming it", err)
+	}
+
+	cfg = common.NewConfig().Strategies
+	cfg.Sectors = map[string]common.StrategyOverride{
+		"Banks": {"consensus": map[string]interface{}{"weights": map[string]interface{}{"Nope": 1}}},
+	}
+	if err := checkConsensusWeights(cfg, Names()); err == nil || !strings.Contains(err.Error(), "strategies.sectors.Banks") {
+		t.Errorf("unknown weight in a sector override gave %v, want an error naming the override", err)
+	}
+}
+
+func TestConsensusIsReproducible(t *testing.T) {
+	s := &Strategies{logger: discardLogger, builtin: Names()}
+	names := s.names()
+	cfg := common.NewConfig().Strategies.Consensus
+	cfg.Levels = common.Levels{StrongBuy: 0.9, Buy: 0.5, WeakBuy: 0.1, WeakSell: -0.1, Sell: -0.5, StrongSell: -0.9}
+
+	// 0.1 + 0.2 + 0.3 and 0.3 + 0.2 + 0.1 differ in float64, putting the score either side of the Buy level
+	weights := []map[string]float64{{names[0]: 0.1, names[1]: 0.2, names[2]: 0.3}}
+	signals := map[string]Signal{names[0]: StrongBuy, names[1]: StrongBuy, names[2]: Hold}
+
+	var first Signal
+	for run := 0; run < 100; run++ {
+		row := &StrategyData{Signals: maps.Clone(signals)}
+		s.applyConsensus(cfg, []*StrategyData{row}, weights)
+		if run == 0 {
+			first = row.Signals[ConsensusName]
+		} else if got := row.Signals[ConsensusName]; got != first {
+			t.Fatalf("consensus of run %d = %s, run 0 gave %s", run, got, first)
+		}
+	}
+}
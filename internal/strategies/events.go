@@ -0,0 +1,269 @@
+package strategies
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gocarina/gocsv"
+	"github.com/shopspring/decimal"
+
+	"isx-auto-scrapper/internal/indicators"
+)
+
+// SignalEvent is a change of one strategy's signal from one bar to the next, with the bar's close
+// and the key indicators most strategies read, keyed by their configured column
+type SignalEvent struct {
+	Ticker     string             `csv:"Ticker" json:"ticker"`
+	Strategy   string             `csv:"Strategy" json:"strategy"`
+	Date       string             `csv:"Date" json:"date"`
+	From       Signal             `csv:"From" json:"from"`
+	To         Signal             `csv:"To" json:"to"`
+	Price      float64            `csv:"Price" json:"price"`
+	Indicators map[string]float64 `csv:"-" json:"indicators"`
+	RSRank     float64            `csv:"RS_Rank" json:"rs_rank"`
+	Consensus  float64            `csv:"Consensus_Score" json:"consensus_score"`
+}
+
+// keyInputs are the indicators logged with each signal change
+var keyInputs = []input{rsiLine, macdHist, cmfLine, obvRoC}
+
+// eventColumns returns the columns of the key indicators specs write; unconfigured ones are left out
+func eventColumns(specs []indicators.Spec) []string {
+	var columns []string
+	for _, in := range keyInputs {
+		if column, ok := in.column(specs); ok {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// eventWriter receives the rows gocsv writes for SignalEvent and adds the indicator columns before RS_Rank
+type eventWriter struct {
+	*csv.Writer
+	events  []SignalEvent
+	columns []string
+	at      int // index of RS_Rank in the gocsv row, -1 before the header is seen
+	row     int
+}
+
+func (w *eventWriter) Write(record []string) error {
+	if w.at < 0 {
+		w.at = slices.Index(record, "RS_Rank")
+		if w.at < 0 {
+			return fmt.Errorf("signal event header has no RS_Rank column")
+		}
+		return w.Writer.Write(slices.Insert(slices.Clone(record), w.at, w.columns...))
+	}
+	values := make([]string, len(w.columns))
+	for k, column := range w.columns {
+		if v, ok := w.events[w.row].Indicators[column]; ok {
+			values[k] = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+	w.row++
+	return w.Writer.Write(slices.Insert(slices.Clone(record), w.at, values...))
+}
+
+// SignalState is the signal a strategy gives on a ticker's last bar and how long it has held.
+// Since is the first bar of the strategy file when the log has no change to the signal.
+type SignalState struct {
+	Signal  Signal `json:"signal"`
+	Since   string `json:"since"`
+	AgeDays int    `json:"age_days"` // Calendar days from Since to the last bar
+	AgeBars int    `json:"age_bars"` // Bars of the strategy file after Since
+}
+
+// SignalEventsFile returns the signal change log of timeframe tf
+func SignalEventsFile(tf indicators.Timeframe) string {
+	return "signal_events" + tf.Suffix() + ".csv"
+}
+
+// SaveSignalEvents writes the events to filePath with the indicator columns, followed by any other
+// indicator the older events carry; cells of indicators an event lacks are left empty
+func SaveSignalEvents(events []SignalEvent, columns []string, filePath string) error {
+	var older []string
+	for _, e := range events {
+		for column := range e.Indicators {
+			if !slices.Contains(columns, column) && !slices.Contains(older, column) {
+				older = append(older, column)
+			}
+		}
+	}
+	sort.Strings(older)
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := &eventWriter{Writer: csv.NewWriter(file), events: events, columns: append(slices.Clone(columns), older...), at: -1}
+	return gocsv.MarshalCSV(&events, w)
+}
+
+// LoadSignalEvents reads the events written by SaveSignalEvents; every column that is not a SignalEvent
+// field is an indicator
+func LoadSignalEvents(filePath string) ([]SignalEvent, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []SignalEvent
+	if err := gocsv.UnmarshalBytes(content, &events); err != nil {
+		return nil, err
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) != len(events)+1 {
+		return nil, fmt.Errorf("%s: %d records for %d events", filePath, len(records), len(events))
+	}
+	fields := []string{"Ticker", "Strategy", "Date", "From", "To", "Price", "RS_Rank", "Consensus_Score"}
+	for i := range events {
+		events[i].Indicators = make(map[string]float64)
+		for k, column := range records[0] {
+			cell := records[i+1][k]
+			if slices.Contains(fields, column) || cell == "" {
+				continue
+			}
+			if v, err := strconv.ParseFloat(cell, 64); err == nil {
+				events[i].Indicators[column] = v
+			}
+		}
+	}
+	return events, nil
+}
+
+// SignalChanges returns the events of the log dated on or after since, oldest first
+func SignalChanges(events []SignalEvent, since string) []SignalEvent {
+	out := []SignalEvent{}
+	for _, e := range events {
+		if e.Date >= since {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// signalEvents returns the signal changes of one ticker's rows, strategies by name. The first row is
+// compared with the signal the ticker's logged events (oldest first) leave before it, and is skipped
+// for strategies the log never saw change; values are the key indicators by bar date.
+func signalEvents(ticker string, data []*StrategyData, logged []SignalEvent, values map[string]map[string]float64) []SignalEvent {
+	var events []SignalEvent
+	if len(data) == 0 {
+		return events
+	}
+	names := make([]string, 0, len(data[0].Signals))
+	for name := range data[0].Signals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	first := data[0].Date.Format("2006-01-02")
+	before := make(map[string]Signal)
+	for _, e := range logged {
+		switch {
+		case e.Date < first:
+			before[e.Strategy] = e.To
+		case e.Date == first:
+			before[e.Strategy] = e.From
+		}
+	}
+
+	for i, d := range data {
+		date := d.Date.Format("2006-01-02")
+		for _, name := range names {
+			from, ok := before[name]
+			if i > 0 {
+				from, ok = data[i-1].Signals[name], true
+			}
+			to := d.Signals[name]
+			if !ok || from == to {
+				continue
+			}
+			keys := make(map[string]float64, len(values[date]))
+			for column, v := range values[date] {
+				keys[column] = round4(decimal.NewFromFloat(v))
+			}
+			events = append(events, SignalEvent{
+				Ticker:     ticker,
+				Strategy:   name,
+				Date:       date,
+				From:       from,
+				To:         to,
+				Price:      round4(d.Close),
+				Indicators: keys,
+				RSRank:     round4(d.RSRank),
+				Consensus:  round4(d.ConsensusScore),
+			})
+		}
+	}
+	return events
+}
+
+// mergeSignalEvents replaces the events of each ticker in fresh from the first bar of its file on,
+// keeping the older ones and those of tickers not read, and orders the log by date then ticker
+func mergeSignalEvents(logged []SignalEvent, fresh map[string][]SignalEvent, firstBar map[string]string) []SignalEvent {
+	var merged []SignalEvent
+	for _, e := range logged {
+		if first, ok := firstBar[e.Ticker]; ok && e.Date >= first {
+			continue
+		}
+		merged = append(merged, e)
+	}
+	for _, events := range fresh {
+		merged = append(merged, events...)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].Date != merged[j].Date {
+			return merged[i].Date < merged[j].Date
+		}
+		return merged[i].Ticker < merged[j].Ticker
+	})
+	return merged
+}
+
+// signalStates returns the state of every signal on the last row; events are the ticker's log, oldest first
+func signalStates(data []*StrategyData, events []SignalEvent) map[string]SignalState {
+	states := make(map[string]SignalState)
+	if len(data) == 0 {
+		return states
+	}
+	last := data[len(data)-1]
+	for name, signal := range last.Signals {
+		since := data[0].Date.Format("2006-01-02")
+		for k := len(events) - 1; k >= 0; k-- {
+			if events[k].Strategy == name {
+				if events[k].To == signal {
+					since = events[k].Date
+				}
+				break
+			}
+		}
+
+		state := SignalState{Signal: signal, Since: since}
+		for i := len(data) - 1; i >= 0 && data[i].Date.Format("2006-01-02") > since; i-- {
+			state.AgeBars++
+		}
+		if start, err := time.Parse("2006-01-02", since); err == nil {
+			state.AgeDays = int(last.Date.Sub(start).Hours() / 24)
+		}
+		states[name] = state
+	}
+	return states
+}
+
+// round4 keeps the logged indicator values readable
+func round4(v decimal.Decimal) float64 {
+	return v.Round(4).InexactFloat64()
+}
@@ -0,0 +1,85 @@
+package strategies
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// signalRows returns daily rows from 2024-03-01 giving RSI Strategy the signals in turn
+func signalRows(signals ...Signal) []*StrategyData {
+	var rows []*StrategyData
+	for i, signal := range signals {
+		d := &StrategyData{Signals: map[string]Signal{"RSI Strategy": signal}}
+		d.Date = time.Date(2024, 3, 1+i, 0, 0, 0, 0, time.UTC)
+		d.Close = decimal.NewFromInt(10)
+		rows = append(rows, d)
+	}
+	return rows
+}
+
+func TestSignalEventsSeedFirstBarFromLog(t *testing.T) {
+	rows := signalRows(Buy, Buy, Sell)
+
+	if events := signalEvents("TEST", rows, nil, nil); len(events) != 1 || events[0].Date != "2024-03-03" {
+		t.Fatalf("events without a log = %v, want the change on 2024-03-03 only", events)
+	}
+
+	logged := []SignalEvent{
+		{Ticker: "TEST", Strategy: "RSI Strategy", Date: "2024-02-10", From: Hold, To: Sell},
+		{Ticker: "TEST", Strategy: "RSI Strategy", Date: "2024-02-20", From: Sell, To: Hold},
+	}
+	events := signalEvents("TEST", rows, logged, nil)
+	if len(events) != 2 || events[0].Date != "2024-03-01" || events[0].From != Hold || events[0].To != Buy {
+		t.Fatalf("events after a log ending in Hold = %v, want Hold to Buy on the first bar", events)
+	}
+
+	// Rewriting the window keeps the change logged on its first bar
+	if again := signalEvents("TEST", rows, append(logged, events...), nil); !slices.EqualFunc(again, events, sameEvent) {
+		t.Errorf("events rewritten over their own log = %v, want %v", again, events)
+	}
+
+	logged[1].To = Buy
+	if events := signalEvents("TEST", rows, logged, nil); len(events) != 1 || events[0].Date != "2024-03-03" {
+		t.Errorf("events after a log ending in Buy = %v, want the change on 2024-03-03 only", events)
+	}
+}
+
+func TestSignalEventsFollowSpecs(t *testing.T) {
+	columns := eventColumns(defaultSpecs(t, map[string]string{"RSI(14)": "RSI(7)", "CMF(20)": ""}))
+	if want := []string{"RSI_7", "MACDh_12_26_9", "OBV_RoC"}; !slices.Equal(columns, want) {
+		t.Fatalf("event columns = %v, want %v", columns, want)
+	}
+
+	values := map[string]map[string]float64{"2024-03-02": {"RSI_7": 28.123456, "MACDh_12_26_9": -0.5, "OBV_RoC": 3}}
+	events := signalEvents("TEST", signalRows(Hold, Buy), nil, values)
+
+	// An event logged under the former RSI_14 column is kept beside the configured ones
+	events = append([]SignalEvent{{Ticker: "TEST", Strategy: "RSI Strategy", Date: "2024-01-05",
+		From: Hold, To: Sell, Indicators: map[string]float64{"RSI_14": 75}}}, events...)
+	path := filepath.Join(t.TempDir(), "signal_events.csv")
+	if err := SaveSignalEvents(events, columns, path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadSignalEvents(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.EqualFunc(loaded, events, sameEvent) {
+		t.Fatalf("loaded events = %v, want %v", loaded, events)
+	}
+	if got := loaded[1].Indicators["RSI_7"]; got != 28.1235 {
+		t.Errorf("logged RSI_7 = %v, want 28.1235", got)
+	}
+}
+
+// sameEvent compares two events field by field, an empty indicator map equal to none
+func sameEvent(a, b SignalEvent) bool {
+	return a.Ticker == b.Ticker && a.Strategy == b.Strategy && a.Date == b.Date && a.From == b.From &&
+		a.To == b.To && a.Price == b.Price && a.RSRank == b.RSRank && a.Consensus == b.Consensus &&
+		maps.Equal(a.Indicators, b.Indicators)
+}
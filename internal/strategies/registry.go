@@ -0,0 +1,110 @@
+package strategies
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"isx-auto-scrapper/internal/common"
+	"isx-auto-scrapper/internal/indicators"
+)
+
+// Signal is the verdict of a strategy on one bar, one of seven levels from Strong Buy to Strong Sell
+type Signal string
+
+// Strategy signal levels with intermediate states
+const (
+	StrongBuy  Signal = "Strong Buy"
+	Buy        Signal = "Buy"
+	WeakBuy    Signal = "Weak Buy"
+	Hold       Signal = "Hold"
+	WeakSell   Signal = "Weak Sell"
+	Sell       Signal = "Sell"
+	StrongSell Signal = "Strong Sell"
+)
+
+// Strategy turns the indicator bars of one ticker into a signal per bar.
+// Its name is the column of Strategies_<TICKER>.csv and the name backtests and summaries use.
+type Strategy interface {
+	Name() string
+	// Params returns the settings the strategy runs with, keyed as in the config
+	Params() map[string]float64
+	// Evaluate returns one signal per bar, oldest first; bars it cannot judge are Hold
+	Evaluate(bars []*indicators.StockDataWithIndicators) []Signal
+}
+
+// Factory builds a strategy from the strategy settings in effect
+type Factory func(cfg common.StrategyConfig) Strategy
+
+var registry []Factory
+
+// Register adds a strategy; names must be unique. Strategies run and are written in registration order.
+func Register(factory Factory) {
+	name := factory(common.StrategyConfig{}).Name()
+	for _, other := range registry {
+		if other(common.StrategyConfig{}).Name() == name {
+			panic("strategy registered twice: " + name)
+		}
+	}
+	registry = append(registry, factory)
+}
+
+// binder is a strategy that finds the indicator columns it reads among the configured specs when built
+type binder interface {
+	bind(specs []indicators.Spec) error
+}
+
+// reader is a strategy reading indicator columns by name, which every indicator file it evaluates must have
+type reader interface {
+	reads() []string
+}
+
+// Build returns the registered strategies configured with cfg that find the indicators they read among the
+// specs, and why each other one is left out. It fails only when no strategy can be built.
+func Build(cfg common.StrategyConfig, specs []indicators.Spec) ([]Strategy, []error, error) {
+	var out []Strategy
+	var skipped []error
+	for _, factory := range registry {
+		strategy := factory(cfg)
+		if b, ok := strategy.(binder); ok {
+			if err := b.bind(specs); err != nil {
+				skipped = append(skipped, err)
+				continue
+			}
+		}
+		out = append(out, strategy)
+	}
+	if len(out) == 0 {
+		return nil, skipped, fmt.Errorf("no strategy can run with the indicators in %s: %w",
+			common.AppConfig.Indicators.SpecFile, errors.Join(skipped...))
+	}
+	return out, skipped, nil
+}
+
+// Names returns the names of the registered strategies, which are the signal columns of the strategy files
+func Names() []string {
+	names := make([]string, len(registry))
+	for k, factory := range registry {
+		names[k] = factory(common.StrategyConfig{}).Name()
+	}
+	return names
+}
+
+// holdAll returns n Hold signals, the starting point of every strategy
+func holdAll(n int) []Signal {
+	signals := make([]Signal, n)
+	for i := range signals {
+		signals[i] = Hold
+	}
+	return signals
+}
+
+// IsBuy reports whether the signal is one of the buy levels
+func (s Signal) IsBuy() bool {
+	return strings.Contains(string(s), "Buy")
+}
+
+// IsSell reports whether the signal is one of the sell levels
+func (s Signal) IsSell() bool {
+	return strings.Contains(string(s), "Sell")
+}
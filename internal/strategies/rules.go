@@ -0,0 +1,249 @@
+package strategies
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"isx-auto-scrapper/internal/common"
+	"isx-auto-scrapper/internal/indicators"
+)
+
+// Rule strategies are written in strategies.rules_file, YAML or JSON by extension:
+//
+//	strategies:
+//	  - name: RSI Pullback Strategy
+//	    entry:
+//	      - signal: Strong Buy
+//	        when: RSI_14 < 25 AND Close > SMA200
+//	      - signal: Buy
+//	        when: CROSS(Close, EMA5)
+//	    exit:
+//	      - signal: Sell
+//	        when: RSI_14 > 70
+//	        bars: 3
+//
+// Conditions are custom indicator formulas over the bar's columns and the custom indicators. A rule
+// matches when its condition held on the last bars bars (1 by default). The first matching entry rule
+// gives a buy level and the first matching exit rule a sell level; a bar matching both, or neither, holds.
+
+// RuleStrategy is one strategy of the rules file
+type RuleStrategy struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Entry       []Rule `json:"entry" yaml:"entry"`
+	Exit        []Rule `json:"exit" yaml:"exit"`
+}
+
+// Rule maps a condition to a signal level
+type Rule struct {
+	Signal Signal `json:"signal" yaml:"signal"`
+	When   string `json:"when" yaml:"when"`
+	Bars   int    `json:"bars,omitempty" yaml:"bars,omitempty"`
+}
+
+// rulesFile is the layout of the rules file
+type rulesFile struct {
+	Strategies []RuleStrategy `json:"strategies" yaml:"strategies"`
+}
+
+// Configured returns the registered strategies configured with cfg that the indicator specs can feed, followed
+// by those of cfg.RulesFile, and why each registered one left out is; consensus weights must name a strategy
+func Configured(cfg common.StrategyConfig) ([]Strategy, []error, error) {
+	specs, err := indicators.ConfiguredSpecs()
+	if err != nil {
+		return nil, nil, err
+	}
+	builtin, skipped, err := Build(cfg, specs)
+	if err != nil {
+		return nil, skipped, err
+	}
+	rules, err := LoadRuleStrategies(cfg.RulesFile, append(Names(), ConsensusName))
+	if err != nil {
+		return nil, skipped, err
+	}
+
+	names := Names()
+	for _, rule := range rules {
+		names = append(names, rule.Name())
+	}
+	if err := checkConsensusWeights(cfg, names); err != nil {
+		return nil, skipped, err
+	}
+	return append(builtin, rules...), skipped, nil
+}
+
+// LoadRuleStrategies reads and validates the rule strategies of path; their names must differ from taken.
+// A missing file gives none.
+func LoadRuleStrategies(path string, taken []string) ([]Strategy, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file rulesFile
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(content, &file)
+	} else {
+		err = yaml.Unmarshal(content, &file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+
+	kinds, err := ruleColumns()
+	if err != nil {
+		return nil, err
+	}
+	fields, err := Header(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	taken = slices.Clone(taken)
+	out := make([]Strategy, 0, len(file.Strategies))
+	for _, rs := range file.Strategies {
+		name := strings.TrimSpace(rs.Name)
+		switch {
+		case name == "":
+			return nil, fmt.Errorf("invalid %s: every strategy needs a name", path)
+		case slices.Contains(taken, name):
+			return nil, fmt.Errorf("invalid %s: strategy %s is defined twice", path, name)
+		case slices.Contains(fields, name):
+			return nil, fmt.Errorf("invalid %s: strategy %s would replace the %s column", path, name, name)
+		case len(rs.Entry)+len(rs.Exit) == 0:
+			return nil, fmt.Errorf("invalid %s: %s has no entry or exit rules", path, name)
+		}
+		taken = append(taken, name)
+
+		strategy := &ruleStrategy{name: name}
+		for _, side := range []struct {
+			label string
+			rules []Rule
+			valid func(Signal) bool
+			out   *[]compiledRule
+		}{
+			{"entry", rs.Entry, Signal.IsBuy, &strategy.entry},
+			{"exit", rs.Exit, Signal.IsSell, &strategy.exit},
+		} {
+			for n, rule := range side.rules {
+				where := fmt.Sprintf("%s %s rule %d", name, side.label, n+1)
+				if k := slices.IndexFunc(signalLevels, func(l Signal) bool { return strings.EqualFold(string(l), string(rule.Signal)) }); k >= 0 {
+					rule.Signal = signalLevels[k]
+				}
+				if !slices.Contains(signalLevels, rule.Signal) || !side.valid(rule.Signal) {
+					return nil, fmt.Errorf("invalid %s: %s: signal %q is not a level an %s rule can give", path, where, rule.Signal, side.label)
+				}
+				if rule.Bars < 0 {
+					return nil, fmt.Errorf("invalid %s: %s: bars must be at least 1", path, where)
+				}
+				condition, err := indicators.CompileCondition(rule.When, kinds)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s: %s: %w", path, where, err)
+				}
+				*side.out = append(*side.out, compiledRule{rule.Signal, condition, max(rule.Bars, 1)})
+			}
+		}
+		out = append(out, strategy)
+	}
+	return out, nil
+}
+
+// signalLevels are the seven signal levels from strongest buy to strongest sell
+var signalLevels = []Signal{StrongBuy, Buy, WeakBuy, Hold, WeakSell, Sell, StrongSell}
+
+// compiledRule is a validated rule
+type compiledRule struct {
+	signal    Signal
+	condition *indicators.Condition
+	bars      int
+}
+
+// ruleStrategy runs the rules of one strategy of the rules file
+type ruleStrategy struct {
+	name        string
+	entry, exit []compiledRule
+}
+
+func (s *ruleStrategy) Name() string { return s.name }
+
+func (s *ruleStrategy) Params() map[string]float64 {
+	return map[string]float64{"entry_rules": float64(len(s.entry)), "exit_rules": float64(len(s.exit))}
+}
+
+func (s *ruleStrategy) Evaluate(bars []*bar) []Signal {
+	signals := holdAll(len(bars))
+	entry := s.start(s.entry)
+	exit := s.start(s.exit)
+	for i, d := range bars {
+		buy, sell := entry(d), exit(d)
+		switch {
+		case buy != Hold && sell == Hold:
+			signals[i] = buy
+		case sell != Hold && buy == Hold:
+			signals[i] = sell
+		}
+	}
+	return signals
+}
+
+// reads returns the columns the rule conditions read
+func (s *ruleStrategy) reads() []string {
+	var names []string
+	for _, rule := range append(slices.Clone(s.entry), s.exit...) {
+		for _, name := range rule.condition.Columns() {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// start returns a judge of rules over consecutive bars giving the signal of the first matching rule, or Hold.
+// Every condition sees every bar so their windows and bar counts stay complete.
+func (s *ruleStrategy) start(rules []compiledRule) func(d *bar) Signal {
+	judges := make([]func([]float64) bool, len(rules))
+	held := make([]int, len(rules))
+	for k, rule := range rules {
+		judges[k] = rule.condition.Judge()
+	}
+
+	return func(d *bar) Signal {
+		signal := Hold
+		for k, rule := range rules {
+			columns := rule.condition.Columns()
+			values := make([]float64, len(columns))
+			for c, name := range columns {
+				values[c] = d.Value(name)
+			}
+			if judges[k](values) {
+				held[k]++
+			} else {
+				held[k] = 0
+			}
+			if signal == Hold && held[k] >= rule.bars {
+				signal = rule.signal
+			}
+		}
+		return signal
+	}
+}
+
+// ruleColumns returns the columns rule conditions can read, mapped to whether each is a flag: every number
+// and flag column of the indicator files, written by the configured specs and custom indicators
+func ruleColumns() (map[string]bool, error) {
+	specs, err := indicators.ConfiguredSpecs()
+	if err != nil {
+		return nil, err
+	}
+	return indicators.FormulaColumns(specs), nil
+}
@@ -0,0 +1,59 @@
+package strategies
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// loadRules writes a rules file and loads its strategies as strategies does
+func loadRules(t *testing.T, content string) ([]Strategy, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "strategy_rules.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return LoadRuleStrategies(path, append(Names(), ConsensusName))
+}
+
+func TestRuleOnSpecColumns(t *testing.T) {
+	rules, err := loadRules(t, `
+strategies:
+  - name: Trend Strength Strategy
+    entry:
+      - signal: Buy
+        when: ADX_14 > 25 AND MFI_14 < 30 AND Close > VWAP_20
+    exit:
+      - signal: Sell
+        when: AROONOSC_25 < -50 OR ICS_26 < 0
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bars := loadBars(t, defaultSpecs(t, nil),
+		map[string]string{"ADX_14": "30", "MFI_14": "20", "Close": "105", "VWAP_20": "100"},
+		map[string]string{"ADX_14": "30", "MFI_14": "40", "Close": "105", "VWAP_20": "100"},
+		map[string]string{"Close": "95", "ICS_26": "-3"},
+	)
+	signals := rules[0].Evaluate(bars)
+	for i, want := range []Signal{Buy, Hold, Sell} {
+		if signals[i] != want {
+			t.Errorf("signal on bar %d = %s, want %s", i, signals[i], want)
+		}
+	}
+}
+
+func TestRuleOnUnknownColumn(t *testing.T) {
+	_, err := loadRules(t, `
+strategies:
+  - name: Stale Strategy
+    entry:
+      - signal: Buy
+        when: ADX_20 > 25
+`)
+	if err == nil || !strings.Contains(err.Error(), "ADX_20") {
+		t.Errorf("a rule on a column no spec writes gave %v, want an error naming it", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -12,105 +13,152 @@ import (
 	"github.com/gocarina/gocsv"
 	"github.com/shopspring/decimal"
 
+	"isx-auto-scrapper/internal/calendar"
 	"isx-auto-scrapper/internal/common"
 	"isx-auto-scrapper/internal/indicators"
+	"isx-auto-scrapper/internal/numeric"
 )
 
 // Strategies handles trading strategy analysis
 type Strategies struct {
-	logger *common.Logger
-	config StrategyConfig
+	logger    *common.Logger
+	config    common.StrategyConfig
+	builtin   []string          // Built-in strategies the indicator specs can feed, in column order
+	rules     []Strategy        // Rule strategies, the same for every ticker
+	specs     []indicators.Spec // Indicator specs the built-in strategies read their columns from
+	failed    map[string]error  // Tickers left out of a run, with the reason
+	sectors   map[string]string // TICKERS.csv sector by ticker, for the sector overrides
+	loaded    bool
+	calendar  *calendar.Calendar
+	timeframe indicators.Timeframe
 }
 
 // NewStrategies creates a new Strategies instance
-func NewStrategies() *Strategies {
-	cfg, err := loadStrategyConfig("strategy_config.json")
-	if err != nil {
-		cfg = defaultStrategyConfig
-	}
+func NewStrategies(logger *common.Logger) *Strategies {
 	return &Strategies{
-		logger: common.NewLogger(),
-		config: cfg,
+		logger:    logger,
+		config:    common.AppConfig.Strategies,
+		calendar:  calendar.Default(),
+		timeframe: indicators.Daily,
+		failed:    make(map[string]error),
 	}
 }
 
-// StrategyData represents stock data with applied strategies
-type StrategyData struct {
-	indicators.StockDataWithIndicators
-	RSIStrategy          string `csv:"RSI Strategy"`
-	RSIStrategy2         string `csv:"RSI Strategy2"`
-	RSI14OBVRoCStrategy  string `csv:"RSI14_OBV_RoC Strategy"`
-	RSIMACDStrategy      string `csv:"RSIMACD Strategy"`
-	RSICMFStrategy       string `csv:"RSICMF Strategy"`
-	RSIOBVStrategy       string `csv:"RSI OBV Strategy"`
-	OBVStrategy          string `csv:"OBV Strategy"`
-	MACDStrategy         string `csv:"MACD Strategy"`
-	CMFStrategy          string `csv:"CMF Strategy"`
-	EMA5PSARStrategy     string `csv:"EMA5 PSAR Strategy"`
-	EMA5PSARStrategy2    string `csv:"EMA5 PSAR Strategy2"`
-	RollingStd10Strategy string `csv:"Rolling Std10 Strategy"`
-	RollingStd50Strategy string `csv:"Rolling Std50 Strategy"`
+// Failed returns the tickers the strategies or the consensus left out, with the reason
+func (s *Strategies) Failed() map[string]error {
+	return s.failed
 }
 
-// Strategy signal levels with intermediate states
-const (
-	StrongBuy  = "Strong Buy"
-	Buy        = "Buy"
-	WeakBuy    = "Weak Buy"
-	Hold       = "Hold"
-	WeakSell   = "Weak Sell"
-	Sell       = "Sell"
-	StrongSell = "Strong Sell"
-)
+// fail logs why a ticker is left out and records it
+func (s *Strategies) fail(ticker string, err error) {
+	s.logger.Error("%v", err)
+	s.failed[ticker] = err
+}
+
+// loadStrategies reads the indicator specs, the rule strategies and the ticker sectors on first use, so an
+// invalid rules file stops the run with its reason. Built-in strategies reading an indicator the spec file
+// lacks are left out with a warning; the run stops only when none is left.
+func (s *Strategies) loadStrategies() error {
+	if s.loaded {
+		return nil
+	}
+	specs, err := indicators.ConfiguredSpecs()
+	if err != nil {
+		return err
+	}
+	built, skipped, err := Build(s.config, specs)
+	if err != nil {
+		return err
+	}
+	for _, err := range skipped {
+		s.logger.Warn("%v; leaving the strategy out", err)
+	}
+	s.specs = specs
+	s.builtin = make([]string, len(built))
+	for k, strategy := range built {
+		s.builtin[k] = strategy.Name()
+	}
+
+	rules, err := LoadRuleStrategies(s.config.RulesFile, append(Names(), ConsensusName))
+	if err != nil {
+		return err
+	}
+	s.rules = rules
+	known := Names()
+	for _, rule := range rules {
+		known = append(known, rule.Name())
+	}
+	if err := checkConsensusWeights(s.config, known); err != nil {
+		return err
+	}
+
+	s.sectors = make(map[string]string)
+	if len(s.config.Sectors) > 0 {
+		infos, err := common.LoadTickersWithInfo("TICKERS.csv")
+		if err != nil {
+			return fmt.Errorf("sector overrides need the sectors in TICKERS.csv: %w", err)
+		}
+		known := make(map[string]bool)
+		for _, info := range infos {
+			s.sectors[info.Symbol] = info.Sector
+			known[info.Sector] = true
+		}
+		for sector := range s.config.Sectors {
+			if !known[sector] {
+				s.logger.Warn("Sector override %s matches no sector in TICKERS.csv", sector)
+			}
+		}
+	}
+	s.loaded = true
+	return nil
+}
 
-// Levels holds threshold levels for a strategy
-type Levels struct {
-	StrongBuy  float64 `json:"strong_buy"`
-	Buy        float64 `json:"buy"`
-	WeakBuy    float64 `json:"weak_buy"`
-	WeakSell   float64 `json:"weak_sell"`
-	Sell       float64 `json:"sell"`
-	StrongSell float64 `json:"strong_sell"`
+// profile returns the strategy settings of ticker with its sector and ticker overrides, and their profile name
+func (s *Strategies) profile(ticker string) (common.StrategyConfig, string, error) {
+	return s.config.Profile(s.sectors[ticker], ticker)
 }
 
-// MACDHistLevels defines thresholds for MACD histogram strength
-type MACDHistLevels struct {
-	Strong float64 `json:"strong"`
-	Buy    float64 `json:"buy"`
+// names returns the names of the strategies that run, in column order
+func (s *Strategies) names() []string {
+	names := slices.Clone(s.builtin)
+	for _, rule := range s.rules {
+		names = append(names, rule.Name())
+	}
+	return names
 }
 
-// StrategyConfig defines tunable strategy thresholds
-type StrategyConfig struct {
-	RSI      Levels         `json:"rsi"`
-	RSI2     Levels         `json:"rsi2"`
-	CMF      Levels         `json:"cmf"`
-	OBVRoC   Levels         `json:"obvroc"`
-	MACDHist MACDHistLevels `json:"macd_hist"`
+// SetTimeframe selects the bars the strategies run on; files of other timeframes carry its suffix
+func (s *Strategies) SetTimeframe(tf indicators.Timeframe) {
+	s.timeframe = tf
 }
 
-// defaultStrategyConfig provides sane defaults if no file is found
-var defaultStrategyConfig = StrategyConfig{
-	RSI:      Levels{20, 30, 40, 60, 70, 80},
-	RSI2:     Levels{15, 25, 35, 65, 75, 85},
-	CMF:      Levels{0.2, 0.1, 0.05, -0.05, -0.1, -0.2},
-	OBVRoC:   Levels{10, 5, 2, -2, -5, -10},
-	MACDHist: MACDHistLevels{0.1, 0.05},
+// indicatorsFile returns the indicator sheet of ticker for the selected timeframe
+func (s *Strategies) indicatorsFile(ticker string) string {
+	return fmt.Sprintf("indicators_%s%s.csv", ticker, s.timeframe.Suffix())
 }
 
-// loadStrategyConfig reads configuration from a JSON file
-func loadStrategyConfig(path string) (StrategyConfig, error) {
-	f, err := os.Open(path)
-	if err != nil {
-		return StrategyConfig{}, err
-	}
-	defer f.Close()
+// strategiesFile returns the strategy sheet of ticker for the selected timeframe
+func (s *Strategies) strategiesFile(ticker string) string {
+	return fmt.Sprintf("Strategies_%s%s.csv", ticker, s.timeframe.Suffix())
+}
 
-	var cfg StrategyConfig
-	dec := json.NewDecoder(f)
-	if err := dec.Decode(&cfg); err != nil {
-		return StrategyConfig{}, err
-	}
-	return cfg, nil
+// StrategyData is one row of Strategies_<TICKER>.csv: the indicator bar with the signal of every strategy
+type StrategyData struct {
+	indicators.StockDataWithIndicators
+
+	// Relative strength of the bar's session from relative_<TICKER>.csv, 0 when unknown
+	RSRank decimal.Decimal `csv:"RS_Rank"`
+	Beta   decimal.Decimal `csv:"Beta"`
+
+	// Consensus Strategy score from 1 to -1 and the share of strategy weight agreeing with it
+	ConsensusScore decimal.Decimal `csv:"Consensus_Score"`
+	Agreement      decimal.Decimal `csv:"Consensus_Agreement"`
+
+	// Strategy settings the row was judged with: default, or the sector and ticker overrides applied
+	Profile string `csv:"Strategy_Profile"`
+
+	// Signals holds the signal of each strategy by name; they are written before RS_Rank
+	Signals map[string]Signal `csv:"-" json:"-"`
 }
 
 // ApplyStrategiesAndSave applies strategies and saves results
@@ -128,25 +176,34 @@ func (s *Strategies) ApplyStrategiesAndSave() error {
 		return nil
 	}
 
+	return s.ApplyStrategiesAndSaveFor(tickers)
+}
+
+// ApplyStrategiesAndSaveFor applies strategies and saves results for the given tickers only
+func (s *Strategies) ApplyStrategiesAndSaveFor(tickers []string) error {
+	if err := s.loadStrategies(); err != nil {
+		return err
+	}
+	windowStart, _, _ := s.calendar.YearWindow(time.Now())
+
 	for _, ticker := range tickers {
-		filePath := fmt.Sprintf("indicators_%s.csv", ticker)
+		filePath := s.indicatorsFile(ticker)
 		if _, err := os.Stat(filePath); os.IsNotExist(err) {
-			s.logger.Error("indicators_%s.csv does not exist", ticker)
+			s.fail(ticker, fmt.Errorf("%s does not exist", filePath))
 			continue
 		}
 
 		// Load indicators data
 		indicatorData, err := s.loadIndicatorData(filePath)
 		if err != nil {
-			s.logger.Error("Error loading indicators for %s: %v", ticker, err)
+			s.fail(ticker, fmt.Errorf("error loading indicators for %s: %w", ticker, err))
 			continue
 		}
 
-		// Filter to last 12 months
-		oneYearAgo := time.Now().AddDate(-1, 0, 0)
+		// Filter to the last 12 months of ISX sessions
 		var filteredData []*indicators.StockDataWithIndicators
 		for _, data := range indicatorData {
-			if data.Date.After(oneYearAgo) {
+			if !data.Date.Before(windowStart) {
 				filteredData = append(filteredData, data)
 			}
 		}
@@ -156,17 +213,35 @@ func (s *Strategies) ApplyStrategiesAndSave() error {
 			continue
 		}
 
+		cfg, profile, err := s.profile(ticker)
+		if err != nil {
+			s.fail(ticker, fmt.Errorf("invalid strategy settings for %s: %w", ticker, err))
+			continue
+		}
+		if profile != "default" {
+			s.logger.Debug("Using strategy profile %s for %s", profile, ticker)
+		}
+
 		// Apply strategies
-		strategyData, err := s.applyTradingStrategies(filteredData)
+		builtin, _, err := Build(cfg, s.specs)
 		if err != nil {
-			s.logger.Error("Error applying strategies for %s: %v", ticker, err)
+			return err
+		}
+		strategyData, err := s.applyTradingStrategies(append(builtin, s.rules...), filteredData)
+		if err != nil {
+			s.fail(ticker, fmt.Errorf("error applying strategies for %s: %w", ticker, err))
 			continue
 		}
+		for _, d := range strategyData {
+			d.Profile = profile
+		}
+		s.addRelativeStrength(ticker, strategyData)
+		s.applySignalFilters(cfg.Filters, strategyData)
 
 		// Save strategies data
-		strategiesFilePath := fmt.Sprintf("Strategies_%s.csv", ticker)
+		strategiesFilePath := s.strategiesFile(ticker)
 		if err := s.saveStrategiesData(strategyData, strategiesFilePath); err != nil {
-			s.logger.Error("Error saving strategies for %s: %v", ticker, err)
+			s.fail(ticker, fmt.Errorf("error saving strategies for %s: %w", ticker, err))
 			continue
 		}
 
@@ -177,9 +252,9 @@ func (s *Strategies) ApplyStrategiesAndSave() error {
 	return nil
 }
 
-// ApplyAlternativeStrategyStates applies alternative strategy states
-func (s *Strategies) ApplyAlternativeStrategyStates() error {
-	s.logger.Info("Applying alternative strategy states")
+// ApplyConsensus adds the Consensus Strategy column to every strategy file
+func (s *Strategies) ApplyConsensus() error {
+	s.logger.Info("Applying consensus signals")
 
 	// Load tickers
 	tickers, err := common.LoadTickers("TICKERS.csv")
@@ -187,34 +262,38 @@ func (s *Strategies) ApplyAlternativeStrategyStates() error {
 		return fmt.Errorf("failed to load tickers: %w", err)
 	}
 
-	// List of all strategy columns
-	strategies := []string{
-		"RSI Strategy", "RSI Strategy2", "RSI14_OBV_RoC Strategy", "RSIMACD Strategy",
-		"RSICMF Strategy", "RSI OBV Strategy", "OBV Strategy", "MACD Strategy",
-		"CMF Strategy", "EMA5 PSAR Strategy", "EMA5 PSAR Strategy2",
-		"Rolling Std10 Strategy", "Rolling Std50 Strategy",
-	}
+	return s.ApplyConsensusFor(tickers)
+}
 
+// ApplyConsensusFor adds the Consensus Strategy column for the given tickers only
+func (s *Strategies) ApplyConsensusFor(tickers []string) error {
+	if err := s.loadStrategies(); err != nil {
+		return err
+	}
 	for _, ticker := range tickers {
-		strategiesFilePath := fmt.Sprintf("Strategies_%s.csv", ticker)
+		strategiesFilePath := s.strategiesFile(ticker)
+		if _, ok := s.failed[ticker]; ok {
+			continue
+		}
+		// Tickers without a session in the window have no strategy file
 		if _, err := os.Stat(strategiesFilePath); os.IsNotExist(err) {
-			s.logger.Error("Strategies_%s.csv does not exist", ticker)
+			s.logger.Warn("%s does not exist", strategiesFilePath)
 			continue
 		}
 
-		// Load and process alternative states
-		if err := s.processAlternativeStates(strategiesFilePath, strategies); err != nil {
-			s.logger.Error("Error processing alternative states for %s: %v", ticker, err)
+		if err := s.processConsensus(ticker, strategiesFilePath); err != nil {
+			s.fail(ticker, fmt.Errorf("error computing the consensus for %s: %w", ticker, err))
 			continue
 		}
 
-		s.logger.Info("Alternative strategy states applied for %s", ticker)
+		s.logger.Info("Consensus signals applied for %s", ticker)
 	}
 
 	return nil
 }
 
-// SummarizeStrategyActions summarizes strategy actions
+// SummarizeStrategyActions summarizes strategy actions and logs the signal changes of every ticker
+// in signal_events.csv, keeping the changes logged before each strategy file's first bar
 func (s *Strategies) SummarizeStrategyActions() error {
 	s.logger.Info("Summarizing strategy actions")
 
@@ -224,25 +303,64 @@ func (s *Strategies) SummarizeStrategyActions() error {
 		return fmt.Errorf("failed to load tickers: %w", err)
 	}
 
-	allSummaries := make(map[string]interface{})
+	eventsFilePath := SignalEventsFile(s.timeframe)
+	logged, err := LoadSignalEvents(eventsFilePath)
+	if err != nil && !os.IsNotExist(err) {
+		s.logger.Warn("Starting a new %s: %v", eventsFilePath, err)
+	}
+
+	specs, err := indicators.ConfiguredSpecs()
+	if err != nil {
+		return err
+	}
+	columns := eventColumns(specs)
+	byTicker := make(map[string][]SignalEvent)
+	for _, e := range logged {
+		byTicker[e.Ticker] = append(byTicker[e.Ticker], e)
+	}
 
+	loaded := make(map[string][]*StrategyData)
+	fresh := make(map[string][]SignalEvent)
+	firstBar := make(map[string]string)
 	for _, ticker := range tickers {
-		strategiesFilePath := fmt.Sprintf("Strategies_%s.csv", ticker)
+		strategiesFilePath := s.strategiesFile(ticker)
 		if _, err := os.Stat(strategiesFilePath); os.IsNotExist(err) {
 			continue
 		}
 
-		summary, err := s.generateStrategySummary(ticker, strategiesFilePath)
+		data, err := LoadStrategyFile(strategiesFilePath)
 		if err != nil {
 			s.logger.Error("Error generating summary for %s: %v", ticker, err)
 			continue
 		}
+		loaded[ticker] = data
+		if len(data) > 0 {
+			values, err := indicators.LoadIndicatorColumns(s.indicatorsFile(ticker), columns)
+			if err != nil {
+				s.logger.Warn("Logging the signal changes of %s without indicator values: %v", ticker, err)
+			}
+			fresh[ticker] = signalEvents(ticker, data, byTicker[ticker], values)
+			firstBar[ticker] = data[0].Date.Format("2006-01-02")
+		}
+	}
 
-		allSummaries[ticker] = summary
+	events := mergeSignalEvents(logged, fresh, firstBar)
+	if err := SaveSignalEvents(events, columns, eventsFilePath); err != nil {
+		return fmt.Errorf("failed to save signal events: %w", err)
+	}
+	s.logger.Info("Logged %d signal changes in %s", len(events), eventsFilePath)
+
+	byTicker = make(map[string][]SignalEvent)
+	for _, e := range events {
+		byTicker[e.Ticker] = append(byTicker[e.Ticker], e)
+	}
+	allSummaries := make(map[string]interface{})
+	for ticker, data := range loaded {
+		allSummaries[ticker] = s.generateStrategySummary(ticker, s.strategiesFile(ticker), data, byTicker[ticker])
 	}
 
 	// Save summary to file
-	summaryFilePath := "Strategy_Summary.json"
+	summaryFilePath := fmt.Sprintf("Strategy_Summary%s.json", s.timeframe.Suffix())
 	if err := s.saveSummaryToFile(allSummaries, summaryFilePath); err != nil {
 		return fmt.Errorf("failed to save strategy summary: %w", err)
 	}
@@ -253,346 +371,73 @@ func (s *Strategies) SummarizeStrategyActions() error {
 
 // loadIndicatorData loads indicator data from CSV file
 func (s *Strategies) loadIndicatorData(filePath string) ([]*indicators.StockDataWithIndicators, error) {
-	file, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	var stockData []*indicators.StockDataWithIndicators
-	if err := gocsv.UnmarshalFile(file, &stockData); err != nil {
-		return nil, err
-	}
-
-	return stockData, nil
+	return indicators.LoadIndicatorFile(filePath)
 }
 
-// applyTradingStrategies applies trading strategies to the data with intermediate states
-func (s *Strategies) applyTradingStrategies(data []*indicators.StockDataWithIndicators) ([]*StrategyData, error) {
-	var strategyData []*StrategyData
-
-	for _, stock := range data {
-		strategy := &StrategyData{
+// applyTradingStrategies evaluates every strategy over the bars
+func (s *Strategies) applyTradingStrategies(strategies []Strategy, data []*indicators.StockDataWithIndicators) ([]*StrategyData, error) {
+	strategyData := make([]*StrategyData, len(data))
+	for i, stock := range data {
+		strategyData[i] = &StrategyData{
 			StockDataWithIndicators: *stock,
-			// Initialize all strategies to "Hold"
-			RSIStrategy:          Hold,
-			RSIStrategy2:         Hold,
-			RSI14OBVRoCStrategy:  Hold,
-			RSIMACDStrategy:      Hold,
-			RSICMFStrategy:       Hold,
-			RSIOBVStrategy:       Hold,
-			OBVStrategy:          Hold,
-			MACDStrategy:         Hold,
-			CMFStrategy:          Hold,
-			EMA5PSARStrategy:     Hold,
-			EMA5PSARStrategy2:    Hold,
-			RollingStd10Strategy: Hold,
-			RollingStd50Strategy: Hold,
+			Signals:                 make(map[string]Signal, len(strategies)),
 		}
-		strategyData = append(strategyData, strategy)
 	}
 
-	// Apply all strategies with enhanced signal levels
-	s.applyRSIStrategy(strategyData)
-	s.applyMACDStrategy(strategyData)
-	s.applyCMFStrategy(strategyData)
-	s.applyOBVStrategy(strategyData)
-	s.applyEMA5PSARStrategy(strategyData)
-	s.applyRollingStdStrategies(strategyData)
-
-	return strategyData, nil
-}
-
-// applyRSIStrategy applies RSI-based strategies with intermediate states
-func (s *Strategies) applyRSIStrategy(data []*StrategyData) {
-	for _, d := range data {
-		rsi := d.RSI14
-		if rsi.IsZero() {
-			continue
-		}
-
-		// RSI Strategy: Enhanced with intermediate states
-		if rsi.LessThan(decimal.NewFromFloat(s.config.RSI.StrongBuy)) {
-			d.RSIStrategy = StrongBuy
-		} else if rsi.LessThan(decimal.NewFromFloat(s.config.RSI.Buy)) {
-			d.RSIStrategy = Buy
-		} else if rsi.LessThan(decimal.NewFromFloat(s.config.RSI.WeakBuy)) {
-			d.RSIStrategy = WeakBuy
-		} else if rsi.GreaterThan(decimal.NewFromFloat(s.config.RSI.StrongSell)) {
-			d.RSIStrategy = StrongSell
-		} else if rsi.GreaterThan(decimal.NewFromFloat(s.config.RSI.Sell)) {
-			d.RSIStrategy = Sell
-		} else if rsi.GreaterThan(decimal.NewFromFloat(s.config.RSI.WeakSell)) {
-			d.RSIStrategy = WeakSell
-		}
-
-		// RSI Strategy2: More conservative thresholds
-		if rsi.LessThan(decimal.NewFromFloat(s.config.RSI2.StrongBuy)) {
-			d.RSIStrategy2 = StrongBuy
-		} else if rsi.LessThan(decimal.NewFromFloat(s.config.RSI2.Buy)) {
-			d.RSIStrategy2 = Buy
-		} else if rsi.LessThan(decimal.NewFromFloat(s.config.RSI2.WeakBuy)) {
-			d.RSIStrategy2 = WeakBuy
-		} else if rsi.GreaterThan(decimal.NewFromFloat(s.config.RSI2.StrongSell)) {
-			d.RSIStrategy2 = StrongSell
-		} else if rsi.GreaterThan(decimal.NewFromFloat(s.config.RSI2.Sell)) {
-			d.RSIStrategy2 = Sell
-		} else if rsi.GreaterThan(decimal.NewFromFloat(s.config.RSI2.WeakSell)) {
-			d.RSIStrategy2 = WeakSell
-		}
-	}
-}
-
-// applyMACDStrategy applies MACD-based strategies with signal strength
-func (s *Strategies) applyMACDStrategy(data []*StrategyData) {
-	for _, d := range data {
-		macd := d.MACD
-		signal := d.MACDSignal
-		histogram := d.MACDHist
-
-		if macd.IsZero() || signal.IsZero() {
-			continue
-		}
-
-		// Calculate signal strength based on MACD histogram
-		histogramAbs := histogram.Abs()
-
-		// MACD Strategy: Enhanced with signal strength
-		if macd.GreaterThan(signal) {
-			if histogramAbs.GreaterThan(decimal.NewFromFloat(s.config.MACDHist.Strong)) {
-				d.MACDStrategy = StrongBuy
-			} else if histogramAbs.GreaterThan(decimal.NewFromFloat(s.config.MACDHist.Buy)) {
-				d.MACDStrategy = Buy
-			} else {
-				d.MACDStrategy = WeakBuy
-			}
-		} else if macd.LessThan(signal) {
-			if histogramAbs.GreaterThan(decimal.NewFromFloat(s.config.MACDHist.Strong)) {
-				d.MACDStrategy = StrongSell
-			} else if histogramAbs.GreaterThan(decimal.NewFromFloat(s.config.MACDHist.Buy)) {
-				d.MACDStrategy = Sell
-			} else {
-				d.MACDStrategy = WeakSell
-			}
-		}
-
-		// Combined RSI+MACD Strategy
-		rsi := d.RSI14
-		if !rsi.IsZero() {
-			if macd.GreaterThan(signal) && rsi.LessThan(decimal.NewFromInt(70)) {
-				if rsi.LessThan(decimal.NewFromInt(30)) && histogramAbs.GreaterThan(decimal.NewFromFloat(0.1)) {
-					d.RSIMACDStrategy = StrongBuy
-				} else if rsi.LessThan(decimal.NewFromInt(50)) {
-					d.RSIMACDStrategy = Buy
-				} else {
-					d.RSIMACDStrategy = WeakBuy
-				}
-			} else if macd.LessThan(signal) && rsi.GreaterThan(decimal.NewFromInt(30)) {
-				if rsi.GreaterThan(decimal.NewFromInt(70)) && histogramAbs.GreaterThan(decimal.NewFromFloat(0.1)) {
-					d.RSIMACDStrategy = StrongSell
-				} else if rsi.GreaterThan(decimal.NewFromInt(50)) {
-					d.RSIMACDStrategy = Sell
-				} else {
-					d.RSIMACDStrategy = WeakSell
+	for _, strategy := range strategies {
+		if r, ok := strategy.(reader); ok && len(data) > 0 {
+			for _, column := range r.reads() {
+				if !data[0].HasColumn(column) {
+					return nil, fmt.Errorf("%s reads %s, which the indicator file lacks; recalculate the indicators", strategy.Name(), column)
 				}
 			}
 		}
-	}
-}
-
-// applyCMFStrategy applies CMF-based strategies with enhanced signal levels
-func (s *Strategies) applyCMFStrategy(data []*StrategyData) {
-	for _, d := range data {
-		cmf := d.CMF
-		if cmf.IsZero() {
-			continue
+		signals := strategy.Evaluate(data)
+		if len(signals) != len(data) {
+			return nil, fmt.Errorf("%s returned %d signals for %d bars", strategy.Name(), len(signals), len(data))
 		}
-
-		// CMF Strategy: Enhanced money flow signals
-		if cmf.GreaterThan(decimal.NewFromFloat(s.config.CMF.StrongBuy)) {
-			d.CMFStrategy = StrongBuy
-		} else if cmf.GreaterThan(decimal.NewFromFloat(s.config.CMF.Buy)) {
-			d.CMFStrategy = Buy
-		} else if cmf.GreaterThan(decimal.NewFromFloat(s.config.CMF.WeakBuy)) {
-			d.CMFStrategy = WeakBuy
-		} else if cmf.LessThan(decimal.NewFromFloat(s.config.CMF.StrongSell)) {
-			d.CMFStrategy = StrongSell
-		} else if cmf.LessThan(decimal.NewFromFloat(s.config.CMF.Sell)) {
-			d.CMFStrategy = Sell
-		} else if cmf.LessThan(decimal.NewFromFloat(s.config.CMF.WeakSell)) {
-			d.CMFStrategy = WeakSell
-		}
-
-		// Combined RSI+CMF Strategy
-		rsi := d.RSI14
-		if !rsi.IsZero() {
-			if cmf.GreaterThan(decimal.NewFromFloat(s.config.CMF.Buy)) && rsi.LessThan(decimal.NewFromInt(70)) {
-				if rsi.LessThan(decimal.NewFromInt(30)) {
-					d.RSICMFStrategy = StrongBuy
-				} else if rsi.LessThan(decimal.NewFromInt(50)) {
-					d.RSICMFStrategy = Buy
-				} else {
-					d.RSICMFStrategy = WeakBuy
-				}
-			} else if cmf.LessThan(decimal.NewFromFloat(s.config.CMF.Sell)) && rsi.GreaterThan(decimal.NewFromInt(30)) {
-				if rsi.GreaterThan(decimal.NewFromInt(70)) {
-					d.RSICMFStrategy = StrongSell
-				} else if rsi.GreaterThan(decimal.NewFromInt(50)) {
-					d.RSICMFStrategy = Sell
-				} else {
-					d.RSICMFStrategy = WeakSell
-				}
-			}
+		for i, signal := range signals {
+			strategyData[i].Signals[strategy.Name()] = signal
 		}
 	}
+	return strategyData, nil
 }
 
-// applyOBVStrategy applies OBV-based strategies with volume momentum levels
-func (s *Strategies) applyOBVStrategy(data []*StrategyData) {
-	for _, d := range data {
-		obvRoc := d.OBVRoC
-		if obvRoc.IsZero() {
-			continue
-		}
-
-		// OBV Strategy: Enhanced volume momentum signals
-		if obvRoc.GreaterThan(decimal.NewFromFloat(s.config.OBVRoC.StrongBuy)) {
-			d.OBVStrategy = StrongBuy
-		} else if obvRoc.GreaterThan(decimal.NewFromFloat(s.config.OBVRoC.Buy)) {
-			d.OBVStrategy = Buy
-		} else if obvRoc.GreaterThan(decimal.NewFromFloat(s.config.OBVRoC.WeakBuy)) {
-			d.OBVStrategy = WeakBuy
-		} else if obvRoc.LessThan(decimal.NewFromFloat(s.config.OBVRoC.StrongSell)) {
-			d.OBVStrategy = StrongSell
-		} else if obvRoc.LessThan(decimal.NewFromFloat(s.config.OBVRoC.Sell)) {
-			d.OBVStrategy = Sell
-		} else if obvRoc.LessThan(decimal.NewFromFloat(s.config.OBVRoC.WeakSell)) {
-			d.OBVStrategy = WeakSell
-		}
-
-		// Combined RSI+OBV Strategy
-		rsi := d.RSI14
-		if !rsi.IsZero() {
-			if obvRoc.GreaterThan(decimal.NewFromFloat(s.config.OBVRoC.Buy)) && rsi.LessThan(decimal.NewFromInt(70)) {
-				if rsi.LessThan(decimal.NewFromInt(30)) {
-					d.RSIOBVStrategy = StrongBuy
-					d.RSI14OBVRoCStrategy = StrongBuy
-				} else if rsi.LessThan(decimal.NewFromInt(50)) {
-					d.RSIOBVStrategy = Buy
-					d.RSI14OBVRoCStrategy = Buy
-				} else {
-					d.RSIOBVStrategy = WeakBuy
-					d.RSI14OBVRoCStrategy = WeakBuy
-				}
-			} else if obvRoc.LessThan(decimal.NewFromFloat(s.config.OBVRoC.Sell)) && rsi.GreaterThan(decimal.NewFromInt(30)) {
-				if rsi.GreaterThan(decimal.NewFromInt(70)) {
-					d.RSIOBVStrategy = StrongSell
-					d.RSI14OBVRoCStrategy = StrongSell
-				} else if rsi.GreaterThan(decimal.NewFromInt(50)) {
-					d.RSIOBVStrategy = Sell
-					d.RSI14OBVRoCStrategy = Sell
-				} else {
-					d.RSIOBVStrategy = WeakSell
-					d.RSI14OBVRoCStrategy = WeakSell
-				}
-			}
-		}
+// addRelativeStrength copies the RS rank and beta of each bar's session from relative_<TICKER>.csv.
+// Weekly and monthly bars are dated by their last session, so they match too.
+func (s *Strategies) addRelativeStrength(ticker string, data []*StrategyData) {
+	records, err := indicators.LoadRelative(indicators.RelativeFile(ticker))
+	if err != nil {
+		s.logger.Debug("No relative strength for %s: %v", ticker, err)
+		return
+	}
+	byDate := make(map[string]*indicators.RelativeRecord, len(records))
+	for _, r := range records {
+		byDate[r.Date] = r
 	}
-}
-
-// applyEMA5PSARStrategy applies EMA5+PSAR trend following strategies
-func (s *Strategies) applyEMA5PSARStrategy(data []*StrategyData) {
 	for _, d := range data {
-		ema5 := d.EMA5
-		psar := d.PSAR
-		price := d.Close
-
-		if ema5.IsZero() || psar.IsZero() || price.IsZero() {
-			continue
-		}
-
-		// Calculate how far price is from EMA5 for signal strength
-		emaDistance := price.Sub(ema5).Div(ema5).Mul(decimal.NewFromInt(100))
-
-		// EMA5 PSAR Strategy: Enhanced trend following
-		if price.GreaterThan(ema5) && price.GreaterThan(psar) {
-			if emaDistance.GreaterThan(decimal.NewFromInt(5)) {
-				d.EMA5PSARStrategy = StrongBuy
-				d.EMA5PSARStrategy2 = StrongBuy
-			} else if emaDistance.GreaterThan(decimal.NewFromInt(2)) {
-				d.EMA5PSARStrategy = Buy
-				d.EMA5PSARStrategy2 = Buy
-			} else {
-				d.EMA5PSARStrategy = WeakBuy
-				d.EMA5PSARStrategy2 = WeakBuy
-			}
-		} else if price.LessThan(ema5) && price.LessThan(psar) {
-			if emaDistance.LessThan(decimal.NewFromInt(-5)) {
-				d.EMA5PSARStrategy = StrongSell
-				d.EMA5PSARStrategy2 = StrongSell
-			} else if emaDistance.LessThan(decimal.NewFromInt(-2)) {
-				d.EMA5PSARStrategy = Sell
-				d.EMA5PSARStrategy2 = Sell
-			} else {
-				d.EMA5PSARStrategy = WeakSell
-				d.EMA5PSARStrategy2 = WeakSell
-			}
+		if r, ok := byDate[d.Date.Format("2006-01-02")]; ok {
+			d.RSRank = decimal.NewFromFloat(r.RSRank)
+			d.Beta = decimal.NewFromFloat(r.Beta)
 		}
 	}
 }
 
-// applyRollingStdStrategies applies volatility-based strategies with band distance
-func (s *Strategies) applyRollingStdStrategies(data []*StrategyData) {
+// applySignalFilters turns buy signals into Hold on bars whose RS rank is below the configured
+// minimum or whose beta is above the configured maximum; bars without a value pass.
+// Filtered bars keep no buy signal, so the consensus of those bars cannot be a buy either.
+func (s *Strategies) applySignalFilters(filters common.SignalFilters, data []*StrategyData) {
+	minRank := decimal.NewFromFloat(filters.MinRSRank)
+	maxBeta := decimal.NewFromFloat(filters.MaxBeta)
 	for _, d := range data {
-		price := d.Close
-		sma10 := d.SMA10
-		sma50 := d.SMA50
-		std10 := d.RollingStd10
-		std50 := d.RollingStd50
-
-		if price.IsZero() || sma10.IsZero() || std10.IsZero() {
+		lagging := minRank.IsPositive() && d.RSRank.IsPositive() && d.RSRank.LessThan(minRank)
+		volatile := maxBeta.IsPositive() && !d.Beta.IsZero() && d.Beta.GreaterThan(maxBeta)
+		if !lagging && !volatile {
 			continue
 		}
-
-		// Rolling Std10 Strategy: Enhanced Bollinger Band-like signals
-		upperBand10 := sma10.Add(std10.Mul(decimal.NewFromInt(2)))
-		lowerBand10 := sma10.Sub(std10.Mul(decimal.NewFromInt(2)))
-		extremeUpper10 := sma10.Add(std10.Mul(decimal.NewFromFloat(2.5)))
-		extremeLower10 := sma10.Sub(std10.Mul(decimal.NewFromFloat(2.5)))
-
-		if price.LessThan(extremeLower10) {
-			d.RollingStd10Strategy = StrongBuy
-		} else if price.LessThan(lowerBand10) {
-			d.RollingStd10Strategy = Buy
-		} else if price.LessThan(sma10.Sub(std10.Mul(decimal.NewFromFloat(0.5)))) {
-			d.RollingStd10Strategy = WeakBuy
-		} else if price.GreaterThan(extremeUpper10) {
-			d.RollingStd10Strategy = StrongSell
-		} else if price.GreaterThan(upperBand10) {
-			d.RollingStd10Strategy = Sell
-		} else if price.GreaterThan(sma10.Add(std10.Mul(decimal.NewFromFloat(0.5)))) {
-			d.RollingStd10Strategy = WeakSell
-		}
-
-		// Rolling Std50 Strategy: Similar logic with SMA50
-		if !sma50.IsZero() && !std50.IsZero() {
-			upperBand50 := sma50.Add(std50.Mul(decimal.NewFromInt(2)))
-			lowerBand50 := sma50.Sub(std50.Mul(decimal.NewFromInt(2)))
-			extremeUpper50 := sma50.Add(std50.Mul(decimal.NewFromFloat(2.5)))
-			extremeLower50 := sma50.Sub(std50.Mul(decimal.NewFromFloat(2.5)))
-
-			if price.LessThan(extremeLower50) {
-				d.RollingStd50Strategy = StrongBuy
-			} else if price.LessThan(lowerBand50) {
-				d.RollingStd50Strategy = Buy
-			} else if price.LessThan(sma50.Sub(std50.Mul(decimal.NewFromFloat(0.5)))) {
-				d.RollingStd50Strategy = WeakBuy
-			} else if price.GreaterThan(extremeUpper50) {
-				d.RollingStd50Strategy = StrongSell
-			} else if price.GreaterThan(upperBand50) {
-				d.RollingStd50Strategy = Sell
-			} else if price.GreaterThan(sma50.Add(std50.Mul(decimal.NewFromFloat(0.5)))) {
-				d.RollingStd50Strategy = WeakSell
+		for name, signal := range d.Signals {
+			if signal.IsBuy() {
+				d.Signals[name] = Hold
 			}
 		}
 	}
@@ -600,109 +445,36 @@ func (s *Strategies) applyRollingStdStrategies(data []*StrategyData) {
 
 // saveStrategiesData saves strategy data to CSV file
 func (s *Strategies) saveStrategiesData(data []*StrategyData, filePath string) error {
-	file, err := os.Create(filePath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	return gocsv.MarshalFile(data, file)
+	return SaveStrategyFile(data, s.names(), filePath)
 }
 
-// processAlternativeStates processes alternative states for strategies
-func (s *Strategies) processAlternativeStates(filePath string, strategies []string) error {
-	s.logger.Info("Processing alternative states for file: %s", filePath)
+// processConsensus weighs the strategies of one file and writes their consensus next to them
+func (s *Strategies) processConsensus(ticker, filePath string) error {
+	s.logger.Info("Computing consensus for file: %s", filePath)
 
-	f, err := os.Open(filePath)
+	data, err := LoadStrategyFile(filePath)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
-
-	var data []*StrategyData
-	if err := gocsv.UnmarshalFile(f, &data); err != nil {
-		return err
-	}
-
-	for _, row := range data {
-		signals := []*string{
-			&row.RSIStrategy, &row.RSIStrategy2, &row.RSI14OBVRoCStrategy,
-			&row.RSIMACDStrategy, &row.RSICMFStrategy, &row.RSIOBVStrategy,
-			&row.OBVStrategy, &row.MACDStrategy, &row.CMFStrategy,
-			&row.EMA5PSARStrategy, &row.EMA5PSARStrategy2,
-			&row.RollingStd10Strategy, &row.RollingStd50Strategy,
-		}
-
-		buyCount := 0
-		sellCount := 0
-		for _, sig := range signals {
-			if strings.Contains(*sig, "Buy") {
-				buyCount++
-			} else if strings.Contains(*sig, "Sell") {
-				sellCount++
-			}
-		}
-
-		if buyCount >= 3 {
-			for _, sig := range signals {
-				if *sig == Hold {
-					*sig = WeakBuy
-				}
-			}
-		}
-
-		if sellCount >= 3 {
-			for _, sig := range signals {
-				if *sig == Hold {
-					*sig = WeakSell
-				}
-			}
-		}
-	}
-
-	out, err := os.Create(filePath)
+	cfg, _, err := s.profile(ticker)
 	if err != nil {
 		return err
 	}
-	defer out.Close()
-	return gocsv.MarshalFile(&data, out)
-}
-
-// generateStrategySummary generates a summary for a ticker's strategies
-func (s *Strategies) generateStrategySummary(ticker, filePath string) (map[string]interface{}, error) {
-	f, err := os.Open(filePath)
-	if err != nil {
-		return nil, err
-	}
-	defer f.Close()
 
-	var data []*StrategyData
-	if err := gocsv.UnmarshalFile(f, &data); err != nil {
-		return nil, err
-	}
+	s.applyConsensus(cfg.Consensus, data, s.consensusWeights(cfg.Consensus, ticker, data))
+	return SaveStrategyFile(data, append(s.names(), ConsensusName), filePath)
+}
 
+// generateStrategySummary summarizes the rows of a ticker's strategy file; events are its logged signal changes
+func (s *Strategies) generateStrategySummary(ticker, filePath string, data []*StrategyData, events []SignalEvent) map[string]interface{} {
 	stats := make(map[string]map[string]int)
 	for _, d := range data {
-		update := func(name, val string) {
+		for name, signal := range d.Signals {
 			if _, ok := stats[name]; !ok {
 				stats[name] = make(map[string]int)
 			}
-			stats[name][val]++
+			stats[name][string(signal)]++
 		}
-
-		update("RSI Strategy", d.RSIStrategy)
-		update("RSI Strategy2", d.RSIStrategy2)
-		update("RSI14_OBV_RoC Strategy", d.RSI14OBVRoCStrategy)
-		update("RSIMACD Strategy", d.RSIMACDStrategy)
-		update("RSICMF Strategy", d.RSICMFStrategy)
-		update("RSI OBV Strategy", d.RSIOBVStrategy)
-		update("OBV Strategy", d.OBVStrategy)
-		update("MACD Strategy", d.MACDStrategy)
-		update("CMF Strategy", d.CMFStrategy)
-		update("EMA5 PSAR Strategy", d.EMA5PSARStrategy)
-		update("EMA5 PSAR Strategy2", d.EMA5PSARStrategy2)
-		update("Rolling Std10 Strategy", d.RollingStd10Strategy)
-		update("Rolling Std50 Strategy", d.RollingStd50Strategy)
 	}
 
 	summary := map[string]interface{}{
@@ -711,7 +483,18 @@ func (s *Strategies) generateStrategySummary(ticker, filePath string) (map[strin
 		"stats":       stats,
 		"last_update": time.Now().Format("2006-01-02 15:04:05"),
 	}
-	return summary, nil
+	if len(data) > 0 {
+		last := data[len(data)-1]
+		summary["profile"] = last.Profile
+		summary["current"] = signalStates(data, events)
+		summary["consensus"] = map[string]interface{}{
+			"date":      last.Date.Format("2006-01-02"),
+			"signal":    last.Signals[ConsensusName],
+			"score":     last.ConsensusScore.InexactFloat64(),
+			"agreement": last.Agreement.InexactFloat64(),
+		}
+	}
+	return summary
 }
 
 // saveSummaryToFile saves summary data to JSON file
@@ -730,15 +513,33 @@ func (s *Strategies) saveSummaryToFile(summaries map[string]interface{}, filePat
 // StrategyTester handles strategy testing and backtesting
 type StrategyTester struct {
 	logger *common.Logger
+
+	// Optional override of the configured backtest tickers
+	tickers []string
 }
 
 // NewStrategyTester creates a new StrategyTester instance
-func NewStrategyTester() *StrategyTester {
+func NewStrategyTester(logger *common.Logger) *StrategyTester {
 	return &StrategyTester{
-		logger: common.NewLogger(),
+		logger: logger,
 	}
 }
 
+// SetTickers overrides the tickers included in the backtest
+func (st *StrategyTester) SetTickers(tickers []string) {
+	st.tickers = tickers
+}
+
+// checkBacktestStrategies rejects backtest strategies that are not among names
+func checkBacktestStrategies(strategies, names []string) error {
+	for _, name := range strategies {
+		if !slices.Contains(names, name) {
+			return fmt.Errorf("backtest.strategies: %q is not a strategy; use one of %s", name, strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
+
 // BacktestEngine represents the main backtesting engine
 type BacktestEngine struct {
 	config           common.BacktestConfig
@@ -746,19 +547,21 @@ type BacktestEngine struct {
 	trades           []common.Trade
 	positions        map[string]common.Position
 	portfolioHistory []common.Portfolio
+	peakValue        float64 // Highest total value so far, for the running drawdown
 	tradeCounter     int
 	logger           *common.Logger
 }
 
 // NewBacktestEngine creates a new backtesting engine
-func NewBacktestEngine(config common.BacktestConfig) *BacktestEngine {
+func NewBacktestEngine(logger *common.Logger, config common.BacktestConfig) *BacktestEngine {
 	return &BacktestEngine{
 		config:           config,
 		positions:        make(map[string]common.Position),
 		trades:           make([]common.Trade, 0),
 		portfolioHistory: make([]common.Portfolio, 0),
+		peakValue:        numeric.Float(config.InitialCash),
 		tradeCounter:     0,
-		logger:           common.NewLogger(),
+		logger:           logger,
 		portfolio: common.Portfolio{
 			Cash:       config.InitialCash,
 			TotalValue: config.InitialCash,
@@ -771,9 +574,11 @@ func (st *StrategyTester) BacktestAllStrategies() error {
 	st.logger.Info("Starting comprehensive backtesting of all strategies")
 
 	// Load configuration
-	config, err := st.loadBacktestConfig()
-	if err != nil {
-		return fmt.Errorf("failed to load backtest config: %w", err)
+	config := common.AppConfig.Backtest
+
+	// Apply command line overrides
+	if len(st.tickers) > 0 {
+		config.Tickers = st.tickers
 	}
 
 	// Load tickers if not specified in config
@@ -785,6 +590,19 @@ func (st *StrategyTester) BacktestAllStrategies() error {
 		config.Tickers = tickers
 	}
 
+	// A misspelt or renamed strategy would hold on every row and show no trades
+	names := append(Names(), ConsensusName)
+	rules, err := LoadRuleStrategies(common.AppConfig.Strategies.RulesFile, names)
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		names = append(names, rule.Name())
+	}
+	if err := checkBacktestStrategies(config.Strategies, names); err != nil {
+		return err
+	}
+
 	// Backtest each strategy
 	allResults := make(map[string]*common.BacktestResult)
 
@@ -806,24 +624,9 @@ func (st *StrategyTester) BacktestAllStrategies() error {
 	return st.saveBacktestResults(allResults)
 }
 
-// loadBacktestConfig loads the backtesting configuration
-func (st *StrategyTester) loadBacktestConfig() (common.BacktestConfig, error) {
-	var config common.BacktestConfig
-
-	file, err := os.Open("backtest_config.json")
-	if err != nil {
-		return config, err
-	}
-	defer file.Close()
-
-	decoder := json.NewDecoder(file)
-	err = decoder.Decode(&config)
-	return config, err
-}
-
 // backtestSingleStrategy backtests a single strategy across all tickers
 func (st *StrategyTester) backtestSingleStrategy(strategy string, tickers []string, config common.BacktestConfig) (*common.BacktestResult, error) {
-	engine := NewBacktestEngine(config)
+	engine := NewBacktestEngine(st.logger.WithStage("backtest").With("strategy", strategy), config)
 
 	// Combine all ticker data into chronological order
 	allData, err := st.loadAndMergeTickerData(tickers, strategy)
@@ -860,7 +663,7 @@ func (st *StrategyTester) loadAndMergeTickerData(tickers []string, strategy stri
 	for _, ticker := range tickers {
 		filePath := fmt.Sprintf("Strategies_%s.csv", ticker)
 		if _, err := os.Stat(filePath); os.IsNotExist(err) {
-			st.logger.Info("Strategy file not found for %s, skipping", ticker)
+			st.logger.Warn("Strategy file not found for %s, skipping", ticker)
 			continue
 		}
 
@@ -895,22 +698,19 @@ type StrategyDataPoint struct {
 
 // loadTickerStrategyData loads strategy data for a specific ticker
 func (st *StrategyTester) loadTickerStrategyData(filePath, ticker, strategy string) ([]StrategyDataPoint, error) {
-	file, err := os.Open(filePath)
+	strategyData, err := LoadStrategyFile(filePath)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
-
-	var strategyData []*StrategyData
-	if err := gocsv.UnmarshalFile(file, &strategyData); err != nil {
-		return nil, err
-	}
 
-	var dataPoints []StrategyDataPoint
-	for _, data := range strategyData {
-		signal := st.getStrategySignal(data, strategy)
+	return dataPoints(ticker, strategyData, strategy), nil
+}
 
-		dataPoint := StrategyDataPoint{
+// dataPoints turns the rows of one ticker into backtest data points carrying the signal of strategy
+func dataPoints(ticker string, rows []*StrategyData, strategy string) []StrategyDataPoint {
+	var points []StrategyDataPoint
+	for _, data := range rows {
+		points = append(points, StrategyDataPoint{
 			Ticker: ticker,
 			Date:   data.Date,
 			Open:   data.Open,
@@ -918,53 +718,26 @@ func (st *StrategyTester) loadTickerStrategyData(filePath, ticker, strategy stri
 			Low:    data.Low,
 			Close:  data.Close,
 			Volume: data.Volume,
-			Signal: signal,
-		}
-		dataPoints = append(dataPoints, dataPoint)
+			Signal: getStrategySignal(data, strategy),
+		})
 	}
-
-	return dataPoints, nil
+	return points
 }
 
-// getStrategySignal extracts the signal for a specific strategy
-func (st *StrategyTester) getStrategySignal(data *StrategyData, strategy string) string {
-	switch strategy {
-	case "RSI Strategy":
-		return data.RSIStrategy
-	case "RSI Strategy2":
-		return data.RSIStrategy2
-	case "MACD Strategy":
-		return data.MACDStrategy
-	case "CMF Strategy":
-		return data.CMFStrategy
-	case "OBV Strategy":
-		return data.OBVStrategy
-	case "RSI OBV Strategy":
-		return data.RSIOBVStrategy
-	case "RSIMACD Strategy":
-		return data.RSIMACDStrategy
-	case "RSICMF Strategy":
-		return data.RSICMFStrategy
-	case "EMA5 PSAR Strategy":
-		return data.EMA5PSARStrategy
-	case "EMA5 PSAR Strategy2":
-		return data.EMA5PSARStrategy2
-	case "Rolling Std10 Strategy":
-		return data.RollingStd10Strategy
-	case "Rolling Std50 Strategy":
-		return data.RollingStd50Strategy
-	case "RSI14_OBV_RoC Strategy":
-		return data.RSI14OBVRoCStrategy
-	default:
-		return Hold
+// getStrategySignal extracts the signal for a specific strategy; unknown strategies always hold
+func getStrategySignal(data *StrategyData, strategy string) string {
+	if signal, ok := data.Signals[strategy]; ok && signal != "" {
+		return string(signal)
 	}
+	return string(Hold)
 }
 
 // filterByDateRange filters data points by date range
 func (st *StrategyTester) filterByDateRange(data []StrategyDataPoint, startDate, endDate time.Time) []StrategyDataPoint {
 	var filtered []StrategyDataPoint
 	for _, point := range data {
-		if point.Date.After(startDate) && point.Date.Before(endDate) {
+		// A zero bound leaves that side of the range open
+		if (startDate.IsZero() || point.Date.After(startDate)) && (endDate.IsZero() || point.Date.Before(endDate)) {
 			filtered = append(filtered, point)
 		}
 	}
@@ -1217,19 +990,11 @@ func (be *BacktestEngine) updatePortfolioMetrics() {
 
 	be.portfolio.TotalReturn = be.portfolio.TotalValue.Sub(be.config.InitialCash).Div(be.config.InitialCash).Mul(decimal.NewFromInt(100))
 
-	// Calculate drawdown
-	if len(be.portfolioHistory) > 0 {
-		maxValue := be.config.InitialCash
-		for _, portfolio := range be.portfolioHistory {
-			if portfolio.TotalValue.GreaterThan(maxValue) {
-				maxValue = portfolio.TotalValue
-			}
-		}
-		if be.portfolio.TotalValue.GreaterThan(maxValue) {
-			maxValue = be.portfolio.TotalValue
-		}
-
-		be.portfolio.Drawdown = be.portfolio.TotalValue.Sub(maxValue).Div(maxValue).Mul(decimal.NewFromInt(100))
+	// Calculate drawdown against the running peak
+	totalValue := numeric.Float(be.portfolio.TotalValue)
+	be.peakValue = math.Max(be.peakValue, totalValue)
+	if len(be.portfolioHistory) > 0 && be.peakValue != 0 {
+		be.portfolio.Drawdown = numeric.Decimal((totalValue - be.peakValue) / be.peakValue * 100)
 	}
 }
 
@@ -1333,85 +1098,21 @@ func (be *BacktestEngine) calculatePerformanceMetrics(strategy string) *common.B
 
 // calculateMaxDrawdown calculates the maximum drawdown
 func (be *BacktestEngine) calculateMaxDrawdown() decimal.Decimal {
-	if len(be.portfolioHistory) == 0 {
-		return decimal.Zero
-	}
-
-	maxDrawdown := decimal.Zero
-	peak := be.portfolioHistory[0].TotalValue
-
-	for _, portfolio := range be.portfolioHistory {
-		if portfolio.TotalValue.GreaterThan(peak) {
-			peak = portfolio.TotalValue
-		}
-
-		drawdown := peak.Sub(portfolio.TotalValue).Div(peak).Mul(decimal.NewFromInt(100))
-		if drawdown.GreaterThan(maxDrawdown) {
-			maxDrawdown = drawdown
-		}
-	}
-
-	return maxDrawdown
+	return numeric.Decimal(numeric.MaxDrawdown(be.equityCurve()))
 }
 
-// calculateSharpeRatio calculates the Sharpe ratio
+// calculateSharpeRatio calculates the Sharpe ratio (assuming 252 trading days)
 func (be *BacktestEngine) calculateSharpeRatio() decimal.Decimal {
-	if len(be.portfolioHistory) < 2 {
-		return decimal.Zero
-	}
-
-	// Calculate daily returns
-	var returns []decimal.Decimal
-	for i := 1; i < len(be.portfolioHistory); i++ {
-		prevValue := be.portfolioHistory[i-1].TotalValue
-		currValue := be.portfolioHistory[i].TotalValue
-		if !prevValue.IsZero() {
-			dailyReturn := currValue.Sub(prevValue).Div(prevValue)
-			returns = append(returns, dailyReturn)
-		}
-	}
-
-	if len(returns) == 0 {
-		return decimal.Zero
-	}
-
-	// Calculate mean return
-	sum := decimal.Zero
-	for _, ret := range returns {
-		sum = sum.Add(ret)
-	}
-	meanReturn := sum.Div(decimal.NewFromInt(int64(len(returns))))
-
-	// Calculate standard deviation
-	varianceSum := decimal.Zero
-	for _, ret := range returns {
-		diff := ret.Sub(meanReturn)
-		varianceSum = varianceSum.Add(diff.Mul(diff))
-	}
-
-	if len(returns) <= 1 {
-		return decimal.Zero
-	}
-
-	variance := varianceSum.Div(decimal.NewFromInt(int64(len(returns) - 1)))
-
-	// Convert to float for sqrt calculation
-	varianceFloat, _ := variance.Float64()
-	if varianceFloat <= 0 {
-		return decimal.Zero
-	}
-
-	stdDev := decimal.NewFromFloat(math.Sqrt(varianceFloat))
+	return numeric.Decimal(numeric.SharpeRatio(numeric.Returns(be.equityCurve()), 252))
+}
 
-	if stdDev.IsZero() {
-		return decimal.Zero
+// equityCurve returns the total portfolio value of every snapshot
+func (be *BacktestEngine) equityCurve() []float64 {
+	values := make([]float64, len(be.portfolioHistory))
+	for i, portfolio := range be.portfolioHistory {
+		values[i] = numeric.Float(portfolio.TotalValue)
 	}
-
-	// Annualized Sharpe ratio (assuming 252 trading days)
-	annualizedReturn := meanReturn.Mul(decimal.NewFromInt(252))
-	annualizedVolatility := stdDev.Mul(decimal.NewFromFloat(math.Sqrt(252)))
-
-	return annualizedReturn.Div(annualizedVolatility)
+	return values
 }
 
 // saveDetailedResults saves detailed backtesting results
@@ -0,0 +1,116 @@
+package strategies
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/gocarina/gocsv"
+)
+
+// signalWriter receives the rows gocsv writes for StrategyData and adds the signal
+// columns before RS_Rank, so the file keeps the layout of the fixed-column era
+type signalWriter struct {
+	*csv.Writer
+	data  []*StrategyData
+	names []string
+	at    int // index of RS_Rank in the gocsv row, -1 before the header is seen
+	row   int
+}
+
+func (w *signalWriter) Write(record []string) error {
+	if w.at < 0 {
+		w.at = slices.Index(record, "RS_Rank")
+		if w.at < 0 {
+			return fmt.Errorf("strategy header has no RS_Rank column")
+		}
+		return w.Writer.Write(slices.Insert(slices.Clone(record), w.at, w.names...))
+	}
+	signals := make([]string, len(w.names))
+	for k, name := range w.names {
+		signals[k] = string(w.data[w.row].Signals[name])
+	}
+	w.row++
+	return w.Writer.Write(slices.Insert(slices.Clone(record), w.at, signals...))
+}
+
+// SaveStrategyFile writes the rows with one signal column per name
+func SaveStrategyFile(data []*StrategyData, names []string, filePath string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	w := &signalWriter{Writer: csv.NewWriter(file), data: data, names: names, at: -1}
+	return gocsv.MarshalCSV(&data, w)
+}
+
+// LoadStrategyFile reads a strategy file; every column that is not a StrategyData field is a signal column
+func LoadStrategyFile(filePath string) ([]*StrategyData, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []*StrategyData
+	if err := gocsv.UnmarshalBytes(content, &data); err != nil {
+		return nil, err
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) != len(data)+1 {
+		return nil, fmt.Errorf("%s: %d records for %d rows", filePath, len(records), len(data))
+	}
+
+	fields, err := Header(nil)
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int)
+	for k, column := range records[0] {
+		if !slices.Contains(fields, column) {
+			columns[column] = k
+		}
+	}
+	for i, row := range data {
+		row.Signals = make(map[string]Signal, len(columns))
+		for name, k := range columns {
+			row.Signals[name] = Signal(records[i+1][k])
+		}
+	}
+	return data, nil
+}
+
+// Header returns the columns of a strategy file with a signal column for each of names
+func Header(names []string) ([]string, error) {
+	var buf bytes.Buffer
+	w := &signalWriter{Writer: csv.NewWriter(&buf), names: names, at: -1}
+	if err := gocsv.MarshalCSV(&[]*StrategyData{}, w); err != nil {
+		return nil, err
+	}
+	return csv.NewReader(&buf).Read()
+}
+
+// MarshalJSON writes the row with each signal under its strategy name
+func (d *StrategyData) MarshalJSON() ([]byte, error) {
+	type plain StrategyData
+	out, err := json.Marshal((*plain)(d))
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(out, &fields); err != nil {
+		return nil, err
+	}
+	for name, signal := range d.Signals {
+		fields[name], _ = json.Marshal(signal)
+	}
+	return json.Marshal(fields)
+}
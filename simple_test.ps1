@@ -41,9 +41,9 @@ function Test-Mode {
     
     try {
         if ($Args) {
-            $result = & ".\isx-auto-scrapper.exe" --mode $Mode $Args 2>&1
+            $result = & ".\isx-auto-scrapper.exe" $Mode $Args 2>&1
         } else {
-            $result = & ".\isx-auto-scrapper.exe" --mode $Mode 2>&1
+            $result = & ".\isx-auto-scrapper.exe" $Mode 2>&1
         }
         
         if ($LASTEXITCODE -eq 0) {
@@ -84,7 +84,7 @@ if (Test-Path "Strategy_Summary.json") {
 # Test 2: Calculate Mode
 Write-Host ""
 Write-Host "=== Phase 2: Calculate Mode Test ===" -ForegroundColor Yellow
-$calcResult = Test-Mode "calculate" "Calculate Mode" $TestTicker
+$calcResult = Test-Mode "calc" "Calculate Mode" $TestTicker
 $testResults += $calcResult
 
 if ($calcResult) {
@@ -95,7 +95,7 @@ if ($calcResult) {
 # Test 3: Calculate_Num Mode
 Write-Host ""
 Write-Host "=== Phase 3: Calculate_Num Mode Test ===" -ForegroundColor Yellow
-$calcNumResult = Test-Mode "calculate_num" "Calculate_Num Mode" $TestTicker
+$calcNumResult = Test-Mode "calc" "Calculate_Num Mode" @("--numeric", $TestTicker)
 $testResults += $calcNumResult
 
 if ($calcNumResult) {
@@ -118,7 +118,7 @@ $testResults += $strategiesResult
 # Test 6: Simulate Mode
 Write-Host ""
 Write-Host "=== Phase 6: Simulate Mode Test ===" -ForegroundColor Yellow
-$simulateResult = Test-Mode "simulate" "Simulate Mode"
+$simulateResult = Test-Mode "backtest" "Backtest Mode"
 $testResults += $simulateResult
 
 if ($simulateResult) {
@@ -79,7 +79,7 @@ Write-Host "--------------------------------" -ForegroundColor $InfoColor
 # Test Single Mode (optional)
 if (-not $SkipSingle) {
     Write-Host "Testing Single Mode..." -ForegroundColor $InfoColor
-    $singleResult = & ".\isx-auto-scrapper.exe" --mode single 2>&1
+    $singleResult = & ".\isx-auto-scrapper.exe" fetch $TestTicker 2>&1
     if ($LASTEXITCODE -eq 0) {
         $rawFile = "raw_$TestTicker.csv"
         Test-CSVContent $rawFile 100
@@ -91,7 +91,7 @@ if (-not $SkipSingle) {
 # Test Calculate Mode
 Write-Host "Testing Calculate Mode..." -ForegroundColor $InfoColor
 try {
-    $calculateResult = & ".\isx-auto-scrapper.exe" --mode calculate $TestTicker 2>&1
+    $calculateResult = & ".\isx-auto-scrapper.exe" calc $TestTicker 2>&1
     if ($LASTEXITCODE -eq 0) {
         $indicatorFile = "indicators_$TestTicker.csv"
         Test-CSVContent $indicatorFile 100
@@ -112,7 +112,7 @@ try {
 # Test Calculate_Num Mode
 Write-Host "Testing Calculate_Num Mode..." -ForegroundColor $InfoColor
 try {
-    $calculateNumResult = & ".\isx-auto-scrapper.exe" --mode calculate_num $TestTicker 2>&1
+    $calculateNumResult = & ".\isx-auto-scrapper.exe" calc --numeric $TestTicker 2>&1
     if ($LASTEXITCODE -eq 0) {
         $indicators2File = "Indicators2_$TestTicker.csv"
         Test-CSVContent $indicators2File 100
@@ -134,7 +134,7 @@ try {
 # Test Liquidity Mode
 Write-Host "Testing Liquidity Mode..." -ForegroundColor $InfoColor
 try {
-    $liquidityResult = & ".\isx-auto-scrapper.exe" --mode liquidity 2>&1
+    $liquidityResult = & ".\isx-auto-scrapper.exe" liquidity 2>&1
     if ($LASTEXITCODE -eq 0) {
         Test-CSVContent "liquidity_scores.csv" 10
     } else {
@@ -152,7 +152,7 @@ Write-Host "------------------------------------" -ForegroundColor $InfoColor
 # Test Strategies Mode
 Write-Host "Testing Strategies Mode..." -ForegroundColor $InfoColor
 try {
-    $strategiesResult = & ".\isx-auto-scrapper.exe" --mode strategies 2>&1
+    $strategiesResult = & ".\isx-auto-scrapper.exe" strategies 2>&1
     if ($LASTEXITCODE -eq 0) {
         $strategyFiles = Get-ChildItem "strategies_*.csv" -ErrorAction SilentlyContinue
         Write-TestResult "Strategies Mode - Files Created" ($strategyFiles.Count -gt 0) "$($strategyFiles.Count) strategy files"
@@ -167,7 +167,7 @@ try {
 # Test Simulate Mode
 Write-Host "Testing Simulate Mode..." -ForegroundColor $InfoColor
 try {
-    $simulateResult = & ".\isx-auto-scrapper.exe" --mode simulate 2>&1
+    $simulateResult = & ".\isx-auto-scrapper.exe" backtest 2>&1
     if ($LASTEXITCODE -eq 0) {
         Write-TestResult "Simulate Mode" $true "Backtesting completed"
         
@@ -203,10 +203,10 @@ try {
         }
         
         # Validate configuration
-        if (Test-Path "backtest_config.json") {
+        if (Test-Path "isx.yaml") {
             try {
-                $config = Get-Content "backtest_config.json" | ConvertFrom-Json
-                Write-TestResult "Backtest Config Valid" ($config.initial_cash -gt 0) "Initial cash: $($config.initial_cash)"
+                $config = (& ".\isx-auto-scrapper.exe" config show -o json 2>$null | ConvertFrom-Json).details.config.backtest
+                Write-TestResult "Backtest Config Valid" ([decimal]$config.initial_cash -gt 0) "Initial cash: $($config.initial_cash)"
                 Write-TestResult "Backtest Strategies Config" ($config.strategies.Count -gt 0) "$($config.strategies.Count) strategies configured"
             } catch {
                 Write-TestResult "Backtest Config Validation" $false $_.Exception.Message
@@ -4,7 +4,10 @@ let displayedData = [];
 let sortColumn = 'date';
 let sortAsc = false;
 let currentChart = null;
+let currentProfile = null;
+let currentRelative = [];
 let selectedSymbol = '';
+let currentTimeframe = 'D';
 let selectedRow = null;
 
 // Debug function
@@ -37,6 +40,7 @@ document.addEventListener('DOMContentLoaded', async function() {
     document.getElementById('liqBtn').addEventListener('click', runLiquidity);
     document.getElementById('stratBtn').addEventListener('click', runStrategies);
     document.getElementById('backtestBtn').addEventListener('click', runBacktest);
+    document.getElementById('timeframeSelect').addEventListener('change', changeTimeframe);
 
     document.getElementById('fetchCancelBtn').addEventListener('click', closeFetchModal);
     document.getElementById('fetchConfirmBtn').addEventListener('click', confirmFetch);
@@ -217,6 +221,14 @@ function sortAndDisplay() {
     populateTickerTable(displayedData);
 }
 
+// Redraw the selected ticker on daily, weekly or monthly bars
+async function changeTimeframe(event) {
+    currentTimeframe = event.target.value;
+    if (selectedSymbol) {
+        await selectTicker(selectedSymbol);
+    }
+}
+
 // Handle ticker selection
 async function selectTicker(symbol) {
     selectedSymbol = symbol;
@@ -240,13 +252,16 @@ async function selectTicker(symbol) {
     
     // Create chart using the exact pattern from the Highcharts sample
     await createChart(symbol);
+    renderRelativeInfo(currentRelative[currentRelative.length - 1]);
 
     // Fetch latest strategy signals
     try {
-        const res = await fetch(`/api/ticker/${symbol}?type=strategies`);
+        const res = await fetch(`/api/ticker/${symbol}?type=strategies&tf=${currentTimeframe}`);
         if (res.ok) {
             const data = await res.json();
             renderStrategySignals(data.signals);
+        } else {
+            renderStrategySignals(null);
         }
     } catch (err) {
         console.log('Strategy fetch error', err);
@@ -281,6 +296,25 @@ function updateSelectedTickerInfo(ticker) {
     infoDiv.classList.add('show');
 }
 
+// Add the latest RS rank, beta and correlation to the selected ticker panel
+function renderRelativeInfo(record) {
+    const details = document.querySelector('#selectedTickerInfo .ticker-details');
+    if (!details) return;
+    const old = details.querySelector('.ticker-relative');
+    if (old) old.remove();
+    if (!record) return;
+
+    const value = (v, digits) => v ? v.toFixed(digits) : '-';
+    const div = document.createElement('div');
+    div.className = 'ticker-relative';
+    div.innerHTML = `
+        <div class="ticker-ohlc">RS Rank: ${value(record.rs_rank, 0)}</div>
+        <div class="ticker-ohlc">Beta: ${value(record.beta, 2)} Corr: ${value(record.correlation, 2)}</div>
+        <div class="ticker-ohlc">RS vs market: ${value(record.rs_market, 1)} vs sector: ${value(record.rs_sector, 1)}</div>
+    `;
+    details.appendChild(div);
+}
+
 // Create chart - EXACTLY matching the Highcharts sample pattern
 async function createChart(symbol) {
     debugLog('Creating chart for: ' + symbol);
@@ -292,10 +326,14 @@ async function createChart(symbol) {
             currentChart.destroy();
             currentChart = null;
         }
+        currentRelative = [];
         
         // Fetch price data
-        const data = await fetch(`/api/ticker/${symbol}?type=price`).then(r=>r.json());
+        const data = await fetch(`/api/ticker/${symbol}?type=price&tf=${currentTimeframe}`).then(r=>r.json());
         debugLog('Loaded data points: ' + data.length);
+        currentProfile = await loadVolumeProfile(symbol);
+        const patternFlags = await loadPatternFlags(symbol);
+        currentRelative = await loadRelativeStrength(symbol);
         if(!Array.isArray(data) || data.length===0) throw new Error('No data');
 
         const ohlcData = [];
@@ -305,21 +343,40 @@ async function createChart(symbol) {
             ohlcData.push([ts, item.open, item.high, item.low, item.close]);
             volumeData.push([ts, item.volume || 0]);
         });
+
+        // Relative strength gets a third pane below volume when it has been calculated
+        const rsMarket = [];
+        const rsSector = [];
+        currentRelative.forEach(r => {
+            const ts = Date.parse(r.date);
+            if (r.rs_market) rsMarket.push([ts, r.rs_market]);
+            if (r.rs_sector) rsSector.push([ts, r.rs_sector]);
+        });
+        const hasRelative = rsMarket.length > 0;
         
         currentChart = Highcharts.stockChart('container', {
             yAxis: [{
                 labels: { align: 'right', x: -3 },
                 title: { text: 'Price' },
-                height: '70%',
+                height: hasRelative ? '55%' : '70%',
                 lineWidth: 1,
                 resize: { enabled:true }
             }, {
                 labels: { align: 'right', x: -3 },
                 title: { text: 'Volume' },
-                top: '75%',
-                height: '25%',
+                top: hasRelative ? '58%' : '75%',
+                height: hasRelative ? '17%' : '25%',
                 offset: 0,
                 lineWidth: 1
+            }, {
+                labels: { align: 'right', x: -3 },
+                title: { text: 'RS' },
+                top: '78%',
+                height: '22%',
+                offset: 0,
+                lineWidth: 1,
+                visible: hasRelative,
+                plotLines: [{ value: 100, color: '#9e9e9e', dashStyle: 'Dash', width: 1 }]
             }],
             series: [{
                 id: 'main',
@@ -336,6 +393,32 @@ async function createChart(symbol) {
                 yAxis: 1,
                 color: 'rgba(0, 0, 150, 0.3)',
                 dataGrouping: { enabled:false }
+            }, {
+                id: 'patterns',
+                type: 'flags',
+                name: 'Candlestick patterns',
+                onSeries: 'main',
+                shape: 'circlepin',
+                width: 16,
+                data: patternFlags
+            }, {
+                id: 'rs-market',
+                type: 'line',
+                name: 'RS vs market',
+                data: rsMarket,
+                yAxis: 2,
+                visible: hasRelative,
+                showInLegend: false,
+                dataGrouping: { enabled:false }
+            }, {
+                id: 'rs-sector',
+                type: 'line',
+                name: 'RS vs sector',
+                data: rsSector,
+                yAxis: 2,
+                visible: rsSector.length > 0,
+                showInLegend: false,
+                dataGrouping: { enabled:false }
             }],
             stockTools: {
                 gui: {
@@ -349,7 +432,10 @@ async function createChart(symbol) {
             scrollbar: { enabled:true },
             credits: { enabled:false },
             colors: ['#2d5016', '#6b9b37', '#FF6F6F', '#8bc34a', '#4a7c23'],
-            chart: { backgroundColor:'rgba(0,0,0,0)' }
+            chart: {
+                backgroundColor:'rgba(0,0,0,0)',
+                events: { render: function() { drawVolumeProfile(this); } }
+            }
         });
 
         debugLog('Chart created successfully');
@@ -367,6 +453,106 @@ async function createChart(symbol) {
     }
 }
 
+// Load the volume traded at each price; the chart is drawn without it on failure
+async function loadVolumeProfile(symbol) {
+    try {
+        const res = await fetch(`/api/ticker/${symbol}?type=profile&tf=${currentTimeframe}`);
+        if (!res.ok) throw new Error('Volume profile not found: ' + res.status);
+        const data = await res.json();
+        debugLog('Loaded volume profile bins: ' + data.profile.bins.length);
+        return data.profile;
+    } catch (err) {
+        debugLog('Volume profile error: ' + err.message);
+        return null;
+    }
+}
+
+// Load candlestick patterns as chart flags, one per bar, coloured by the bias of its strongest pattern;
+// the chart is drawn without them on failure
+async function loadPatternFlags(symbol) {
+    try {
+        const res = await fetch(`/api/ticker/${symbol}?type=patterns&tf=${currentTimeframe}`);
+        if (!res.ok) throw new Error('Patterns not found: ' + res.status);
+        const data = await res.json();
+        const byDate = new Map();
+        data.patterns.forEach(p => {
+            if (!byDate.has(p.date)) byDate.set(p.date, []);
+            byDate.get(p.date).push(p);
+        });
+        const colors = { bullish: '#6FB76F', bearish: '#FF6F6F', neutral: '#9e9e9e' };
+        const titles = { bullish: '▲', bearish: '▼', neutral: '◆' };
+        const flags = [];
+        byDate.forEach((patterns, date) => {
+            patterns.sort((a, b) => b.strength - a.strength);
+            flags.push({
+                x: Date.parse(date),
+                title: titles[patterns[0].bias],
+                text: patterns.map(p => `${p.pattern} (${p.bias}, strength ${p.strength})`).join('<br>'),
+                fillColor: colors[patterns[0].bias]
+            });
+        });
+        debugLog('Loaded pattern flags: ' + flags.length);
+        return flags;
+    } catch (err) {
+        debugLog('Patterns error: ' + err.message);
+        return [];
+    }
+}
+
+// Load relative strength against the market and sector peers; the chart is drawn without it on failure
+async function loadRelativeStrength(symbol) {
+    try {
+        const res = await fetch(`/api/ticker/${symbol}?type=relative&tf=${currentTimeframe}`);
+        if (!res.ok) throw new Error('Relative strength not found: ' + res.status);
+        const data = await res.json();
+        const records = data.records || [];
+        debugLog('Loaded relative strength rows: ' + records.length);
+        return records;
+    } catch (err) {
+        debugLog('Relative strength error: ' + err.message);
+        return [];
+    }
+}
+
+// Draw volume-at-price bars along the right edge of the price pane, split into up and down volume,
+// with a dashed line at the point of control. Redrawn on every render so it follows zoom and resize.
+function drawVolumeProfile(chart) {
+    if (chart.volumeProfileGroup) {
+        chart.volumeProfileGroup.destroy();
+        chart.volumeProfileGroup = null;
+    }
+    if (!currentProfile || currentProfile.bins.length === 0) return;
+
+    const axis = chart.yAxis[0];
+    const maxVolume = Math.max(...currentProfile.bins.map(bin => bin.volume));
+    if (!maxVolume) return;
+
+    const right = chart.plotLeft + chart.plotWidth;
+    const maxWidth = chart.plotWidth * 0.2;
+    const group = chart.renderer.g('volume-profile').attr({ zIndex: 3 }).add();
+
+    currentProfile.bins.forEach(bin => {
+        const top = Math.max(axis.toPixels(bin.high), axis.top);
+        const bottom = Math.min(axis.toPixels(bin.low), axis.top + axis.height);
+        if (bottom <= top || !bin.volume) return;
+
+        const width = maxWidth * bin.volume / maxVolume;
+        const upWidth = width * bin.up_volume / bin.volume;
+        const height = Math.max(bottom - top - 1, 1);
+        chart.renderer.rect(right - width, top, upWidth, height)
+            .attr({ fill: 'rgba(111, 183, 111, 0.35)' }).add(group);
+        chart.renderer.rect(right - width + upWidth, top, width - upWidth, height)
+            .attr({ fill: 'rgba(255, 111, 111, 0.35)' }).add(group);
+    });
+
+    const poc = axis.toPixels(currentProfile.poc);
+    if (poc >= axis.top && poc <= axis.top + axis.height) {
+        chart.renderer.path(['M', right - maxWidth, poc, 'L', right, poc])
+            .attr({ stroke: '#2d5016', 'stroke-width': 1, dashstyle: 'Dash' }).add(group);
+    }
+    chart.volumeProfileGroup = group;
+}
+
 // Clear chart
 function clearChart() {
     if (currentChart) {
@@ -484,7 +670,7 @@ async function loadIndicatorData(symbol = selectedSymbol) {
         return;
     }
     try {
-        const res = await fetch(`/api/ticker/${symbol}?type=indicators`);
+        const res = await fetch(`/api/ticker/${symbol}?type=indicators&tf=${currentTimeframe}`);
         if (res.ok) {
             const data = await res.json();
             debugLog('Indicators reloaded for ' + symbol);
@@ -13,16 +13,42 @@ function applyDefaultSort(arr) {
     });
 }
 
+// Language from the page URL (?lang=ar); the server's labels replace the English defaults
+const reportLang = new URLSearchParams(window.location.search).get('lang') || '';
+const langQuery = reportLang ? `?lang=${encodeURIComponent(reportLang)}` : '';
+let labels = {};
+
+function L(key, fallback) {
+    return labels[key] || fallback;
+}
+
+function col(name) {
+    return L('report.column.' + name, name);
+}
+
+// Apply the report language to the page direction and its static text
+function applyLanguage(data) {
+    labels = data.labels || {};
+    const lang = data.language || 'en';
+    document.documentElement.lang = lang;
+    document.documentElement.dir = lang === 'ar' ? 'rtl' : 'ltr';
+    document.title = L('report.page_title', document.title);
+    document.querySelectorAll('[data-i18n]').forEach(el => {
+        el.textContent = L(el.dataset.i18n, el.textContent);
+    });
+}
+
 async function loadReport() {
     const container = document.getElementById('reportContent');
     if (container) container.innerHTML = '<p style="padding:1rem;">Loading daily report…</p>'; // placeholder
 
     try {
-        const res = await fetch('/api/daily_report');
+        const res = await fetch('/api/daily_report' + langQuery);
         if (!res.ok) {
             throw new Error(`Server responded ${res.status}`);
         }
         const data = await res.json();
+        applyLanguage(data);
         renderReport(data);
     } catch (err) {
         console.error('Failed to load daily report', err);
@@ -34,7 +60,7 @@ document.addEventListener('DOMContentLoaded', () => {
     loadReport();
 
     const dl = document.getElementById('downloadReport');
-    if (dl) dl.addEventListener('click', () => { window.location = '/api/daily_report_excel'; });
+    if (dl) dl.addEventListener('click', () => { window.location = '/api/daily_report_excel' + langQuery; });
 
     setupRowInteractions();
 });
@@ -52,15 +78,15 @@ function buildTopTable(rows, metric = 'volume') {
     let colLabel = '';
     let cellRenderer = () => '';
     if (metric === 'volume') {
-        colLabel = 'Volume';
+        colLabel = col('Volume');
         cellRenderer = r => fmtInt(r.volume);
     } else if (metric === 'value') {
-        colLabel = 'Value';
+        colLabel = col('Value');
         cellRenderer = r => fmtFloat(r.value);
     }
 
     // Build table header
-    let headerHtml = '<tr><th>Ticker</th><th>Name</th><th>Close</th><th>Change%</th>';
+    let headerHtml = `<tr><th>${col('Ticker')}</th><th>${col('Name')}</th><th>${col('Close')}</th><th>${col('Change%')}</th>`;
     if (metric !== 'none') {
         headerHtml += `<th>${colLabel}</th>`;
     }
@@ -86,10 +112,10 @@ function renderReport(data) {
 
     const topCardsHtml = `
         <section class="report-cards">
-            ${buildTopCard('Top 5 by Volume', data.top_volume, 'chart-volume', 'volume')}
-            ${buildTopCard('Top 5 by Value', data.top_value, 'chart-value', 'value')}
-            ${buildTopCard('Top 5 Gainers', data.top_gain, 'chart-gainers', 'none')}
-            ${buildTopCard('Top 5 Losers', data.top_loss, 'chart-losers', 'none')}
+            ${buildTopCard(L('report.top_volume', 'Top 5 by Volume'), data.top_volume, 'chart-volume', 'volume')}
+            ${buildTopCard(L('report.top_value', 'Top 5 by Value'), data.top_value, 'chart-value', 'value')}
+            ${buildTopCard(L('report.top_gain', 'Top 5 Gainers'), data.top_gain, 'chart-gainers', 'none')}
+            ${buildTopCard(L('report.top_loss', 'Top 5 Losers'), data.top_loss, 'chart-losers', 'none')}
         </section>`;
 
     const tradedSorted = sortTraded([...data.traded]);
@@ -97,32 +123,51 @@ function renderReport(data) {
 
     container.innerHTML = `
         <div class="report-header-section">
-            <h2>Daily Report – ${data.date}</h2>
+            <h2>${L('report.title', 'Daily Report – %s').replace('%s', data.date)}</h2>
         </div>
         ${topCardsHtml}
         <section class="report-section">
-            <h3>Traded Companies</h3>
+            <h3>${L('report.traded', 'Traded Companies')}</h3>
             ${buildCompanyTable(tradedSorted)}
         </section>
         <section class="report-section">
-            <h3>Non-Traded Companies</h3>
+            <h3>${L('report.non_traded', 'Non-Traded Companies')}</h3>
             ${buildNonTradedTable(nonSorted)}
+        </section>
+        <section class="report-section">
+            <h3>${L('report.divergences', 'Divergences Today')}</h3>
+            ${buildDivergenceTable(data.divergences)}
         </section>`;
 
     createSparklines(tradedSorted);
     createSparklines(nonSorted);
 
-    drawTopPie('chart-volume', 'Volume Share', data.top_volume, 'volume');
-    drawTopPie('chart-value', 'Value Share', data.top_value, 'value');
-    drawTopPie('chart-gainers', 'Gain %', data.top_gain, 'change_pct');
-    drawTopPie('chart-losers', 'Loss %', data.top_loss, 'change_pct');
+    drawTopPie('chart-volume', L('report.volume_share', 'Volume Share'), data.top_volume, 'volume');
+    drawTopPie('chart-value', L('report.value_share', 'Value Share'), data.top_value, 'value');
+    drawTopPie('chart-gainers', L('report.gain_pct', 'Gain %'), data.top_gain, 'change_pct');
+    drawTopPie('chart-losers', L('report.loss_pct', 'Loss %'), data.top_loss, 'change_pct');
+}
+
+function buildDivergenceTable(rows) {
+    if (!Array.isArray(rows) || rows.length === 0) {
+        return `<p>${L('report.no_divergences', 'No divergences confirmed in this session.')}</p>`;
+    }
+    const header = ['Ticker', 'Name', 'Indicator', 'Type', 'Bias', 'From', 'To', 'Price', 'Strength'].map(c => `<th>${col(c)}</th>`).join('');
+    let html = `<table class="simple-table interactive"><thead><tr>${header}</tr></thead><tbody>`;
+    rows.forEach(r => {
+        const biasCls = r.bias === 'bullish' ? 'positive' : 'negative';
+        html += `<tr data-ticker="${r.ticker}"><td>${r.ticker}</td><td>${r.name}</td><td>${r.indicator}</td><td>${L('report.value.' + r.type, r.type)}</td><td class="${biasCls}">${L('report.value.' + r.bias, r.bias)}</td><td>${r.start}</td><td>${r.end}</td><td>${fmtFloat(r.start_price)} → ${fmtFloat(r.end_price)}</td><td>${fmtInt(r.strength)}</td></tr>`;
+    });
+    html += '</tbody></table>';
+    return html;
 }
 
 function buildCompanyTable(rows) {
     if (!Array.isArray(rows) || rows.length === 0) {
-        return '<p>No data available.</p>';
+        return `<p>${L('report.no_data', 'No data available.')}</p>`;
     }
-    let html = '<table class="simple-table interactive"><thead><tr><th>Code</th><th>Name</th><th>Open</th><th>High</th><th>Low</th><th>Avg</th><th>Prev Avg</th><th>Close</th><th>Prev Close</th><th>Change%</th><th>Trades</th><th>Volume</th><th>Value</th><th></th></tr></thead><tbody>';
+    const header = ['Code', 'Name', 'Open', 'High', 'Low', 'Avg', 'Prev Avg', 'Close', 'Prev Close', 'Change%', 'Trades', 'Volume', 'Value'].map(c => `<th>${col(c)}</th>`).join('');
+    let html = `<table class="simple-table interactive"><thead><tr>${header}<th></th></tr></thead><tbody>`;
     rows.forEach(r => {
         const cp = (r.change_pct ?? 0);
         const changeCls = cp >= 0 ? 'positive' : 'negative';
@@ -135,9 +180,10 @@ function buildCompanyTable(rows) {
 
 function buildNonTradedTable(rows) {
     if (!Array.isArray(rows) || rows.length === 0) {
-        return '<p>No data available.</p>';
+        return `<p>${L('report.no_data', 'No data available.')}</p>`;
     }
-    let html = '<table class="simple-table interactive"><thead><tr><th>Code</th><th>Name</th><th>Last Traded</th><th>Open</th><th>High</th><th>Low</th><th>Avg</th><th>Close</th><th>Trades</th><th>Volume</th><th>Value</th><th></th></tr></thead><tbody>';
+    const header = ['Code', 'Name', 'Last Traded', 'Open', 'High', 'Low', 'Avg', 'Close', 'Trades', 'Volume', 'Value'].map(c => `<th>${col(c)}</th>`).join('');
+    let html = `<table class="simple-table interactive"><thead><tr>${header}<th></th></tr></thead><tbody>`;
     rows.forEach(r => {
         html += `<tr data-ticker="${r.code}"><td>${r.code}</td><td>${r.name}</td><td>${r.last_traded || '-'}</td><td>${fmtFloat(r.open)}</td><td>${fmtFloat(r.high)}</td><td>${fmtFloat(r.low)}</td><td>${fmtFloat(r.avg_price)}</td><td>${fmtFloat(r.close)}</td><td>${fmtInt(r.trades)}</td><td>${fmtInt(r.volume)}</td><td>${fmtFloat(r.value)}</td><td><div class="sparkline" id="spark-${r.code}"></div></td></tr>`;
     });